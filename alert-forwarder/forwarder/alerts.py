@@ -41,6 +41,26 @@ def create_alert_description(koney_alert: KoneyAlert) -> str:
         namespaced_pod_name = f"{namespace}/{pod}" if namespace and pod else "?"
         return f"Access to honeytoken ({file_path}) in pod ({namespaced_pod_name}) detected"
 
+    if koney_alert["trap_type"] == "network_honeypot":
+        metadata = koney_alert.get("metadata", {})
+        namespace = (koney_alert.get("pod", {}) or {}).get("namespace", "?")
+        client_pod = metadata.get("client_pod") or {}
+        if client_pod:
+            client_name = f"{client_pod.get('namespace')}/{client_pod.get('name')}"
+        else:
+            client_name = metadata.get("client_ip", "?")
+        return f"Connection to network honeypot in namespace ({namespace}) from ({client_name}) detected"
+
+    if koney_alert["trap_type"] == "envvar_honeytoken":
+        metadata = koney_alert.get("metadata", {})
+        env_var_name = metadata.get("env_var_name", "?")
+        pod = (koney_alert.get("pod", {}) or {}).get("name")
+        namespace = (koney_alert.get("pod", {}) or {}).get("namespace")
+        namespaced_pod_name = f"{namespace}/{pod}" if namespace and pod else "?"
+        if metadata.get("detection") == "process_arguments":
+            return f"Use of honeytoken environment variable ({env_var_name}) in pod ({namespaced_pod_name}) detected"
+        return f"Read of process environment with honeytoken ({env_var_name}) in pod ({namespaced_pod_name}) detected"
+
     return "Koney alert triggered"
 
 
@@ -72,6 +92,8 @@ def map_to_dynatrace_event(
         "koney.deception_policy_name": koney_alert["deception_policy_name"],
         "koney.trap_type": koney_alert["trap_type"],
         "koney.metadata.file_path": koney_alert.get("metadata", {}).get("file_path"),
+        "koney.metadata.client_ip": koney_alert.get("metadata", {}).get("client_ip"),
+        "koney.metadata.env_var_name": koney_alert.get("metadata", {}).get("env_var_name"),
         # event metadata
         "event.kind": "SECURITY_EVENT",
         "event.type": "DETECTION_FINDING",
@@ -0,0 +1,98 @@
+# Copyright (c) 2025 Dynatrace LLC
+#
+# This program is free software: you can redistribute it and/or modify
+# it under the terms of the GNU Affero General Public License as published by
+# the Free Software Foundation, either version 3 of the License, or
+# (at your option) any later version.
+#
+# This program is distributed in the hope that it will be useful,
+# but WITHOUT ANY WARRANTY; without even the implied warranty of
+# MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+# GNU Affero General Public License for more details.
+#
+# You should have received a copy of the GNU Affero General Public License
+# along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+import base64
+import hashlib
+import hmac
+import os
+import time
+from typing import Literal, cast
+
+from kubernetes import client
+
+from .sink import KONEY_NAMESPACE
+
+# the secret (and its key) that holds the HMAC key shared with the Koney operator
+WEBHOOK_AUTH_SECRET_NAME = "koney-webhook-auth"
+WEBHOOK_AUTH_SECRET_KEY = "hmac-key"
+
+# "enforce" rejects unauthenticated webhook calls, "permissive" only counts them,
+# which helps to migrate TracingPolicies that were created before webhooks were signed
+WebhookAuthMode = Literal["enforce", "permissive"]
+WEBHOOK_AUTH_MODE: WebhookAuthMode = cast(
+    WebhookAuthMode,
+    os.environ.get("KONEY_WEBHOOK_AUTH_MODE", "enforce"),
+)
+
+# the minimum number of seconds between two reads of the key from the cluster,
+# so that requests with invalid signatures cannot make us spam the Kubernetes API
+KEY_REFRESH_INTERVAL_SECONDS = 30
+
+_cached_key: bytes | None = None
+_cached_key_read_at = 0.0
+
+
+def verify_signature(deception_policy_name: str | None, signature: str | None) -> bool:
+    if not deception_policy_name or not signature:
+        return False
+
+    # the key is cached, but re-read if the signature does not match (e.g., rotation)
+    key = _get_key(refresh=False)
+    if key and _is_valid_signature(key, deception_policy_name, signature):
+        return True
+
+    key = _get_key(refresh=True)
+    if key is None:
+        return False
+    return _is_valid_signature(key, deception_policy_name, signature)
+
+
+def is_enforced() -> bool:
+    return WEBHOOK_AUTH_MODE != "permissive"
+
+
+###############################################################################
+
+
+def _is_valid_signature(key: bytes, message: str, signature: str) -> bool:
+    expected = hmac.new(key, message.encode("utf-8"), hashlib.sha256).hexdigest()
+    return hmac.compare_digest(expected, signature)
+
+
+def _get_key(refresh: bool) -> bytes | None:
+    global _cached_key, _cached_key_read_at
+
+    now = time.monotonic()
+    if _cached_key is not None and not refresh:
+        return _cached_key
+    if refresh and now - _cached_key_read_at < KEY_REFRESH_INTERVAL_SECONDS:
+        return _cached_key
+
+    _cached_key_read_at = now
+    _cached_key = _read_key()
+    return _cached_key
+
+
+def _read_key() -> bytes | None:
+    api = client.CoreV1Api()
+    secret = cast(
+        client.V1Secret,
+        api.read_namespaced_secret(WEBHOOK_AUTH_SECRET_NAME, KONEY_NAMESPACE),
+    )
+
+    if not secret.data or not secret.data.get(WEBHOOK_AUTH_SECRET_KEY):
+        return None
+
+    return base64.b64decode(secret.data[WEBHOOK_AUTH_SECRET_KEY])
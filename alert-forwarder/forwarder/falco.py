@@ -0,0 +1,108 @@
+# Copyright (c) 2025 Dynatrace LLC
+#
+# This program is free software: you can redistribute it and/or modify
+# it under the terms of the GNU Affero General Public License as published by
+# the Free Software Foundation, either version 3 of the License, or
+# (at your option) any later version.
+#
+# This program is distributed in the hope that it will be useful,
+# but WITHOUT ANY WARRANTY; without even the implied warranty of
+# MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+# GNU Affero General Public License for more details.
+#
+# You should have received a copy of the GNU Affero General Public License
+# along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+import re
+from typing import Any
+
+from pydantic import BaseModel, ConfigDict
+
+from .types import (
+    ContainerMetadata,
+    KoneyAlert,
+    NodeMetadata,
+    PodMetadata,
+    ProcessMetadata,
+)
+
+# all Falco rules created by Koney have this tag
+FALCO_KONEY_TAG = "koney"
+# the tag prefix that references the deception policy in a Falco rule
+FALCO_DECEPTION_POLICY_REF = "koney/deception-policy="
+
+
+class FalcoEvent(BaseModel):
+    # Falco sends more fields (e.g., priority, source), which we do not need
+    model_config = ConfigDict(extra="allow")
+
+    rule: str
+    time: str | None = None
+    hostname: str | None = None
+    tags: list[str] = []
+    output_fields: dict[str, Any] = {}
+
+
+def map_falco_event(event: FalcoEvent) -> KoneyAlert | None:
+    tags = event.tags
+    if FALCO_KONEY_TAG not in tags:
+        return None  # not an alert of a rule created by Koney
+
+    deception_policy_name = None
+    for tag in tags:
+        if tag.startswith(FALCO_DECEPTION_POLICY_REF):
+            deception_policy_name = tag.removeprefix(FALCO_DECEPTION_POLICY_REF)
+
+    fields = event.output_fields
+
+    # Falco only monitors file-open events of filesystem honeytokens
+    return KoneyAlert(
+        timestamp=_normalize_timestamp(event.time),
+        deception_policy_name=deception_policy_name,
+        trap_type="filesystem_honeytoken",
+        metadata=dict(file_path=fields.get("fd.name")),
+        pod=_extract_pod_metadata(fields),
+        node=NodeMetadata(name=event.hostname) if event.hostname else None,
+        process=_extract_process_metadata(fields),
+    )
+
+
+###############################################################################
+
+
+def _normalize_timestamp(timestamp: str | None) -> str | None:
+    if timestamp is None:
+        return None
+    # remove the fractional seconds, so that timestamps look like the ones of Tetragon alerts
+    return re.sub(r"(\d{2}:\d{2}:\d{2})\.\d+", r"\1", timestamp)
+
+
+def _extract_pod_metadata(fields: dict) -> PodMetadata | None:
+    if not fields.get("k8s.pod.name"):
+        return None
+
+    return PodMetadata(
+        name=fields.get("k8s.pod.name"),
+        namespace=fields.get("k8s.ns.name"),
+        container=ContainerMetadata(
+            id=fields.get("container.id"),
+            name=fields.get("container.name"),
+        ),
+    )
+
+
+def _extract_process_metadata(fields: dict) -> ProcessMetadata | None:
+    if fields.get("proc.pid") is None:
+        return None
+
+    # the command line starts with the process name, but we only want the arguments
+    cmdline = fields.get("proc.cmdline") or ""
+    arguments = cmdline.split(" ", 1)[1] if " " in cmdline else ""
+
+    return ProcessMetadata(
+        uid=fields.get("user.uid"),
+        pid=fields.get("proc.pid"),
+        cwd=fields.get("proc.cwd"),
+        binary=fields.get("proc.exepath"),
+        arguments=arguments,
+    )
@@ -17,17 +17,48 @@ import json
 import logging
 import time
 
-from fastapi import BackgroundTasks, FastAPI, Response, status
+from fastapi import BackgroundTasks, FastAPI, Request, Response, status
+from fastapi.exception_handlers import request_validation_exception_handler
+from fastapi.exceptions import RequestValidationError
 from kubernetes import config
+from prometheus_client import make_asgi_app
 from rich.console import Console
 
-from .sink import read_alert_sinks, send_alert
+from .auth import is_enforced, verify_signature
+from .falco import FalcoEvent, map_falco_event
+from .metrics import (
+    ALERTS,
+    ERRORS,
+    INVALID_REQUEST_ERROR_REASON,
+    K8S_AUTH_ERROR_REASON,
+    K8S_CONFIG_SINK_READ_ERROR_REASON,
+    K8S_POLICY_SINK_READ_ERROR_REASON,
+    K8S_SINK_READ_ERROR_REASON,
+    REQUESTS,
+    SINK_SEND_ERROR_REASON,
+    WEBHOOK_KEY_READ_ERROR_REASON,
+    WEBHOOK_SIGNATURE_ERROR_REASON,
+)
+from .operator_alert import OperatorEvent, map_operator_event
+from .plugin import PluginEvent, map_plugin_event
+from .sidecar import SidecarEvent, map_sidecar_event
+from .sink import (
+    read_alert_sinks,
+    read_config_alert_sinks,
+    read_policy_alert_sinks,
+    send_alert,
+)
 from .tetragon import is_filtered_alert, map_tetragon_event, read_tetragon_events
+from .types import AlertSink, KoneyAlert
 
 # various error messages
 K8S_AUTH_ERROR = "failed to authenticate with Kubernetes API"
 K8S_SINK_READ_ERROR = "failed to read DeceptionAlertSink objects"
+K8S_POLICY_SINK_READ_ERROR = "failed to read alert webhooks of DeceptionPolicy"
+K8S_CONFIG_SINK_READ_ERROR = "failed to read alert webhooks of KoneyConfig"
 SINK_SEND_ERROR = "failed to send alert to external system"
+WEBHOOK_KEY_READ_ERROR = "failed to read the webhook authentication key"
+WEBHOOK_SIGNATURE_ERROR = "webhook call without valid signature"
 
 # the delay after receiving a (possibly multiple) triggers until we start loading alerts (once)
 DEBOUNCE_SECONDS = 5
@@ -36,25 +67,163 @@ app = FastAPI(docs_url=None, redoc_url=None, openapi_url=None)
 logger = logging.getLogger("uvicorn.error")
 console = Console()
 
+# expose counters of requests, errors, and alerts for Prometheus
+app.mount("/metrics", make_asgi_app())
+
 # global variable to remember when any handler was last triggered
 most_recent_trigger = 0
 
 
 @app.get("/handlers/tetragon", status_code=status.HTTP_202_ACCEPTED)
-def handle_tetragon(response: Response, background_tasks: BackgroundTasks):
+def handle_tetragon(
+    response: Response,
+    background_tasks: BackgroundTasks,
+    policy: str | None = None,
+    signature: str | None = None,
+):
     global most_recent_trigger
     trigger_time = time.time()
 
     if not authenticate_kubernetes():
+        REQUESTS.labels(handler="tetragon", outcome="unavailable").inc()
         response.status_code = status.HTTP_401_UNAUTHORIZED
         return dict(message=K8S_AUTH_ERROR)
 
+    # Tetragon calls the URL that was signed by Koney when creating the TracingPolicy
+    if not authenticate_webhook(policy, signature):
+        REQUESTS.labels(handler="tetragon", outcome="unauthorized").inc()
+        response.status_code = status.HTTP_401_UNAUTHORIZED
+        return dict(message=WEBHOOK_SIGNATURE_ERROR)
+
+    REQUESTS.labels(handler="tetragon", outcome="accepted").inc()
+
     # enqueue a background task to load new alerts,
     # which will be debounced automatically
     most_recent_trigger = trigger_time
     background_tasks.add_task(load_new_alerts, timestamp=trigger_time)
 
 
+@app.post("/handlers/falco", status_code=status.HTTP_202_ACCEPTED)
+def handle_falco(
+    event: FalcoEvent, response: Response, background_tasks: BackgroundTasks
+):
+    if not authenticate_kubernetes():
+        REQUESTS.labels(handler="falco", outcome="unavailable").inc()
+        response.status_code = status.HTTP_401_UNAUTHORIZED
+        return dict(message=K8S_AUTH_ERROR)
+
+    REQUESTS.labels(handler="falco", outcome="accepted").inc()
+
+    # Falco pushes every alert individually, so there is nothing to debounce
+    background_tasks.add_task(load_falco_alert, event=event)
+
+
+def load_falco_alert(event: FalcoEvent):
+    koney_alert = map_falco_event(event)
+    if koney_alert is None:
+        return  # not an alert of a rule created by Koney
+
+    if is_filtered_alert(koney_alert):
+        if logger.level <= logging.DEBUG:
+            console.print(f"Skipping event ", koney_alert)
+        return
+
+    forward_alert(koney_alert, load_alert_sinks(), {})
+
+
+@app.post("/handlers/sidecar", status_code=status.HTTP_202_ACCEPTED)
+def handle_sidecar(
+    event: SidecarEvent,
+    response: Response,
+    background_tasks: BackgroundTasks,
+    policy: str | None = None,
+    signature: str | None = None,
+):
+    if not authenticate_kubernetes():
+        REQUESTS.labels(handler="sidecar", outcome="unavailable").inc()
+        response.status_code = status.HTTP_401_UNAUTHORIZED
+        return dict(message=K8S_AUTH_ERROR)
+
+    # the signed policy must be the one of the reported trap,
+    # otherwise a sidecar could report alerts in the name of other policies
+    if policy != event.trap.deception_policy_name or not authenticate_webhook(
+        policy, signature
+    ):
+        REQUESTS.labels(handler="sidecar", outcome="unauthorized").inc()
+        response.status_code = status.HTTP_401_UNAUTHORIZED
+        return dict(message=WEBHOOK_SIGNATURE_ERROR)
+
+    REQUESTS.labels(handler="sidecar", outcome="accepted").inc()
+
+    # sidecar captors report every access individually, so there is nothing to debounce
+    background_tasks.add_task(load_sidecar_alert, event=event)
+
+
+def load_sidecar_alert(event: SidecarEvent):
+    koney_alert = map_sidecar_event(event)
+    forward_alert(koney_alert, load_alert_sinks(), {})
+
+
+@app.post("/handlers/plugin", status_code=status.HTTP_202_ACCEPTED)
+def handle_plugin(
+    event: PluginEvent,
+    response: Response,
+    background_tasks: BackgroundTasks,
+    policy: str | None = None,
+    signature: str | None = None,
+):
+    if not authenticate_kubernetes():
+        REQUESTS.labels(handler="plugin", outcome="unavailable").inc()
+        response.status_code = status.HTTP_401_UNAUTHORIZED
+        return dict(message=K8S_AUTH_ERROR)
+
+    # like sidecars, plugins must only report alerts of the policy that they were given a URL for
+    if policy != event.deception_policy_name or not authenticate_webhook(
+        policy, signature
+    ):
+        REQUESTS.labels(handler="plugin", outcome="unauthorized").inc()
+        response.status_code = status.HTTP_401_UNAUTHORIZED
+        return dict(message=WEBHOOK_SIGNATURE_ERROR)
+
+    REQUESTS.labels(handler="plugin", outcome="accepted").inc()
+    background_tasks.add_task(load_plugin_alert, event=event)
+
+
+def load_plugin_alert(event: PluginEvent):
+    koney_alert = map_plugin_event(event)
+    forward_alert(koney_alert, load_alert_sinks(), {})
+
+
+@app.post("/handlers/operator", status_code=status.HTTP_202_ACCEPTED)
+def handle_operator(
+    event: OperatorEvent,
+    response: Response,
+    background_tasks: BackgroundTasks,
+    policy: str | None = None,
+    signature: str | None = None,
+):
+    if not authenticate_kubernetes():
+        REQUESTS.labels(handler="operator", outcome="unavailable").inc()
+        response.status_code = status.HTTP_401_UNAUTHORIZED
+        return dict(message=K8S_AUTH_ERROR)
+
+    # the operator signs the URL for the policy of the alert, just like for sidecars and plugins
+    if policy != event.deception_policy_name or not authenticate_webhook(
+        policy, signature
+    ):
+        REQUESTS.labels(handler="operator", outcome="unauthorized").inc()
+        response.status_code = status.HTTP_401_UNAUTHORIZED
+        return dict(message=WEBHOOK_SIGNATURE_ERROR)
+
+    REQUESTS.labels(handler="operator", outcome="accepted").inc()
+    background_tasks.add_task(load_operator_alert, event=event)
+
+
+def load_operator_alert(event: OperatorEvent):
+    koney_alert = map_operator_event(event)
+    forward_alert(koney_alert, load_alert_sinks(), {})
+
+
 def load_new_alerts(timestamp: float):
     global most_recent_trigger
     time.sleep(DEBOUNCE_SECONDS)
@@ -69,13 +238,10 @@ def load_new_alerts(timestamp: float):
         return
 
     # resolve alert sinks
-    alert_sinks = []
-    try:
-        alert_sinks = read_alert_sinks()
-    except:
-        if logger.level <= logging.ERROR:
-            console.print(K8S_SINK_READ_ERROR, style="bold red")
-            console.print_exception()
+    alert_sinks = load_alert_sinks()
+
+    # alert webhooks of individual deception policies, resolved on demand
+    policy_alert_sinks: dict[str, list[AlertSink]] = {}
 
     # iterate over Tetragon events, map, log, and send alerts
     for policy_name, events in events_per_policy.items():
@@ -89,18 +255,53 @@ def load_new_alerts(timestamp: float):
                     console.print(f"Skipping event ", koney_alert)
                 continue
 
-            # write to stdout
-            koney_alert_str = json.dumps(koney_alert)
-            console.print(koney_alert_str, soft_wrap=True)
+            forward_alert(koney_alert, alert_sinks, policy_alert_sinks)
 
-            # send to external systems
-            for sink in alert_sinks:
-                try:
-                    send_alert(koney_alert, sink)
-                except:
-                    if logger.level <= logging.ERROR:
-                        console.print(SINK_SEND_ERROR, style="bold red")
-                        console.print_exception()
+
+def load_alert_sinks() -> list[AlertSink]:
+    alert_sinks = []
+    try:
+        alert_sinks += read_alert_sinks()
+    except:
+        report_error(K8S_SINK_READ_ERROR_REASON, K8S_SINK_READ_ERROR)
+
+    # webhooks of the KoneyConfig receive the alerts of all policies
+    try:
+        alert_sinks += read_config_alert_sinks()
+    except:
+        report_error(K8S_CONFIG_SINK_READ_ERROR_REASON, K8S_CONFIG_SINK_READ_ERROR)
+
+    return alert_sinks
+
+
+def forward_alert(
+    koney_alert: KoneyAlert,
+    alert_sinks: list[AlertSink],
+    policy_alert_sinks: dict[str, list[AlertSink]],
+):
+    # write to stdout
+    koney_alert_str = json.dumps(koney_alert)
+    console.print(koney_alert_str, soft_wrap=True)
+    ALERTS.labels(trap_type=koney_alert["trap_type"]).inc()
+
+    # resolve the webhooks of the deception policy that created the trap
+    deception_policy_name = koney_alert["deception_policy_name"]
+    if deception_policy_name and deception_policy_name not in policy_alert_sinks:
+        policy_alert_sinks[deception_policy_name] = []
+        try:
+            policy_alert_sinks[deception_policy_name] = read_policy_alert_sinks(
+                deception_policy_name
+            )
+        except:
+            report_error(K8S_POLICY_SINK_READ_ERROR_REASON, K8S_POLICY_SINK_READ_ERROR)
+
+    # send to external systems
+    policy_sinks = policy_alert_sinks.get(deception_policy_name or "", [])
+    for sink in alert_sinks + policy_sinks:
+        try:
+            send_alert(koney_alert, sink)
+        except:
+            report_error(SINK_SEND_ERROR_REASON, SINK_SEND_ERROR)
 
 
 @app.get("/healthz", status_code=status.HTTP_204_NO_CONTENT)
@@ -116,7 +317,37 @@ def authenticate_kubernetes() -> bool:
         config.load_incluster_config()
         return True
     except config.config_exception.ConfigException:
-        if logger.level <= logging.ERROR:
-            console.print(K8S_AUTH_ERROR, style="bold red")
-            console.print_exception()
+        report_error(K8S_AUTH_ERROR_REASON, K8S_AUTH_ERROR)
         return False
+
+
+def authenticate_webhook(policy: str | None, signature: str | None) -> bool:
+    try:
+        valid = verify_signature(policy, signature)
+    except:
+        report_error(WEBHOOK_KEY_READ_ERROR_REASON, WEBHOOK_KEY_READ_ERROR)
+        valid = False
+
+    if not valid:
+        ERRORS.labels(reason=WEBHOOK_SIGNATURE_ERROR_REASON).inc()
+        if logger.level <= logging.WARNING:
+            message = f"{WEBHOOK_SIGNATURE_ERROR} (policy={policy})"
+            console.print(message, style="yellow")
+
+    # in permissive mode, unauthenticated calls are only counted and logged
+    return valid or not is_enforced()
+
+
+@app.exception_handler(RequestValidationError)
+async def handle_invalid_request(request: Request, exc: RequestValidationError):
+    handler = request.url.path.removeprefix("/handlers/")
+    REQUESTS.labels(handler=handler, outcome="invalid").inc()
+    ERRORS.labels(reason=INVALID_REQUEST_ERROR_REASON).inc()
+    return await request_validation_exception_handler(request, exc)
+
+
+def report_error(reason: str, message: str):
+    ERRORS.labels(reason=reason).inc()
+    if logger.level <= logging.ERROR:
+        console.print(message, style="bold red")
+        console.print_exception()
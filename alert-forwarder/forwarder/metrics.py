@@ -0,0 +1,47 @@
+# Copyright (c) 2025 Dynatrace LLC
+#
+# This program is free software: you can redistribute it and/or modify
+# it under the terms of the GNU Affero General Public License as published by
+# the Free Software Foundation, either version 3 of the License, or
+# (at your option) any later version.
+#
+# This program is distributed in the hope that it will be useful,
+# but WITHOUT ANY WARRANTY; without even the implied warranty of
+# MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+# GNU Affero General Public License for more details.
+#
+# You should have received a copy of the GNU Affero General Public License
+# along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+from prometheus_client import Counter
+
+# every request to a handler, by outcome
+# ("accepted", "unauthorized", "invalid", "unavailable")
+REQUESTS = Counter(
+    "koney_alert_forwarder_requests",
+    "Number of requests received by the alert forwarder handlers.",
+    ["handler", "outcome"],
+)
+
+# every error while processing alerts, by reason (see *_ERROR_REASON below)
+ERRORS = Counter(
+    "koney_alert_forwarder_errors",
+    "Number of errors while receiving, processing, or forwarding alerts.",
+    ["reason"],
+)
+
+# alerts that were forwarded, by trap type
+ALERTS = Counter(
+    "koney_alert_forwarder_alerts",
+    "Number of alerts that were forwarded.",
+    ["trap_type"],
+)
+
+K8S_AUTH_ERROR_REASON = "k8s_auth"
+K8S_SINK_READ_ERROR_REASON = "k8s_sink_read"
+K8S_POLICY_SINK_READ_ERROR_REASON = "k8s_policy_sink_read"
+K8S_CONFIG_SINK_READ_ERROR_REASON = "k8s_config_sink_read"
+WEBHOOK_KEY_READ_ERROR_REASON = "webhook_key_read"
+WEBHOOK_SIGNATURE_ERROR_REASON = "webhook_signature"
+INVALID_REQUEST_ERROR_REASON = "invalid_request"
+SINK_SEND_ERROR_REASON = "sink_send"
@@ -0,0 +1,52 @@
+# Copyright (c) 2025 Dynatrace LLC
+#
+# This program is free software: you can redistribute it and/or modify
+# it under the terms of the GNU Affero General Public License as published by
+# the Free Software Foundation, either version 3 of the License, or
+# (at your option) any later version.
+#
+# This program is distributed in the hope that it will be useful,
+# but WITHOUT ANY WARRANTY; without even the implied warranty of
+# MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+# GNU Affero General Public License for more details.
+#
+# You should have received a copy of the GNU Affero General Public License
+# along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+from datetime import datetime, timezone
+
+from pydantic import BaseModel, Field
+
+from .types import ContainerMetadata, KoneyAlert, NodeMetadata, PodMetadata
+
+
+class PluginEvent(BaseModel):
+    deception_policy_name: str = Field(min_length=1)
+    plugin: str = Field(min_length=1)
+    type: str | None = None
+    metadata: dict = {}
+    pod: str | None = None
+    namespace: str | None = None
+    container: str | None = None
+    node: str | None = None
+
+
+def map_plugin_event(event: PluginEvent) -> KoneyAlert:
+    # plugins decide themselves which details about the access they can report
+    pod = None
+    if event.pod:
+        pod = PodMetadata(
+            name=event.pod,
+            namespace=event.namespace,
+            container=ContainerMetadata(id=None, name=event.container),
+        )
+
+    return KoneyAlert(
+        timestamp=datetime.now(timezone.utc).strftime("%Y-%m-%dT%H:%M:%SZ"),
+        deception_policy_name=event.deception_policy_name,
+        trap_type="plugin",
+        metadata=dict(event.metadata, plugin=event.plugin, plugin_type=event.type),
+        pod=pod,
+        node=NodeMetadata(name=event.node) if event.node else None,
+        process=None,
+    )
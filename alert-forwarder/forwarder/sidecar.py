@@ -0,0 +1,53 @@
+# Copyright (c) 2025 Dynatrace LLC
+#
+# This program is free software: you can redistribute it and/or modify
+# it under the terms of the GNU Affero General Public License as published by
+# the Free Software Foundation, either version 3 of the License, or
+# (at your option) any later version.
+#
+# This program is distributed in the hope that it will be useful,
+# but WITHOUT ANY WARRANTY; without even the implied warranty of
+# MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+# GNU Affero General Public License for more details.
+#
+# You should have received a copy of the GNU Affero General Public License
+# along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+from datetime import datetime, timezone
+
+from pydantic import BaseModel, Field
+
+from .types import ContainerMetadata, KoneyAlert, NodeMetadata, PodMetadata
+
+
+class SidecarTrap(BaseModel):
+    deception_policy_name: str = Field(min_length=1)
+    file_path: str = Field(min_length=1)
+
+
+class SidecarEvent(BaseModel):
+    trap: SidecarTrap
+    pod: str | None = None
+    namespace: str | None = None
+    node: str | None = None
+
+
+def map_sidecar_event(event: SidecarEvent) -> KoneyAlert:
+    # sidecars only see that the decoy was opened, but not by which container or process
+    pod = None
+    if event.pod:
+        pod = PodMetadata(
+            name=event.pod,
+            namespace=event.namespace,
+            container=ContainerMetadata(id=None, name=None),
+        )
+
+    return KoneyAlert(
+        timestamp=datetime.now(timezone.utc).strftime("%Y-%m-%dT%H:%M:%SZ"),
+        deception_policy_name=event.trap.deception_policy_name,
+        trap_type="filesystem_honeytoken",
+        metadata=dict(file_path=event.trap.file_path),
+        pod=pod,
+        node=NodeMetadata(name=event.node) if event.node else None,
+        process=None,
+    )
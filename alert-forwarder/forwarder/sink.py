@@ -23,7 +23,7 @@ from kubernetes import client
 from rich.console import Console
 
 from .alerts import map_to_dynatrace_event
-from .types import AlertSink, DynatraceSink, KoneyAlert
+from .types import AlertSink, DynatraceSink, KoneyAlert, WebhookSink
 
 # the namespace where Koney and the DeceptionAlertSink CRDs are located
 KONEY_NAMESPACE = "koney-system"
@@ -36,6 +36,21 @@ KONEY_DECEPTION_ALERT_SINK_GVNP = (
     "deceptionalertsinks",
 )
 
+# group, version, plural of the Koney DeceptionPolicy CRD (cluster-scoped)
+KONEY_DECEPTION_POLICY_GVP = (
+    "research.dynatrace.com",
+    "v1alpha1",
+    "deceptionpolicies",
+)
+
+# group, version, plural, and name of the Koney KoneyConfig CRD (cluster-scoped singleton)
+KONEY_CONFIG_GVPN = (
+    "research.dynatrace.com",
+    "v1alpha1",
+    "koneyconfigs",
+    "koney",
+)
+
 # number of seconds after we timeout requests to external systems
 SINK_REQUEST_TIMEOUT = 25
 
@@ -52,6 +67,53 @@ def read_alert_sinks() -> list[AlertSink]:
         alert_sink = AlertSink(
             name=obj.get("metadata", {}).get("name"),
             dynatrace_sink=_extract_dynatrace_sink(obj),
+            webhook_sink=None,
+        )
+        alert_sinks.append(alert_sink)
+
+    return alert_sinks
+
+
+def read_policy_alert_sinks(deception_policy_name: str) -> list[AlertSink]:
+    api = client.CustomObjectsApi()
+    policy = cast(
+        dict,
+        api.get_cluster_custom_object(
+            *KONEY_DECEPTION_POLICY_GVP, deception_policy_name
+        ),
+    )
+
+    alert_sinks = []
+    webhooks = (policy.get("spec", {}).get("alerting") or {}).get("webhooks") or []
+    for webhook in webhooks:
+        alert_sink = AlertSink(
+            name=f"{deception_policy_name}/{webhook.get('name')}",
+            dynatrace_sink=None,
+            webhook_sink=_extract_webhook_sink(webhook),
+        )
+        alert_sinks.append(alert_sink)
+
+    return alert_sinks
+
+
+def read_config_alert_sinks() -> list[AlertSink]:
+    api = client.CustomObjectsApi()
+    try:
+        koney_config = cast(dict, api.get_cluster_custom_object(*KONEY_CONFIG_GVPN))
+    except client.ApiException as e:
+        # without a KoneyConfig, there are no operator-wide webhooks
+        if e.status == 404:
+            return []
+        raise
+
+    alert_sinks = []
+    spec = koney_config.get("spec", {})
+    webhooks = (spec.get("alerting") or {}).get("webhooks") or []
+    for webhook in webhooks:
+        alert_sink = AlertSink(
+            name=f"{KONEY_CONFIG_GVPN[3]}/{webhook.get('name')}",
+            dynatrace_sink=None,
+            webhook_sink=_extract_webhook_sink(webhook),
         )
         alert_sinks.append(alert_sink)
 
@@ -86,6 +148,35 @@ def send_alert(koney_alert: KoneyAlert, sink: AlertSink) -> None:
                 f"failed to send alert to Dynatrace: {resp.status_code} {resp.text}"
             )
 
+    if sink["webhook_sink"]:
+        url = sink["webhook_sink"]["url"]
+        if logger.level <= logging.DEBUG:
+            console.print(f"Sending alert to webhook {sink['name']}:", koney_alert)
+
+        auth = None
+        headers = {"Content-Type": "application/json"}
+        if token := sink["webhook_sink"]["token"]:
+            headers["Authorization"] = f"Bearer {token}"
+        elif sink["webhook_sink"]["username"] is not None:
+            auth = (
+                sink["webhook_sink"]["username"],
+                sink["webhook_sink"]["password"] or "",
+            )
+
+        resp = requests.post(
+            url,
+            json=koney_alert,
+            timeout=SINK_REQUEST_TIMEOUT,
+            headers=headers,
+            auth=auth,
+        )
+
+        # check response status
+        if not resp.ok:
+            raise RuntimeError(
+                f"failed to send alert to webhook {sink['name']}: {resp.status_code} {resp.text}"
+            )
+
 
 ###############################################################################
 
@@ -101,6 +192,26 @@ def _extract_dynatrace_sink(obj: dict) -> DynatraceSink | None:
                 )
 
 
+def _extract_webhook_sink(webhook: dict) -> WebhookSink | None:
+    if not (url := webhook.get("url")):
+        return None
+
+    secret = {}
+    if secret_name := (webhook.get("secretRef") or {}).get("name"):
+        # secrets are only read from the koney-system namespace, so that policy authors
+        # cannot make us send secrets of other namespaces to their webhooks
+        secret = _get_decoded_secret_data(secret_name)
+        if secret is None:
+            return None  # do not send alerts without the configured credentials
+
+    return WebhookSink(
+        url=url,
+        token=secret.get("token"),
+        username=secret.get("username"),
+        password=secret.get("password"),
+    )
+
+
 def _get_decoded_secret_data(secret_name: str) -> dict | None:
     api = client.CoreV1Api()
     secret = cast(
@@ -13,6 +13,7 @@
 # You should have received a copy of the GNU Affero General Public License
 # along with this program.  If not, see <http://www.gnu.org/licenses/>.
 
+import ipaddress
 import json
 import re
 from collections import defaultdict
@@ -46,6 +47,9 @@ TETRAGON_POD_LABEL_SELECTOR = "app.kubernetes.io/name=tetragon"
 TETRAGON_POD_CONTAINER_NAME = "export-stdout"
 # the label key that references the deception policy in a tracing policy
 TETRAGON_DECEPTION_POLICY_REF = "koney/deception-policy"
+# the annotation keys that hold the name and value of environment variable honeytokens
+TETRAGON_ENVVAR_NAME = "koney/envvar-name"
+TETRAGON_ENVVAR_VALUE = "koney/envvar-value"
 
 # stores hashes of already processed events to prevent duplicates
 event_cache = set()
@@ -109,21 +113,29 @@ def read_tetragon_events(since_seconds=60) -> dict[str, list[dict]]:
 
 def map_tetragon_event(event: dict) -> KoneyAlert:
     deception_policy_name = None
+    tracing_policy = None
     trap_type = "unknown"
     metadata = dict()
 
     try:
         # attempt to resolve the DeceptionPolicy name (calls Kubernetes API)
         if tracing_policy_name := _extract_tracing_policy_name(event):
-            deception_policy_name = _resolve_deception_policy_name(tracing_policy_name)
+            tracing_policy = _resolve_tracing_policy(tracing_policy_name)
+            deception_policy_name = _extract_deception_policy_name(tracing_policy)
     except client.ApiException:
         pass
 
     # infer trap type and metadata by inspecting the event
     if kprobe := event.get("process_kprobe"):
-        if meta := _extract_metadata_for_filesystem_honeytoken(kprobe):
+        if meta := _extract_metadata_for_envvar_honeytoken(kprobe, tracing_policy):
+            trap_type = "envvar_honeytoken"
+            metadata = meta
+        elif meta := _extract_metadata_for_filesystem_honeytoken(kprobe):
             trap_type = "filesystem_honeytoken"
             metadata = meta
+        elif meta := _extract_metadata_for_network_honeypot(kprobe):
+            trap_type = "network_honeypot"
+            metadata = meta
 
     pod = _extract_pod_metadata(event)
     node = _extract_node_metadata(event)
@@ -142,6 +154,10 @@ def map_tetragon_event(event: dict) -> KoneyAlert:
 
 
 def is_filtered_alert(alert: KoneyAlert) -> bool:
+    # outbound connections only matter if the process used the environment variable honeytoken
+    if alert["trap_type"] == "envvar_honeytoken" and not alert["metadata"].get("detection"):
+        return True
+
     if not alert["process"] or not alert["process"]["arguments"]:
         return False  # cannot decide, assume not filtered
 
@@ -158,15 +174,17 @@ def is_filtered_alert(alert: KoneyAlert) -> bool:
 ###############################################################################
 
 
-def _resolve_deception_policy_name(tracing_policy_name: str) -> str | None:
+def _resolve_tracing_policy(tracing_policy_name: str) -> dict:
     api = client.CustomObjectsApi()
-    tracing_policy = cast(
+    return cast(
         dict,
         api.get_cluster_custom_object(
             *TETRAGON_TRACING_POLICIES_GVP, tracing_policy_name
         ),
     )
 
+
+def _extract_deception_policy_name(tracing_policy: dict) -> str | None:
     return (
         tracing_policy.get("metadata", {})
         .get("labels", {})
@@ -226,3 +244,107 @@ def _extract_metadata_for_filesystem_honeytoken(kprobe: dict) -> dict | None:
     if kprobe.get("function_name") in file_access_fn:
         file_path = kprobe.get("args", [{}])[0].get("file_arg", {}).get("path")
         return dict(file_path=file_path)
+
+
+def _extract_metadata_for_envvar_honeytoken(
+    kprobe: dict, tracing_policy: dict | None
+) -> dict | None:
+    annotations = (tracing_policy or {}).get("metadata", {}).get("annotations") or {}
+    if TETRAGON_ENVVAR_VALUE not in annotations:
+        return None  # not the tracing policy of an environment variable honeytoken
+
+    metadata = dict(env_var_name=annotations.get(TETRAGON_ENVVAR_NAME), detection=None)
+    if kprobe.get("function_name") == "security_file_permission":
+        # some process read the environment of a process from /proc/<pid>/environ
+        file_path = kprobe.get("args", [{}])[0].get("file_arg", {}).get("path")
+        metadata.update(detection="environ_read", file_path=file_path)
+    elif kprobe.get("function_name") == "tcp_connect":
+        # some process opened an outbound connection, which only matters if it used the value
+        arguments = kprobe.get("process", {}).get("arguments") or ""
+        if annotations[TETRAGON_ENVVAR_VALUE] in arguments:
+            sock = kprobe.get("args", [{}])[0].get("sock_arg", {})
+            metadata.update(
+                detection="process_arguments",
+                destination_ip=sock.get("daddr"),
+                destination_port=sock.get("dport"),
+            )
+
+    return metadata
+
+
+def _extract_metadata_for_network_honeypot(kprobe: dict) -> dict | None:
+    if kprobe.get("function_name") == "inet_csk_accept":
+        # the accepted socket is local to the honeypot, so the destination is the client
+        sock = kprobe.get("return", {}).get("sock_arg", {})
+        client_ip = _normalize_ip(sock.get("daddr"))
+        metadata = dict(
+            client_ip=client_ip,
+            client_port=sock.get("dport"),
+            honeypot_port=sock.get("sport"),
+        )
+
+        try:
+            # attempt to resolve the client pod (calls Kubernetes API)
+            if client_pod := _resolve_pod_by_ip(client_ip):
+                metadata["client_pod"] = client_pod
+        except client.ApiException:
+            pass
+
+        return metadata
+
+
+def _normalize_ip(ip: str | None) -> str | None:
+    if not ip:
+        return None
+
+    try:
+        address = ipaddress.ip_address(ip)
+    except ValueError:
+        return ip
+
+    # dual-stack listeners see IPv4 clients as IPv4-mapped IPv6 addresses (::ffff:a.b.c.d)
+    if isinstance(address, ipaddress.IPv6Address) and address.ipv4_mapped:
+        return str(address.ipv4_mapped)
+    return str(address)
+
+
+def _resolve_pod_by_ip(ip: str | None) -> dict | None:
+    if not ip:
+        return None
+
+    v1 = client.CoreV1Api()
+    pod_list = cast(
+        client.V1PodList,
+        v1.list_pod_for_all_namespaces(field_selector=f"status.podIP={ip}"),
+    )
+
+    # pods with host networking share the node's ip, so we can only resolve unique matches
+    pods = [pod for pod in pod_list.items if not pod.spec.host_network]
+    if not pods:
+        # in dual-stack clusters, status.podIP is only the primary address of a pod,
+        # so clients connecting over the secondary family are found by their status.podIPs
+        pods = [
+            pod
+            for pod in _list_running_pods(v1)
+            if not pod.spec.host_network and ip in _pod_ips(pod)
+        ]
+    if len(pods) != 1:
+        return None
+
+    return dict(name=pods[0].metadata.name, namespace=pods[0].metadata.namespace)
+
+
+def _list_running_pods(v1: client.CoreV1Api) -> list[client.V1Pod]:
+    pod_list = cast(
+        client.V1PodList,
+        v1.list_pod_for_all_namespaces(field_selector="status.phase=Running"),
+    )
+    return pod_list.items
+
+
+def _pod_ips(pod: client.V1Pod) -> set[str]:
+    return {
+        str(_normalize_ip(pod_ip.ip))
+        for pod_ip in (pod.status.pod_i_ps or [])
+        if pod_ip.ip
+    }
@@ -47,6 +47,9 @@ class KoneyAlert(TypedDict):
         "filesystem_honeytoken",
         "http_endpoint",
         "http_payload",
+        "network_honeypot",
+        "envvar_honeytoken",
+        "plugin",
     ]
 
     # optional metadata that can be present depending on the trap type
@@ -66,6 +69,15 @@ class DynatraceSink(TypedDict):
     severity: DynatraceSeverity
 
 
+class WebhookSink(TypedDict):
+    url: str
+    # optional credentials, either a bearer token or basic authentication
+    token: str | None
+    username: str | None
+    password: str | None
+
+
 class AlertSink(TypedDict):
     name: str
     dynatrace_sink: DynatraceSink | None
+    webhook_sink: WebhookSink | None
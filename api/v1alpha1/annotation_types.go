@@ -29,6 +29,10 @@ type TrapAnnotation struct {
 	// DeploymentStrategy is the strategy to deploy the trap.
 	DeploymentStrategy string `json:"deploymentStrategy"`
 
+	// CaptorDeploymentStrategy is the strategy to deploy the captor of the trap.
+	// +optional
+	CaptorDeploymentStrategy string `json:"captorDeploymentStrategy,omitempty"`
+
 	// Containers is the list of containers where the trap is deployed.
 	// kubebuilder:validation:UniqueItems=true
 	Containers []string `json:"containers"`
@@ -53,6 +57,10 @@ type TrapAnnotation struct {
 	// HttpPayload is the configuration for an HTTP payload trap.
 	// +optional
 	HttpPayload HttpPayloadAnnotation `json:"httpPayload"`
+
+	// EnvVarHoneytoken is the configuration for an environment variable honeytoken trap.
+	// +optional
+	EnvVarHoneytoken EnvVarHoneytokenAnnotation `json:"envVarHoneytoken,omitempty"`
 }
 
 // FilesystemHoneytokenAnnotation represents a concrete deployment of a filesystem honeytoken trap.
@@ -107,6 +115,30 @@ func (annotation *HttpPayloadAnnotation) Equals(other *HttpPayloadAnnotation) bo
 	return true
 }
 
+// EnvVarHoneytokenAnnotation represents a concrete deployment of an environment variable honeytoken trap.
+type EnvVarHoneytokenAnnotation struct {
+	// Name is the name of the environment variable.
+	Name string `json:"name"`
+
+	// ValueHash is the MD5 hash of the value of the environment variable.
+	ValueHash string `json:"valueHash"`
+}
+
+// Equals returns true if the environment variable honeytoken annotations are equal.
+func (annotation *EnvVarHoneytokenAnnotation) Equals(other *EnvVarHoneytokenAnnotation) bool {
+	if annotation == other {
+		return true
+	}
+	if annotation.Name != other.Name {
+		return false
+	}
+	if annotation.ValueHash != other.ValueHash {
+		return false
+	}
+
+	return true
+}
+
 // TrapType translates a TrapAnnotation to a TrapType.
 func (trap *TrapAnnotation) TrapType() TrapType {
 	switch {
@@ -116,11 +148,19 @@ func (trap *TrapAnnotation) TrapType() TrapType {
 		return HttpEndpointTrap
 	case trap.HttpPayload != HttpPayloadAnnotation{}:
 		return HttpPayloadTrap
+	case trap.EnvVarHoneytoken != EnvVarHoneytokenAnnotation{}:
+		return EnvVarHoneytokenTrap
 	default:
 		return UnknownTrap
 	}
 }
 
+// HasSidecarCaptor returns true if the captor of the trap was deployed as a sidecar container.
+// Sidecar captors are deployed together with the decoy, other captors are deployed independently of resources.
+func (trap *TrapAnnotation) HasSidecarCaptor() bool {
+	return trap.CaptorDeploymentStrategy == "sidecar"
+}
+
 // Equals returns true if the traps annotations are equal (excluding CreatedAt and UpdatedAt).
 // If ignoreContainers is true, the function also ignores the containers list.
 func (annotation *TrapAnnotation) Equals(other *TrapAnnotation, ignoreContainers bool) bool {
@@ -130,6 +170,9 @@ func (annotation *TrapAnnotation) Equals(other *TrapAnnotation, ignoreContainers
 	if annotation.DeploymentStrategy != other.DeploymentStrategy {
 		return false
 	}
+	if annotation.HasSidecarCaptor() != other.HasSidecarCaptor() {
+		return false
+	}
 
 	if !ignoreContainers {
 		if len(annotation.Containers) != len(other.Containers) {
@@ -155,6 +198,10 @@ func (annotation *TrapAnnotation) Equals(other *TrapAnnotation, ignoreContainers
 		if !annotation.HttpPayload.Equals(&other.HttpPayload) {
 			return false
 		}
+	case EnvVarHoneytokenTrap:
+		if !annotation.EnvVarHoneytoken.Equals(&other.EnvVarHoneytoken) {
+			return false
+		}
 	default:
 		return false
 	}
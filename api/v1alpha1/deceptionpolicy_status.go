@@ -25,6 +25,88 @@ type DeceptionPolicyStatus struct {
 	// +listType=map
 	// +listMapKey=type
 	Conditions []DeceptionPolicyCondition `json:"conditions" yaml:"conditions"`
+
+	// DecoyProgress tracks the deployment of decoys to the matched objects.
+	// If the controller restarts in the middle of a deployment, it resumes with the traps that are not fully deployed yet.
+	// +optional
+	DecoyProgress *DeploymentProgress `json:"decoyProgress,omitempty" yaml:"decoyProgress,omitempty"`
+
+	// SkippedNamespaces lists the namespaces where traps matched resources, but were not deployed to,
+	// because the namespaces are excluded (e.g., kube-system, or the namespaces excluded by the KoneyConfig).
+	// +optional
+	// +listType=atomic
+	SkippedNamespaces []string `json:"skippedNamespaces,omitempty" yaml:"skippedNamespaces,omitempty"`
+}
+
+// DeploymentTargetState is the state of the deployment of a decoy to a single object.
+// +kubebuilder:validation:Enum=Pending;InProgress;Deployed;Failed;Unsupported
+type DeploymentTargetState string
+
+const (
+	// DeploymentTargetPending means that the object matched, but the decoy was not deployed yet, e.g., because the object was not ready.
+	DeploymentTargetPending DeploymentTargetState = "Pending"
+	// DeploymentTargetInProgress means that the decoy is being deployed to the object.
+	DeploymentTargetInProgress DeploymentTargetState = "InProgress"
+	// DeploymentTargetDeployed means that the decoy is in place on the object.
+	DeploymentTargetDeployed DeploymentTargetState = "Deployed"
+	// DeploymentTargetFailed means that the deployment of the decoy to the object failed and will be retried.
+	DeploymentTargetFailed DeploymentTargetState = "Failed"
+	// DeploymentTargetUnsupported means that the decoy cannot be deployed to the object with the chosen strategy,
+	// e.g., because its containers have no shell for the containerExec strategy. The deployment is not retried.
+	DeploymentTargetUnsupported DeploymentTargetState = "Unsupported"
+)
+
+// DeploymentProgress describes how far the deployment of decoys got for one generation of a DeceptionPolicy.
+type DeploymentProgress struct {
+	// ObservedGeneration is the generation of the DeceptionPolicy that the progress refers to.
+	// The progress starts over whenever the spec of the DeceptionPolicy changes.
+	ObservedGeneration int64 `json:"observedGeneration" yaml:"observedGeneration"`
+
+	// Summary is a human-readable summary of the progress, e.g., "3/5 deployed, 1 failed".
+	// +optional
+	Summary string `json:"summary,omitempty" yaml:"summary,omitempty"`
+
+	// Pending is the number of targets that the decoy was not deployed to yet.
+	Pending int32 `json:"pending" yaml:"pending"`
+
+	// InProgress is the number of targets that the decoy is being deployed to.
+	InProgress int32 `json:"inProgress" yaml:"inProgress"`
+
+	// Deployed is the number of targets that the decoy is in place on.
+	Deployed int32 `json:"deployed" yaml:"deployed"`
+
+	// Failed is the number of targets where the deployment of the decoy failed.
+	Failed int32 `json:"failed" yaml:"failed"`
+
+	// Unsupported is the number of targets where the decoy cannot be deployed with the chosen strategy.
+	// +optional
+	Unsupported int32 `json:"unsupported,omitempty" yaml:"unsupported,omitempty"`
+
+	// Targets lists the objects that decoys are deployed to, and how far the deployment got.
+	// Very long lists are truncated, targets that are not deployed yet are kept first.
+	// +optional
+	// +listType=atomic
+	Targets []DeploymentTarget `json:"targets,omitempty" yaml:"targets,omitempty"`
+}
+
+// DeploymentTarget is an object that the decoy of a trap is deployed to.
+type DeploymentTarget struct {
+	// Trap is the index of the trap in the spec of the DeceptionPolicy.
+	Trap int32 `json:"trap" yaml:"trap"`
+
+	// Kind is the kind of the object (e.g., Pod or Deployment).
+	// +optional
+	Kind string `json:"kind,omitempty" yaml:"kind,omitempty"`
+
+	// Namespace is the namespace of the object.
+	// +optional
+	Namespace string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+
+	// Name is the name of the object.
+	Name string `json:"name" yaml:"name"`
+
+	// State is the state of the deployment of the decoy to the object.
+	State DeploymentTargetState `json:"state" yaml:"state"`
 }
 
 // DeceptionPolicyCondition describes the state of one aspect of a DeceptionPolicy at a certain point.
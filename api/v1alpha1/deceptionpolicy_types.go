@@ -16,12 +16,27 @@
 package v1alpha1
 
 import (
+	"time"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// MinRetryInterval is the shortest interval that a DeceptionPolicy may set as RetryInterval.
+const MinRetryInterval = 1 * time.Second
+
+// MinRotationInterval is the shortest interval that a DeceptionPolicy may set as Rotate.
+const MinRotationInterval = 1 * time.Minute
+
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
-// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:resource:scope=Cluster,shortName=dp;deceptionpol,categories=security
+// +kubebuilder:printcolumn:name="Valid",type=string,JSONPath=`.status.conditions[?(@.type=="PolicyValid")].status`
+// +kubebuilder:printcolumn:name="Decoys",type=string,JSONPath=`.status.conditions[?(@.type=="DecoysDeployed")].status`
+// +kubebuilder:printcolumn:name="Captors",type=string,JSONPath=`.status.conditions[?(@.type=="CaptorsDeployed")].status`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+// +kubebuilder:printcolumn:name="Decoy Status",type=string,JSONPath=`.status.conditions[?(@.type=="DecoysDeployed")].message`,priority=1
+// +kubebuilder:printcolumn:name="Captor Status",type=string,JSONPath=`.status.conditions[?(@.type=="CaptorsDeployed")].message`,priority=1
+// +kubebuilder:printcolumn:name="Progress",type=string,JSONPath=`.status.decoyProgress.summary`,priority=1
 
 // DeceptionPolicy is the Schema for the deceptionpolicies API
 type DeceptionPolicy struct {
@@ -67,6 +82,76 @@ type DeceptionPolicySpec struct {
 	// +optional
 	// +kubebuilder:default=true
 	MutateExisting *bool `json:"mutateExisting,omitempty" yaml:"mutateExisting,omitempty"`
+
+	// Alerting configures where the alerts of this DeceptionPolicy are sent to,
+	// in addition to the cluster-wide DeceptionAlertSinks.
+	// +optional
+	Alerting *Alerting `json:"alerting,omitempty" yaml:"alerting,omitempty"`
+
+	// SyncInterval is how often the traps are checked again after they were deployed successfully,
+	// e.g., to redeploy decoys that were removed in the meantime. Use "0s" to disable periodic checks.
+	// If not set, the default interval of the controller is used (see its --sync-interval flag).
+	// +optional
+	SyncInterval *metav1.Duration `json:"syncInterval,omitempty" yaml:"syncInterval,omitempty"`
+
+	// RetryInterval is how soon the deployment is retried if some matched resources were not ready for traps yet,
+	// e.g., because their containers were still starting.
+	// If not set, the default interval of the controller is used (see its --retry-interval flag).
+	// +optional
+	RetryInterval *metav1.Duration `json:"retryInterval,omitempty" yaml:"retryInterval,omitempty"`
+
+	// Priority resolves conflicts with other DeceptionPolicies, i.e., if filesystem honeytokens of both policies
+	// have the same file path but a different content, and are deployed to the same containers.
+	// Only the trap of the policy with the higher priority is deployed. If both policies have the same priority,
+	// the older policy takes precedence (or the policy whose name sorts first, if both are equally old).
+	// +optional
+	Priority int32 `json:"priority,omitempty" yaml:"priority,omitempty"`
+
+	// Rotate is how often the contents of honeytokens that Koney generates (see FileContentFrom.Generate) are regenerated,
+	// e.g., "168h" to rotate them weekly, so that long-lived decoys do not get stale and leaked tokens age out.
+	// The decoys are redeployed with the new contents, while their captors are kept as they are.
+	// If not set, generated contents are never rotated.
+	// +optional
+	Rotate *metav1.Duration `json:"rotate,omitempty" yaml:"rotate,omitempty"`
+}
+
+// TakesPrecedenceOver returns true if the traps of this DeceptionPolicy are deployed instead of conflicting traps of the other policy.
+// Conflicts are resolved by priority first, then by age, and finally by name, so that exactly one of both policies takes precedence.
+func (policy *DeceptionPolicy) TakesPrecedenceOver(other *DeceptionPolicy) bool {
+	if policy.Spec.Priority != other.Spec.Priority {
+		return policy.Spec.Priority > other.Spec.Priority
+	}
+	if !policy.CreationTimestamp.Equal(&other.CreationTimestamp) {
+		return policy.CreationTimestamp.Before(&other.CreationTimestamp)
+	}
+	return policy.Name < other.Name
+}
+
+// GetSyncInterval returns the sync interval of the DeceptionPolicy, or the given default interval if it is not set.
+// Negative intervals are treated like zero, i.e., periodic checks are disabled.
+func (spec *DeceptionPolicySpec) GetSyncInterval(defaultInterval time.Duration) time.Duration {
+	if spec.SyncInterval == nil {
+		return defaultInterval
+	}
+	return max(spec.SyncInterval.Duration, 0)
+}
+
+// GetRetryInterval returns the retry interval of the DeceptionPolicy, or the given default interval if it is not set.
+// Intervals that are too short are raised to MinRetryInterval, to not overload the cluster.
+func (spec *DeceptionPolicySpec) GetRetryInterval(defaultInterval time.Duration) time.Duration {
+	if spec.RetryInterval == nil {
+		return defaultInterval
+	}
+	return max(spec.RetryInterval.Duration, MinRetryInterval)
+}
+
+// GetRotationInterval returns how often generated honeytoken contents are rotated, or zero if they are never rotated.
+// Intervals that are too short are raised to MinRotationInterval, to not redeploy decoys all the time.
+func (spec *DeceptionPolicySpec) GetRotationInterval() time.Duration {
+	if spec.Rotate == nil || spec.Rotate.Duration <= 0 {
+		return 0
+	}
+	return max(spec.Rotate.Duration, MinRotationInterval)
 }
 
 func init() {
@@ -0,0 +1,96 @@
+// Copyright (c) 2025 Dynatrace LLC
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v1alpha1
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("DeceptionPolicySpec intervals", func() {
+	It("should fall back to the defaults if no intervals are set", func() {
+		spec := DeceptionPolicySpec{}
+		Expect(spec.GetSyncInterval(5 * time.Minute)).To(Equal(5 * time.Minute))
+		Expect(spec.GetRetryInterval(20 * time.Second)).To(Equal(20 * time.Second))
+	})
+
+	It("should prefer the intervals of the policy", func() {
+		spec := DeceptionPolicySpec{
+			SyncInterval:  &metav1.Duration{Duration: time.Hour},
+			RetryInterval: &metav1.Duration{Duration: 30 * time.Second},
+		}
+		Expect(spec.GetSyncInterval(5 * time.Minute)).To(Equal(time.Hour))
+		Expect(spec.GetRetryInterval(20 * time.Second)).To(Equal(30 * time.Second))
+	})
+
+	It("should clamp intervals that are too short", func() {
+		spec := DeceptionPolicySpec{
+			SyncInterval:  &metav1.Duration{Duration: -time.Minute},
+			RetryInterval: &metav1.Duration{Duration: time.Millisecond},
+		}
+		Expect(spec.GetSyncInterval(5 * time.Minute)).To(BeZero())
+		Expect(spec.GetRetryInterval(20 * time.Second)).To(Equal(MinRetryInterval))
+	})
+
+	It("should only rotate generated contents if the policy sets an interval", func() {
+		spec := DeceptionPolicySpec{}
+		Expect(spec.GetRotationInterval()).To(BeZero())
+
+		spec.Rotate = &metav1.Duration{Duration: 168 * time.Hour}
+		Expect(spec.GetRotationInterval()).To(Equal(168 * time.Hour))
+
+		spec.Rotate = &metav1.Duration{Duration: time.Second}
+		Expect(spec.GetRotationInterval()).To(Equal(MinRotationInterval))
+
+		spec.Rotate = &metav1.Duration{Duration: 0}
+		Expect(spec.GetRotationInterval()).To(BeZero())
+	})
+})
+
+var _ = Describe("DeceptionPolicy precedence", func() {
+	newPolicy := func(name string, priority int32, createdAt time.Time) *DeceptionPolicy {
+		return &DeceptionPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: name, CreationTimestamp: metav1.NewTime(createdAt)},
+			Spec:       DeceptionPolicySpec{Priority: priority},
+		}
+	}
+
+	now := time.Now()
+
+	It("should prefer the policy with the higher priority", func() {
+		older := newPolicy("a", 0, now.Add(-time.Hour))
+		newer := newPolicy("b", 10, now)
+		Expect(newer.TakesPrecedenceOver(older)).To(BeTrue())
+		Expect(older.TakesPrecedenceOver(newer)).To(BeFalse())
+	})
+
+	It("should prefer the older policy if priorities are equal", func() {
+		older := newPolicy("b", 5, now.Add(-time.Hour))
+		newer := newPolicy("a", 5, now)
+		Expect(older.TakesPrecedenceOver(newer)).To(BeTrue())
+		Expect(newer.TakesPrecedenceOver(older)).To(BeFalse())
+	})
+
+	It("should prefer the policy whose name sorts first if both are equally old", func() {
+		first := newPolicy("a", 0, now)
+		second := newPolicy("b", 0, now)
+		Expect(first.TakesPrecedenceOver(second)).To(BeTrue())
+		Expect(second.TakesPrecedenceOver(first)).To(BeFalse())
+	})
+})
@@ -0,0 +1,46 @@
+// Copyright (c) 2025 Dynatrace LLC
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v1alpha1
+
+// Alerting configures where the alerts of a DeceptionPolicy are sent to.
+// Alerts are sent to these destinations in addition to all DeceptionAlertSinks.
+type Alerting struct {
+	// Webhooks is a list of webhooks that receive the alerts of this DeceptionPolicy.
+	// +optional
+	Webhooks []AlertWebhook `json:"webhooks,omitempty" yaml:"webhooks,omitempty"`
+}
+
+// AlertWebhook is a destination that receives alerts as JSON objects with HTTP POST requests.
+type AlertWebhook struct {
+	// Name identifies the webhook, e.g., in logs of the alert forwarder.
+	Name string `json:"name" yaml:"name"`
+
+	// URL is the destination URL of the webhook.
+	// +kubebuilder:validation:Pattern=`^https?://`
+	URL string `json:"url" yaml:"url"`
+
+	// SecretRef references a secret with the credentials to authenticate with the webhook.
+	// If the secret contains the key `token`, it is sent as a bearer token.
+	// If the secret contains the keys `username` and `password`, they are sent with basic authentication.
+	// +optional
+	SecretRef *WebhookSecretRef `json:"secretRef,omitempty" yaml:"secretRef,omitempty"`
+}
+
+// WebhookSecretRef references a secret in the namespace of Koney.
+type WebhookSecretRef struct {
+	// Name is the name of the secret. The secret must be in the koney-system namespace.
+	Name string `json:"name" yaml:"name"`
+}
@@ -18,10 +18,13 @@ package v1alpha1
 // CaptorDeployment is the entity that monitors access to the traps.
 type CaptorDeployment struct {
 	// Strategy is the technical method to deploy the captor.
-	// Currently, only "tetragon" is supported, which is also the default.
-	// It requires the Tetragon controller to be installed.
-	// +kubebuilder:validation:Enum=tetragon
+	// Supported values are "tetragon", "falco", and "sidecar".
+	// If not set, the default captor strategy of the KoneyConfig is used, which is "tetragon" unless configured otherwise.
+	// The "tetragon" strategy requires the Tetragon controller to be installed.
+	// The "falco" strategy renders Falco rules into a ConfigMap that must be mounted into Falco.
+	// The "sidecar" strategy injects a container that watches the decoy with inotify, which requires neither eBPF nor Falco.
+	// Currently, "falco" and "sidecar" only support filesystem honeytoken traps, and "sidecar" requires the volumeMount or initContainer strategy.
+	// +kubebuilder:validation:Enum=tetragon;falco;sidecar
 	// +optional
-	// +kubebuilder:default="tetragon"
 	Strategy string `json:"strategy,omitempty" yaml:"strategy,omitempty"`
 }
@@ -18,7 +18,9 @@ package v1alpha1
 // DecoyDeployment is the entities that is attacked (e.g., the honeytoken).
 type DecoyDeployment struct {
 	// Strategy is the technical method to deploy the trap.
-	// +kubebuilder:validation:Enum=volumeMount;containerExec;kyvernoPolicy
+	// The "initContainer" strategy copies the decoy into an emptyDir volume during pod start,
+	// for containers with read-only root filesystems or without a shell.
+	// +kubebuilder:validation:Enum=volumeMount;containerExec;initContainer;kyvernoPolicy
 	// +optional
 	// +kubebuilder:default="volumeMount"
 	Strategy string `json:"strategy,omitempty" yaml:"strategy,omitempty"`
@@ -0,0 +1,222 @@
+// Copyright (c) 2025 Dynatrace LLC
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v1alpha1
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// FileContentSource describes where the content of a honeytoken is sourced from,
+// if it is not specified inline.
+type FileContentSource struct {
+	// ExternalSecret sources the content from an external secret store (e.g., a vault),
+	// using the external-secrets operator (https://external-secrets.io).
+	// +optional
+	ExternalSecret *ExternalSecretSource `json:"externalSecret,omitempty" yaml:"externalSecret,omitempty"`
+
+	// SecretKeyRef sources the content from a key of a Secret in the namespace of Koney.
+	// +optional
+	SecretKeyRef *ContentKeySelector `json:"secretKeyRef,omitempty" yaml:"secretKeyRef,omitempty"`
+
+	// ConfigMapKeyRef sources the content from a key of a ConfigMap in the namespace of Koney.
+	// +optional
+	ConfigMapKeyRef *ContentKeySelector `json:"configMapKeyRef,omitempty" yaml:"configMapKeyRef,omitempty"`
+
+	// Generate lets Koney generate a random content, which is kept in a Secret in the namespace of Koney.
+	// The content is regenerated periodically if the DeceptionPolicy sets Rotate.
+	// +optional
+	Generate *GeneratedContent `json:"generate,omitempty" yaml:"generate,omitempty"`
+
+	// CanaryToken mints the content with an external canary token provider (e.g., canarytokens.org),
+	// so that the token also raises alerts if it is used outside of the cluster.
+	// The content is kept in a Secret in the namespace of Koney and minted again if the DeceptionPolicy sets Rotate.
+	// +optional
+	CanaryToken *CanaryTokenSource `json:"canaryToken,omitempty" yaml:"canaryToken,omitempty"`
+}
+
+// CanaryTokenSource describes a canary token that is minted by an external token provider.
+type CanaryTokenSource struct {
+	// Provider is the name of the token provider, as configured in Koney.
+	// +optional
+	// +kubebuilder:default="canarytokens"
+	Provider string `json:"provider,omitempty" yaml:"provider,omitempty"`
+
+	// Kind is the kind of token to mint. The content of the honeytoken is the URL of "web" tokens,
+	// the hostname of "dns" tokens, and an AWS credentials file of "aws-keys" tokens.
+	// +kubebuilder:validation:Enum=web;dns;aws-keys
+	Kind string `json:"kind" yaml:"kind"`
+
+	// Memo is a reminder where the token is deployed, which the provider includes in its alerts.
+	// If not set, Koney describes the DeceptionPolicy and the file path of the trap.
+	// +optional
+	Memo string `json:"memo,omitempty" yaml:"memo,omitempty"`
+
+	// Email is the address that the provider sends alerts to.
+	// +optional
+	Email string `json:"email,omitempty" yaml:"email,omitempty"`
+
+	// WebhookURL is the URL that the provider sends alerts to. It must be reachable from the provider.
+	// +optional
+	WebhookURL string `json:"webhookURL,omitempty" yaml:"webhookURL,omitempty"`
+}
+
+// CanaryTokenKinds are the kinds of tokens that canary token sources may mint.
+var CanaryTokenKinds = []string{"web", "dns", "aws-keys"}
+
+// DefaultTokenProvider is the token provider of canary token sources, if no provider is set.
+const DefaultTokenProvider = "canarytokens"
+
+// GetProvider returns the name of the token provider, or DefaultTokenProvider if no provider is set.
+func (s *CanaryTokenSource) GetProvider() string {
+	if s.Provider == "" {
+		return DefaultTokenProvider
+	}
+	return s.Provider
+}
+
+// GeneratedContent describes a random content that Koney generates.
+type GeneratedContent struct {
+	// Length is the number of random alphanumeric characters to generate.
+	// +optional
+	// +kubebuilder:default=32
+	// +kubebuilder:validation:Minimum=8
+	// +kubebuilder:validation:Maximum=4096
+	Length int32 `json:"length,omitempty" yaml:"length,omitempty"`
+
+	// Prefix is placed in front of the random characters, e.g., "AKIA" to make the content look like an AWS access key ID.
+	// +optional
+	Prefix string `json:"prefix,omitempty" yaml:"prefix,omitempty"`
+}
+
+// MinGeneratedContentLength and MaxGeneratedContentLength limit the number of random characters of generated contents.
+const (
+	MinGeneratedContentLength = 8
+	MaxGeneratedContentLength = 4096
+)
+
+// DefaultGeneratedContentLength is the number of random characters of generated contents, if no length is set.
+const DefaultGeneratedContentLength = 32
+
+// GetLength returns the number of random characters to generate, or DefaultGeneratedContentLength if no length is set.
+func (g *GeneratedContent) GetLength() int {
+	if g.Length == 0 {
+		return DefaultGeneratedContentLength
+	}
+	return int(g.Length)
+}
+
+// ContentKeySelector selects a key of a Secret or ConfigMap.
+// The Secret or ConfigMap must be in the koney-system namespace.
+type ContentKeySelector struct {
+	// Name is the name of the Secret or ConfigMap.
+	Name string `json:"name" yaml:"name"`
+
+	// Key is the key whose value is the content.
+	Key string `json:"key" yaml:"key"`
+}
+
+// ExternalSecretSource references a value in an external secret store.
+// Koney creates an ExternalSecret in its own namespace and waits until the
+// external-secrets operator synchronized the value into a Kubernetes Secret.
+type ExternalSecretSource struct {
+	// SecretStoreRef references the (Cluster)SecretStore that holds the value.
+	SecretStoreRef SecretStoreRef `json:"secretStoreRef" yaml:"secretStoreRef"`
+
+	// RemoteRef points to the value in the external secret store.
+	RemoteRef ExternalSecretRemoteRef `json:"remoteRef" yaml:"remoteRef"`
+
+	// RefreshInterval is the amount of time before the value is read again from the secret store.
+	// +optional
+	// +kubebuilder:default="1h"
+	RefreshInterval string `json:"refreshInterval,omitempty" yaml:"refreshInterval,omitempty"`
+}
+
+// SecretStoreRef references a SecretStore or ClusterSecretStore of the external-secrets operator.
+type SecretStoreRef struct {
+	// Name is the name of the secret store.
+	Name string `json:"name" yaml:"name"`
+
+	// Kind is the kind of the secret store.
+	// A SecretStore must exist in the namespace where Koney is installed.
+	// +kubebuilder:validation:Enum=SecretStore;ClusterSecretStore
+	// +optional
+	// +kubebuilder:default="ClusterSecretStore"
+	Kind string `json:"kind,omitempty" yaml:"kind,omitempty"`
+}
+
+// ExternalSecretRemoteRef points to a value in an external secret store.
+type ExternalSecretRemoteRef struct {
+	// Key is the key (or path) of the value in the external secret store.
+	Key string `json:"key" yaml:"key"`
+
+	// Property selects a property of the value, if the value is structured (e.g., JSON).
+	// +optional
+	Property string `json:"property,omitempty" yaml:"property,omitempty"`
+
+	// Version selects a specific version of the value.
+	// +optional
+	Version string `json:"version,omitempty" yaml:"version,omitempty"`
+}
+
+// IsValid checks if the content source is valid.
+// Exactly one source must be specified.
+func (s *FileContentSource) IsValid() error {
+	numSources := 0
+	for _, specified := range []bool{s.ExternalSecret != nil, s.SecretKeyRef != nil, s.ConfigMapKeyRef != nil, s.Generate != nil, s.CanaryToken != nil} {
+		if specified {
+			numSources++
+		}
+	}
+
+	if numSources == 0 {
+		return errors.New("FileContentFrom does not specify any source")
+	} else if numSources > 1 {
+		return errors.New("FileContentFrom specifies more than one source")
+	}
+
+	switch {
+	case s.ExternalSecret != nil:
+		if s.ExternalSecret.SecretStoreRef.Name == "" {
+			return errors.New("FileContentFrom.ExternalSecret.SecretStoreRef.Name is empty")
+		}
+		if s.ExternalSecret.RemoteRef.Key == "" {
+			return errors.New("FileContentFrom.ExternalSecret.RemoteRef.Key is empty")
+		}
+	case s.SecretKeyRef != nil:
+		if s.SecretKeyRef.Name == "" || s.SecretKeyRef.Key == "" {
+			return errors.New("FileContentFrom.SecretKeyRef.Name and FileContentFrom.SecretKeyRef.Key must not be empty")
+		}
+	case s.ConfigMapKeyRef != nil:
+		if s.ConfigMapKeyRef.Name == "" || s.ConfigMapKeyRef.Key == "" {
+			return errors.New("FileContentFrom.ConfigMapKeyRef.Name and FileContentFrom.ConfigMapKeyRef.Key must not be empty")
+		}
+	case s.Generate != nil:
+		if length := s.Generate.GetLength(); length < MinGeneratedContentLength || length > MaxGeneratedContentLength {
+			return fmt.Errorf("FileContentFrom.Generate.Length must be between %d and %d", MinGeneratedContentLength, MaxGeneratedContentLength)
+		}
+	case s.CanaryToken != nil:
+		if !slices.Contains(CanaryTokenKinds, s.CanaryToken.Kind) {
+			return fmt.Errorf("FileContentFrom.CanaryToken.Kind must be one of %v", CanaryTokenKinds)
+		}
+		if s.CanaryToken.Email == "" && s.CanaryToken.WebhookURL == "" {
+			return errors.New("FileContentFrom.CanaryToken must specify an Email or a WebhookURL for alerts")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+// Copyright (c) 2025 Dynatrace LLC
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v1alpha1
+
+import (
+	"errors"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// EnvVarHoneytoken defines the configuration for an environment variable honeytoken trap.
+// The environment variable is injected into the selected containers by mutating their Deployments.
+type EnvVarHoneytoken struct {
+	// Name is the name of the environment variable to be injected, e.g., "AWS_SECRET_ACCESS_KEY".
+	Name string `json:"name" yaml:"name"`
+
+	// Value is the (decoy) value of the environment variable.
+	// Alerts are also raised if this value appears in the arguments of processes that open outbound connections.
+	Value string `json:"value" yaml:"value"`
+}
+
+// IsValid checks if the environment variable honeytoken trap is valid.
+// The name must be a valid environment variable name and the value must not be empty.
+func (e *EnvVarHoneytoken) IsValid() error {
+	if errs := validation.IsEnvVarName(e.Name); len(errs) > 0 {
+		return fmt.Errorf("Name is not a valid environment variable name: '%s'", e.Name)
+	}
+
+	if e.Value == "" {
+		return errors.New("Value must not be empty")
+	}
+
+	return nil
+}
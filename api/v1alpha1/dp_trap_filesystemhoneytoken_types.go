@@ -16,6 +16,7 @@
 package v1alpha1
 
 import (
+	"errors"
 	"fmt"
 	"path/filepath"
 )
@@ -23,13 +24,20 @@ import (
 // FilesystemHoneytoken defines the configuration for a filesystem honeytoken trap.
 type FilesystemHoneytoken struct {
 	// FilePath is the path of the file to be created.
-	FilePath string `json:"filePath" yaml:"filePath"`
+	// It can only be omitted if the trap uses a preset, which provides the file path.
+	// +optional
+	FilePath string `json:"filePath,omitempty" yaml:"filePath,omitempty"`
 
 	// FileContent is the content of the file to be created.
 	// +optional
 	// +kubebuilder:default=""
 	FileContent string `json:"fileContent" yaml:"fileContent"`
 
+	// FileContentFrom sources the content of the file from somewhere else, instead of FileContent.
+	// It cannot be used together with FileContent.
+	// +optional
+	FileContentFrom *FileContentSource `json:"fileContentFrom,omitempty" yaml:"fileContentFrom,omitempty"`
+
 	// ReadOnly is a flag to make the file read-only.
 	// +optional
 	// +kubebuilder:default=true
@@ -37,12 +45,21 @@ type FilesystemHoneytoken struct {
 }
 
 // IsValid checks if the filesystem honeytoken trap is valid.
-// The file path must be absolute.
+// The file path must be absolute and the content must not be specified twice.
 func (f *FilesystemHoneytoken) IsValid() error {
 	// Check if the file path is absolute
 	if !filepath.IsAbs(f.FilePath) {
 		return fmt.Errorf("FilePath is not absolute: '%s'", f.FilePath)
 	}
 
+	if f.FileContentFrom != nil {
+		if f.FileContent != "" {
+			return errors.New("FileContent and FileContentFrom cannot be specified together")
+		}
+		if err := f.FileContentFrom.IsValid(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
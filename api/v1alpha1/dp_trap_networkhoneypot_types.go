@@ -0,0 +1,106 @@
+// Copyright (c) 2025 Dynatrace LLC
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v1alpha1
+
+import (
+	"errors"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// IP families of a network honeypot.
+const (
+	NetworkHoneypotIPv4      = "IPv4"
+	NetworkHoneypotIPv6      = "IPv6"
+	NetworkHoneypotDualStack = "DualStack"
+)
+
+// NetworkHoneypot defines the configuration for a network honeypot trap.
+// A network honeypot is a lightweight listener pod, exposed by a Service in every matched namespace.
+type NetworkHoneypot struct {
+	// ServiceName is the name of the Service (and listener Deployment) that exposes the honeypot.
+	// Pick an enticing name that fits the namespace, e.g., "redis-cache" or "bastion".
+	ServiceName string `json:"serviceName" yaml:"serviceName"`
+
+	// Protocol is the protocol that the honeypot pretends to speak.
+	// The honeypot only sends a protocol-specific greeting and never implements the protocol.
+	// +kubebuilder:validation:Enum=redis;ssh;tcp
+	Protocol string `json:"protocol" yaml:"protocol"`
+
+	// Port is the port that the honeypot listens on.
+	// If omitted, the well-known port of the protocol is used (6379 for redis, 22 for ssh).
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port,omitempty" yaml:"port,omitempty"`
+
+	// IPFamily is the IP family that the honeypot is reachable over. Defaults to "IPv4".
+	// Use "IPv6" in IPv6-only clusters, and "DualStack" to expose the honeypot over both families in dual-stack clusters.
+	// +optional
+	// +kubebuilder:validation:Enum=IPv4;IPv6;DualStack
+	IPFamily string `json:"ipFamily,omitempty" yaml:"ipFamily,omitempty"`
+}
+
+// GetPort returns the port that the honeypot listens on, falling back to the well-known port of the protocol.
+func (h *NetworkHoneypot) GetPort() int32 {
+	if h.Port != 0 {
+		return h.Port
+	}
+
+	switch h.Protocol {
+	case "redis":
+		return 6379
+	case "ssh":
+		return 22
+	default:
+		return 0
+	}
+}
+
+// GetIPFamily returns the IP family that the honeypot is reachable over, falling back to IPv4.
+func (h *NetworkHoneypot) GetIPFamily() string {
+	if h.IPFamily != "" {
+		return h.IPFamily
+	}
+	return NetworkHoneypotIPv4
+}
+
+// IsValid checks if the network honeypot trap is valid.
+// The service name must be a valid DNS label and the port must be known.
+func (h *NetworkHoneypot) IsValid() error {
+	if errs := validation.IsDNS1035Label(h.ServiceName); len(errs) > 0 {
+		return fmt.Errorf("ServiceName is not a valid service name: '%s'", h.ServiceName)
+	}
+
+	switch h.Protocol {
+	case "redis", "ssh", "tcp":
+	default:
+		return fmt.Errorf("Protocol is unknown: '%s'", h.Protocol)
+	}
+
+	if h.GetPort() == 0 {
+		return errors.New("Port must be specified for the tcp protocol")
+	}
+
+	switch h.GetIPFamily() {
+	case NetworkHoneypotIPv4, NetworkHoneypotIPv6, NetworkHoneypotDualStack:
+	default:
+		return fmt.Errorf("IPFamily is unknown: '%s'", h.IPFamily)
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+// Copyright (c) 2025 Dynatrace LLC
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v1alpha1
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// PluginTrap defines the configuration for a trap whose type is implemented by an out-of-tree plugin.
+// Koney resolves the matching objects and delegates the deployment of decoys and captors to the plugin.
+type PluginTrap struct {
+	// Name is the name of the plugin that implements the trap, e.g., "acme-db-records".
+	// The manager discovers the plugin by its socket "<name>.sock" in the plugin directory.
+	Name string `json:"name" yaml:"name"`
+
+	// Type is the trap type within the plugin, in case the plugin implements more than one trap type.
+	// +optional
+	Type string `json:"type,omitempty" yaml:"type,omitempty"`
+
+	// Config is the configuration of the trap, which is passed as-is to the plugin (e.g., a JSON document).
+	// +optional
+	Config string `json:"config,omitempty" yaml:"config,omitempty"`
+}
+
+// IsValid checks if the plugin trap is valid.
+// The name must be a valid DNS label, because it is used to find the socket of the plugin.
+func (p *PluginTrap) IsValid() error {
+	if errs := validation.IsDNS1123Label(p.Name); len(errs) > 0 {
+		return fmt.Errorf("Name is not a valid plugin name: '%s'", p.Name)
+	}
+
+	return nil
+}
@@ -18,6 +18,7 @@ package v1alpha1
 import (
 	"errors"
 	"fmt"
+	"slices"
 )
 
 // TrapType is a string representation of a trap type and can be used like an enum.
@@ -35,10 +36,26 @@ const (
 
 	// HttpPayloadTrap is an HTTP payload trap.
 	HttpPayloadTrap TrapType = "HttpPayload"
+
+	// NetworkHoneypotTrap is a network honeypot trap.
+	NetworkHoneypotTrap TrapType = "NetworkHoneypot"
+
+	// EnvVarHoneytokenTrap is an environment variable honeytoken trap.
+	EnvVarHoneytokenTrap TrapType = "EnvVarHoneytoken"
+
+	// PluginTrapType is a trap that is implemented by an out-of-tree plugin.
+	PluginTrapType TrapType = "Plugin"
 )
 
 // Trap describes a cyber deception technique, also simply known as a trap.
 type Trap struct {
+	// Preset is the name of a built-in trap preset, which fills in a realistic file path and content for a common scenario
+	// (e.g., "aws-credentials"). Fields that are set in the trap take precedence over the preset.
+	// Presets can only be used for filesystem honeytoken traps.
+	// +kubebuilder:validation:Enum=aws-credentials;ssh-private-key;kubeconfig;docker-config;git-credentials
+	// +optional
+	Preset string `json:"preset,omitempty" yaml:"preset,omitempty"`
+
 	// FilesystemHoneytoken is the configuration for a filesystem honeytoken trap.
 	// +optional
 	FilesystemHoneytoken FilesystemHoneytoken `json:"filesystemHoneytoken,omitempty" yaml:"spec,omitempty"`
@@ -51,6 +68,18 @@ type Trap struct {
 	// +optional
 	HttpPayload HttpPayload `json:"httpPayload,omitempty" yaml:"httpPayload,omitempty"`
 
+	// NetworkHoneypot is the configuration for a network honeypot trap.
+	// +optional
+	NetworkHoneypot NetworkHoneypot `json:"networkHoneypot,omitempty" yaml:"networkHoneypot,omitempty"`
+
+	// EnvVarHoneytoken is the configuration for an environment variable honeytoken trap.
+	// +optional
+	EnvVarHoneytoken EnvVarHoneytoken `json:"envVarHoneytoken,omitempty" yaml:"envVarHoneytoken,omitempty"`
+
+	// Plugin is the configuration for a trap that is implemented by an out-of-tree plugin.
+	// +optional
+	Plugin PluginTrap `json:"plugin,omitempty" yaml:"plugin,omitempty"`
+
 	// DecoyDeployment configures how traps (the entities that are attacked) are going to be deployed.
 	// +optional
 	DecoyDeployment DecoyDeployment `json:"decoyDeployment,omitempty" yaml:"decoyDeployment,omitempty"`
@@ -65,6 +94,9 @@ type Trap struct {
 	MatchResources MatchResources `json:"match,omitempty" yaml:"match,omitempty"`
 }
 
+// TrapPresets are the names of the built-in trap presets.
+var TrapPresets = []string{"aws-credentials", "ssh-private-key", "kubeconfig", "docker-config", "git-credentials"}
+
 // TrapType returns the type of trap.
 func (trap *Trap) TrapType() TrapType {
 	switch {
@@ -74,6 +106,12 @@ func (trap *Trap) TrapType() TrapType {
 		return HttpEndpointTrap
 	case trap.HttpPayload != HttpPayload{}:
 		return HttpPayloadTrap
+	case trap.NetworkHoneypot != NetworkHoneypot{}:
+		return NetworkHoneypotTrap
+	case trap.EnvVarHoneytoken != EnvVarHoneytoken{}:
+		return EnvVarHoneytokenTrap
+	case trap.Plugin != PluginTrap{}:
+		return PluginTrapType
 	default:
 		return UnknownTrap
 	}
@@ -107,11 +145,30 @@ func (trap *Trap) IsValid() error {
 	if (trap.HttpPayload != HttpPayload{}) {
 		numTraps += 1
 	}
+	if (trap.NetworkHoneypot != NetworkHoneypot{}) {
+		numTraps += 1
+	}
+	if (trap.EnvVarHoneytoken != EnvVarHoneytoken{}) {
+		numTraps += 1
+	}
+	if (trap.Plugin != PluginTrap{}) {
+		numTraps += 1
+	}
 
 	if numTraps != 1 {
 		return fmt.Errorf("only one trap can be specified per list item, but %d traps were found", numTraps)
 	}
 
+	// Presets are expanded before traps are validated, so they must have resulted in a filesystem honeytoken
+	if trap.Preset != "" {
+		if !slices.Contains(TrapPresets, trap.Preset) {
+			return fmt.Errorf("preset '%s' is unknown", trap.Preset)
+		}
+		if trap.TrapType() != FilesystemHoneytokenTrap {
+			return fmt.Errorf("presets can only be used for FilesystemHoneytoken traps, not for %s traps", trap.TrapType())
+		}
+	}
+
 	switch trap.TrapType() {
 	case FilesystemHoneytokenTrap:
 		if err := trap.FilesystemHoneytoken.IsValid(); err != nil {
@@ -125,9 +182,43 @@ func (trap *Trap) IsValid() error {
 		if err := trap.HttpPayload.IsValid(); err != nil {
 			return err
 		}
+	case NetworkHoneypotTrap:
+		if err := trap.NetworkHoneypot.IsValid(); err != nil {
+			return err
+		}
+	case EnvVarHoneytokenTrap:
+		if err := trap.EnvVarHoneytoken.IsValid(); err != nil {
+			return err
+		}
+		// Environment variables of running processes cannot be changed, so we can only mutate Deployments
+		if trap.DecoyDeployment.Strategy != "volumeMount" {
+			return fmt.Errorf("EnvVarHoneytoken traps can only be deployed with the volumeMount strategy, not '%s'", trap.DecoyDeployment.Strategy)
+		}
+	case PluginTrapType:
+		if err := trap.Plugin.IsValid(); err != nil {
+			return err
+		}
 	default:
 		return fmt.Errorf("trap type is %T is unknown", trap)
 	}
 
+	switch trap.CaptorDeployment.Strategy {
+	case "falco":
+		// Falco rules only cover file-open events, so other trap types cannot be monitored with Falco
+		if trap.TrapType() != FilesystemHoneytokenTrap {
+			return fmt.Errorf("%s traps cannot be monitored with the falco strategy", trap.TrapType())
+		}
+	case "sidecar":
+		// Sidecars watch the volume of the decoy, so the decoy must be a file that is mounted as a volume
+		if trap.TrapType() != FilesystemHoneytokenTrap || (trap.DecoyDeployment.Strategy != "volumeMount" && trap.DecoyDeployment.Strategy != "initContainer") {
+			return errors.New("the sidecar strategy can only monitor FilesystemHoneytoken traps that are deployed with the volumeMount or initContainer strategy")
+		}
+	}
+
+	// Init containers copy the decoy file into a volume, so only files can be deployed this way
+	if trap.DecoyDeployment.Strategy == "initContainer" && trap.TrapType() != FilesystemHoneytokenTrap {
+		return fmt.Errorf("%s traps cannot be deployed with the initContainer strategy", trap.TrapType())
+	}
+
 	return nil
 }
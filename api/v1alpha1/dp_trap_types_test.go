@@ -170,4 +170,261 @@ var _ = Describe("IsValid", func() {
 			}
 		})
 	})
+
+	Context("when checking a trap with an unknown preset", func() {
+		It("should return error", func() {
+			for _, trap := range testTraps {
+				trap.Preset = "gcp-credentials"
+				err := trap.IsValid()
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).Should(ContainSubstring("preset 'gcp-credentials' is unknown"))
+			}
+		})
+	})
+
+	Context("when checking a filesystem honeytoken trap with the initContainer strategy and the sidecar captor strategy", func() {
+		It("should return no error", func() {
+			for _, trap := range testTraps {
+				trap.DecoyDeployment.Strategy = "initContainer"
+				trap.CaptorDeployment.Strategy = "sidecar"
+				Expect(trap.IsValid()).ShouldNot(HaveOccurred())
+			}
+		})
+	})
+})
+
+var _ = Describe("FileContentFrom", func() {
+	var externalSecretSource = FileContentSource{
+		ExternalSecret: &ExternalSecretSource{
+			SecretStoreRef: SecretStoreRef{Name: "vault", Kind: "ClusterSecretStore"},
+			RemoteRef:      ExternalSecretRemoteRef{Key: "canaries/service_token"},
+		},
+	}
+
+	Context("when checking a filesystem honeytoken trap that sources its content from an external secret", func() {
+		It("should return no error", func() {
+			for _, trap := range testTraps {
+				trap.FilesystemHoneytoken.FileContent = ""
+				trap.FilesystemHoneytoken.FileContentFrom = externalSecretSource.DeepCopy()
+				Expect(trap.IsValid()).ShouldNot(HaveOccurred())
+			}
+		})
+	})
+
+	Context("when checking a filesystem honeytoken trap with both FileContent and FileContentFrom", func() {
+		It("should return error", func() {
+			for _, trap := range testTraps {
+				trap.FilesystemHoneytoken.FileContentFrom = externalSecretSource.DeepCopy()
+				err := trap.IsValid()
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).Should(ContainSubstring("cannot be specified together"))
+			}
+		})
+	})
+
+	Context("when checking a filesystem honeytoken trap with an incomplete external secret reference", func() {
+		It("should return error", func() {
+			for _, trap := range testTraps {
+				trap.FilesystemHoneytoken.FileContent = ""
+				trap.FilesystemHoneytoken.FileContentFrom = externalSecretSource.DeepCopy()
+				trap.FilesystemHoneytoken.FileContentFrom.ExternalSecret.RemoteRef.Key = ""
+				err := trap.IsValid()
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).Should(ContainSubstring("RemoteRef.Key is empty"))
+			}
+		})
+	})
+
+	Context("when checking a filesystem honeytoken trap that sources its content from a Secret or ConfigMap", func() {
+		It("should return no error", func() {
+			for _, source := range []FileContentSource{
+				{SecretKeyRef: &ContentKeySelector{Name: "service-token", Key: "token"}},
+				{ConfigMapKeyRef: &ContentKeySelector{Name: "service-token", Key: "token"}},
+			} {
+				for _, trap := range testTraps {
+					trap.FilesystemHoneytoken.FileContent = ""
+					trap.FilesystemHoneytoken.FileContentFrom = source.DeepCopy()
+					Expect(trap.IsValid()).ShouldNot(HaveOccurred())
+				}
+			}
+		})
+
+		It("should return error if the key is missing", func() {
+			for _, trap := range testTraps {
+				trap.FilesystemHoneytoken.FileContent = ""
+				trap.FilesystemHoneytoken.FileContentFrom = &FileContentSource{SecretKeyRef: &ContentKeySelector{Name: "service-token"}}
+				Expect(trap.IsValid()).Should(MatchError(ContainSubstring("must not be empty")))
+			}
+		})
+	})
+
+	Context("when checking a filesystem honeytoken trap with generated content", func() {
+		It("should return no error", func() {
+			for _, generate := range []GeneratedContent{{}, {Length: 40, Prefix: "AKIA"}} {
+				for _, trap := range testTraps {
+					trap.FilesystemHoneytoken.FileContent = ""
+					trap.FilesystemHoneytoken.FileContentFrom = &FileContentSource{Generate: generate.DeepCopy()}
+					Expect(trap.IsValid()).ShouldNot(HaveOccurred())
+				}
+			}
+		})
+
+		It("should return error if the length is out of bounds", func() {
+			for _, length := range []int32{4, 5000} {
+				for _, trap := range testTraps {
+					trap.FilesystemHoneytoken.FileContent = ""
+					trap.FilesystemHoneytoken.FileContentFrom = &FileContentSource{Generate: &GeneratedContent{Length: length}}
+					Expect(trap.IsValid()).Should(MatchError(ContainSubstring("Generate.Length must be between")))
+				}
+			}
+		})
+	})
+
+	Context("when checking a filesystem honeytoken trap with a canary token", func() {
+		It("should return no error", func() {
+			for _, trap := range testTraps {
+				trap.FilesystemHoneytoken.FileContent = ""
+				trap.FilesystemHoneytoken.FileContentFrom = &FileContentSource{CanaryToken: &CanaryTokenSource{Kind: "aws-keys", Email: "security@example.com"}}
+				Expect(trap.IsValid()).ShouldNot(HaveOccurred())
+			}
+		})
+
+		It("should return error if the kind is unknown or alerts have no target", func() {
+			for _, trap := range testTraps {
+				trap.FilesystemHoneytoken.FileContent = ""
+				trap.FilesystemHoneytoken.FileContentFrom = &FileContentSource{CanaryToken: &CanaryTokenSource{Kind: "pdf", Email: "security@example.com"}}
+				Expect(trap.IsValid()).Should(MatchError(ContainSubstring("CanaryToken.Kind must be one of")))
+
+				trap.FilesystemHoneytoken.FileContentFrom = &FileContentSource{CanaryToken: &CanaryTokenSource{Kind: "web"}}
+				Expect(trap.IsValid()).Should(MatchError(ContainSubstring("Email or a WebhookURL")))
+			}
+		})
+	})
+
+	Context("when checking a filesystem honeytoken trap with more than one content source", func() {
+		It("should return error", func() {
+			for _, trap := range testTraps {
+				trap.FilesystemHoneytoken.FileContent = ""
+				trap.FilesystemHoneytoken.FileContentFrom = externalSecretSource.DeepCopy()
+				trap.FilesystemHoneytoken.FileContentFrom.SecretKeyRef = &ContentKeySelector{Name: "service-token", Key: "token"}
+				Expect(trap.IsValid()).Should(MatchError(ContainSubstring("more than one source")))
+			}
+		})
+	})
+})
+
+var _ = Describe("EnvVarHoneytoken", func() {
+	var envVarTrap = Trap{
+		EnvVarHoneytoken: EnvVarHoneytoken{
+			Name:  "AWS_SECRET_ACCESS_KEY",
+			Value: "wJalrXUtnFEMI/K7MDENG/bPxRfiCYEXAMPLEKEY",
+		},
+		DecoyDeployment: DecoyDeployment{
+			Strategy: "volumeMount",
+		},
+		MatchResources: MatchResources{
+			Any: []ResourceFilter{
+				{ResourceDescription: ResourceDescription{Namespaces: []string{"koney"}}},
+			},
+		},
+	}
+
+	Context("when checking a valid environment variable honeytoken trap", func() {
+		It("should return no error", func() {
+			Expect(envVarTrap.TrapType()).To(Equal(EnvVarHoneytokenTrap))
+			Expect(envVarTrap.IsValid()).ShouldNot(HaveOccurred())
+		})
+	})
+
+	Context("when checking an environment variable honeytoken trap with an invalid name", func() {
+		It("should return error", func() {
+			trap := envVarTrap
+			trap.EnvVarHoneytoken.Name = "1=AWS"
+			err := trap.IsValid()
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).Should(ContainSubstring("not a valid environment variable name"))
+		})
+	})
+
+	Context("when checking an environment variable honeytoken trap with the containerExec strategy", func() {
+		It("should return error", func() {
+			trap := envVarTrap
+			trap.DecoyDeployment.Strategy = "containerExec"
+			err := trap.IsValid()
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).Should(ContainSubstring("only be deployed with the volumeMount strategy"))
+		})
+	})
+
+	Context("when checking an environment variable honeytoken trap with the falco captor strategy", func() {
+		It("should return error", func() {
+			trap := envVarTrap
+			trap.CaptorDeployment.Strategy = "falco"
+			err := trap.IsValid()
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).Should(ContainSubstring("cannot be monitored with the falco strategy"))
+		})
+	})
+
+	Context("when checking an environment variable honeytoken trap with the sidecar captor strategy", func() {
+		It("should return error", func() {
+			trap := envVarTrap
+			trap.CaptorDeployment.Strategy = "sidecar"
+			err := trap.IsValid()
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).Should(ContainSubstring("sidecar strategy can only monitor FilesystemHoneytoken traps"))
+		})
+	})
+})
+
+var _ = Describe("PluginTrap", func() {
+	var pluginTrap = Trap{
+		Plugin: PluginTrap{
+			Name:   "acme-db-records",
+			Type:   "customer-record",
+			Config: `{"table":"customers"}`,
+		},
+		DecoyDeployment: DecoyDeployment{Strategy: "volumeMount"},
+		MatchResources: MatchResources{
+			Any: []ResourceFilter{{ResourceDescription: ResourceDescription{Namespaces: []string{"koney"}}}},
+		},
+	}
+
+	Context("when checking a valid plugin trap", func() {
+		It("should return no error", func() {
+			trap := pluginTrap
+			Expect(trap.TrapType()).To(Equal(PluginTrapType))
+			Expect(trap.IsValid()).ShouldNot(HaveOccurred())
+		})
+	})
+
+	Context("when checking a plugin trap with an invalid name", func() {
+		It("should return error", func() {
+			trap := pluginTrap
+			trap.Plugin.Name = "../acme"
+			err := trap.IsValid()
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).Should(ContainSubstring("not a valid plugin name"))
+		})
+	})
+
+	Context("when checking a plugin trap with the falco captor strategy", func() {
+		It("should return error", func() {
+			trap := pluginTrap
+			trap.CaptorDeployment.Strategy = "falco"
+			err := trap.IsValid()
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).Should(ContainSubstring("cannot be monitored with the falco strategy"))
+		})
+	})
+
+	Context("when checking a plugin trap with the initContainer strategy", func() {
+		It("should return error", func() {
+			trap := pluginTrap
+			trap.DecoyDeployment.Strategy = "initContainer"
+			err := trap.IsValid()
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).Should(ContainSubstring("cannot be deployed with the initContainer strategy"))
+		})
+	})
 })
@@ -0,0 +1,85 @@
+// Copyright (c) 2025 Dynatrace LLC
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// KoneyConfigName is the name of the singleton KoneyConfig that Koney reads its settings from.
+const KoneyConfigName = "koney"
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster,categories=security
+// +kubebuilder:validation:XValidation:rule="self.metadata.name == 'koney'",message="the KoneyConfig must be named koney"
+
+// KoneyConfig is the Schema for the koneyconfigs API.
+// It holds operator-wide settings that Koney picks up without a restart.
+// Only a single KoneyConfig named "koney" is read, and its settings take precedence over the command-line flags.
+type KoneyConfig struct {
+	metav1.TypeMeta `json:",inline" yaml:",inline"`
+
+	// Standard object's metadata.
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty" yaml:"metadata,omitempty"`
+
+	// Spec is the specification of the KoneyConfig.
+	Spec KoneyConfigSpec `json:"spec,omitempty" yaml:"spec,omitempty"`
+}
+
+// KoneyConfigSpec defines the operator-wide settings of Koney.
+type KoneyConfigSpec struct {
+	// Alerting configures where the alerts of all DeceptionPolicies are sent to,
+	// in addition to the DeceptionAlertSinks and the alerting settings of each policy.
+	// +optional
+	Alerting *Alerting `json:"alerting,omitempty" yaml:"alerting,omitempty"`
+
+	// DefaultCaptorStrategy is the captor strategy of traps that do not set one.
+	// If not set, "tetragon" is used.
+	// +kubebuilder:validation:Enum=tetragon;falco;sidecar
+	// +optional
+	DefaultCaptorStrategy string `json:"defaultCaptorStrategy,omitempty" yaml:"defaultCaptorStrategy,omitempty"`
+
+	// MaxConcurrentDeployments is the maximum number of workloads that decoys and captors are deployed to in parallel.
+	// If not set, the value of the --max-concurrent-deployments flag is used.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxConcurrentDeployments *int32 `json:"maxConcurrentDeployments,omitempty" yaml:"maxConcurrentDeployments,omitempty"`
+
+	// ExcludedNamespaces is a list of namespaces in which no traps are deployed, regardless of the policies.
+	// If not set, the system namespaces kube-system, kube-public, kube-node-lease, and koney-system are excluded.
+	// Set it to an empty list to allow traps in all namespaces, except for koney-system, which is always excluded.
+	// +optional
+	ExcludedNamespaces []string `json:"excludedNamespaces,omitempty" yaml:"excludedNamespaces,omitempty"`
+
+	// AlertWebhookHost is the host that captors send their alerts to.
+	// If not set, the value of the --alert-webhook-host flag is used.
+	// +optional
+	AlertWebhookHost string `json:"alertWebhookHost,omitempty" yaml:"alertWebhookHost,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// KoneyConfigList contains a list of KoneyConfig
+type KoneyConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []KoneyConfig `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&KoneyConfig{}, &KoneyConfigList{})
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2025 Dynatrace LLC
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Namespaced,shortName=ndp,categories=security
+// +kubebuilder:printcolumn:name="Valid",type=string,JSONPath=`.status.conditions[?(@.type=="PolicyValid")].status`
+// +kubebuilder:printcolumn:name="Decoys",type=string,JSONPath=`.status.conditions[?(@.type=="DecoysDeployed")].status`
+// +kubebuilder:printcolumn:name="Captors",type=string,JSONPath=`.status.conditions[?(@.type=="CaptorsDeployed")].status`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+// +kubebuilder:printcolumn:name="Decoy Status",type=string,JSONPath=`.status.conditions[?(@.type=="DecoysDeployed")].message`,priority=1
+// +kubebuilder:printcolumn:name="Captor Status",type=string,JSONPath=`.status.conditions[?(@.type=="CaptorsDeployed")].message`,priority=1
+// +kubebuilder:printcolumn:name="Progress",type=string,JSONPath=`.status.decoyProgress.summary`,priority=1
+
+// NamespacedDeceptionPolicy is the Schema for the namespaceddeceptionpolicies API.
+// It works like a DeceptionPolicy, but traps only match resources in the namespace of the policy,
+// so that namespace admins can manage the traps of their own namespace.
+type NamespacedDeceptionPolicy struct {
+	metav1.TypeMeta `json:",inline" yaml:",inline"`
+
+	// Standard object's metadata.
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty" yaml:"metadata,omitempty"`
+
+	// Spec is the specification of the NamespacedDeceptionPolicy.
+	// The namespaces of all resource filters must be empty or equal to the namespace of the policy.
+	// Traps must not source their content from secret stores, and alert webhooks must not reference secrets,
+	// because these would be resolved with the permissions of Koney in its own namespace.
+	Spec DeceptionPolicySpec `json:"spec,omitempty" yaml:"spec,omitempty"`
+
+	// Status is the status of the NamespacedDeceptionPolicy.
+	// It mirrors the status of the DeceptionPolicy that Koney manages for this policy.
+	Status DeceptionPolicyStatus `json:"status,omitempty" yaml:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// NamespacedDeceptionPolicyList contains a list of NamespacedDeceptionPolicy
+type NamespacedDeceptionPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NamespacedDeceptionPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&NamespacedDeceptionPolicy{}, &NamespacedDeceptionPolicyList{})
+}
@@ -9,6 +9,63 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertWebhook) DeepCopyInto(out *AlertWebhook) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(WebhookSecretRef)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertWebhook.
+func (in *AlertWebhook) DeepCopy() *AlertWebhook {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertWebhook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Alerting) DeepCopyInto(out *Alerting) {
+	*out = *in
+	if in.Webhooks != nil {
+		in, out := &in.Webhooks, &out.Webhooks
+		*out = make([]AlertWebhook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Alerting.
+func (in *Alerting) DeepCopy() *Alerting {
+	if in == nil {
+		return nil
+	}
+	out := new(Alerting)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CanaryTokenSource) DeepCopyInto(out *CanaryTokenSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanaryTokenSource.
+func (in *CanaryTokenSource) DeepCopy() *CanaryTokenSource {
+	if in == nil {
+		return nil
+	}
+	out := new(CanaryTokenSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CaptorDeployment) DeepCopyInto(out *CaptorDeployment) {
 	*out = *in
@@ -46,6 +103,21 @@ func (in *ChangeAnnotation) DeepCopy() *ChangeAnnotation {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContentKeySelector) DeepCopyInto(out *ContentKeySelector) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContentKeySelector.
+func (in *ContentKeySelector) DeepCopy() *ContentKeySelector {
+	if in == nil {
+		return nil
+	}
+	out := new(ContentKeySelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DeceptionAlertSink) DeepCopyInto(out *DeceptionAlertSink) {
 	*out = *in
@@ -215,6 +287,26 @@ func (in *DeceptionPolicySpec) DeepCopyInto(out *DeceptionPolicySpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Alerting != nil {
+		in, out := &in.Alerting, &out.Alerting
+		*out = new(Alerting)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SyncInterval != nil {
+		in, out := &in.SyncInterval, &out.SyncInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.RetryInterval != nil {
+		in, out := &in.RetryInterval, &out.RetryInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Rotate != nil {
+		in, out := &in.Rotate, &out.Rotate
+		*out = new(v1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeceptionPolicySpec.
@@ -237,6 +329,16 @@ func (in *DeceptionPolicyStatus) DeepCopyInto(out *DeceptionPolicyStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.DecoyProgress != nil {
+		in, out := &in.DecoyProgress, &out.DecoyProgress
+		*out = new(DeploymentProgress)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SkippedNamespaces != nil {
+		in, out := &in.SkippedNamespaces, &out.SkippedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeceptionPolicyStatus.
@@ -264,6 +366,41 @@ func (in *DecoyDeployment) DeepCopy() *DecoyDeployment {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeploymentProgress) DeepCopyInto(out *DeploymentProgress) {
+	*out = *in
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]DeploymentTarget, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentProgress.
+func (in *DeploymentProgress) DeepCopy() *DeploymentProgress {
+	if in == nil {
+		return nil
+	}
+	out := new(DeploymentProgress)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeploymentTarget) DeepCopyInto(out *DeploymentTarget) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentTarget.
+func (in *DeploymentTarget) DeepCopy() *DeploymentTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(DeploymentTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DynatraceSinkSpec) DeepCopyInto(out *DynatraceSinkSpec) {
 	*out = *in
@@ -279,9 +416,116 @@ func (in *DynatraceSinkSpec) DeepCopy() *DynatraceSinkSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvVarHoneytoken) DeepCopyInto(out *EnvVarHoneytoken) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvVarHoneytoken.
+func (in *EnvVarHoneytoken) DeepCopy() *EnvVarHoneytoken {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvVarHoneytoken)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvVarHoneytokenAnnotation) DeepCopyInto(out *EnvVarHoneytokenAnnotation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvVarHoneytokenAnnotation.
+func (in *EnvVarHoneytokenAnnotation) DeepCopy() *EnvVarHoneytokenAnnotation {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvVarHoneytokenAnnotation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalSecretRemoteRef) DeepCopyInto(out *ExternalSecretRemoteRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalSecretRemoteRef.
+func (in *ExternalSecretRemoteRef) DeepCopy() *ExternalSecretRemoteRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalSecretRemoteRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalSecretSource) DeepCopyInto(out *ExternalSecretSource) {
+	*out = *in
+	out.SecretStoreRef = in.SecretStoreRef
+	out.RemoteRef = in.RemoteRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalSecretSource.
+func (in *ExternalSecretSource) DeepCopy() *ExternalSecretSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalSecretSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FileContentSource) DeepCopyInto(out *FileContentSource) {
+	*out = *in
+	if in.ExternalSecret != nil {
+		in, out := &in.ExternalSecret, &out.ExternalSecret
+		*out = new(ExternalSecretSource)
+		**out = **in
+	}
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(ContentKeySelector)
+		**out = **in
+	}
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		*out = new(ContentKeySelector)
+		**out = **in
+	}
+	if in.Generate != nil {
+		in, out := &in.Generate, &out.Generate
+		*out = new(GeneratedContent)
+		**out = **in
+	}
+	if in.CanaryToken != nil {
+		in, out := &in.CanaryToken, &out.CanaryToken
+		*out = new(CanaryTokenSource)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FileContentSource.
+func (in *FileContentSource) DeepCopy() *FileContentSource {
+	if in == nil {
+		return nil
+	}
+	out := new(FileContentSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FilesystemHoneytoken) DeepCopyInto(out *FilesystemHoneytoken) {
 	*out = *in
+	if in.FileContentFrom != nil {
+		in, out := &in.FileContentFrom, &out.FileContentFrom
+		*out = new(FileContentSource)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilesystemHoneytoken.
@@ -309,6 +553,21 @@ func (in *FilesystemHoneytokenAnnotation) DeepCopy() *FilesystemHoneytokenAnnota
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GeneratedContent) DeepCopyInto(out *GeneratedContent) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GeneratedContent.
+func (in *GeneratedContent) DeepCopy() *GeneratedContent {
+	if in == nil {
+		return nil
+	}
+	out := new(GeneratedContent)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HttpEndpoint) DeepCopyInto(out *HttpEndpoint) {
 	*out = *in
@@ -369,6 +628,94 @@ func (in *HttpPayloadAnnotation) DeepCopy() *HttpPayloadAnnotation {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KoneyConfig) DeepCopyInto(out *KoneyConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KoneyConfig.
+func (in *KoneyConfig) DeepCopy() *KoneyConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(KoneyConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KoneyConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KoneyConfigList) DeepCopyInto(out *KoneyConfigList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KoneyConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KoneyConfigList.
+func (in *KoneyConfigList) DeepCopy() *KoneyConfigList {
+	if in == nil {
+		return nil
+	}
+	out := new(KoneyConfigList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KoneyConfigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KoneyConfigSpec) DeepCopyInto(out *KoneyConfigSpec) {
+	*out = *in
+	if in.Alerting != nil {
+		in, out := &in.Alerting, &out.Alerting
+		*out = new(Alerting)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaxConcurrentDeployments != nil {
+		in, out := &in.MaxConcurrentDeployments, &out.MaxConcurrentDeployments
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ExcludedNamespaces != nil {
+		in, out := &in.ExcludedNamespaces, &out.ExcludedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KoneyConfigSpec.
+func (in *KoneyConfigSpec) DeepCopy() *KoneyConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KoneyConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MatchResources) DeepCopyInto(out *MatchResources) {
 	*out = *in
@@ -391,6 +738,95 @@ func (in *MatchResources) DeepCopy() *MatchResources {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespacedDeceptionPolicy) DeepCopyInto(out *NamespacedDeceptionPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespacedDeceptionPolicy.
+func (in *NamespacedDeceptionPolicy) DeepCopy() *NamespacedDeceptionPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespacedDeceptionPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NamespacedDeceptionPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespacedDeceptionPolicyList) DeepCopyInto(out *NamespacedDeceptionPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NamespacedDeceptionPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespacedDeceptionPolicyList.
+func (in *NamespacedDeceptionPolicyList) DeepCopy() *NamespacedDeceptionPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespacedDeceptionPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NamespacedDeceptionPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkHoneypot) DeepCopyInto(out *NetworkHoneypot) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkHoneypot.
+func (in *NetworkHoneypot) DeepCopy() *NetworkHoneypot {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkHoneypot)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PluginTrap) DeepCopyInto(out *PluginTrap) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginTrap.
+func (in *PluginTrap) DeepCopy() *PluginTrap {
+	if in == nil {
+		return nil
+	}
+	out := new(PluginTrap)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceDescription) DeepCopyInto(out *ResourceDescription) {
 	*out = *in
@@ -432,12 +868,30 @@ func (in *ResourceFilter) DeepCopy() *ResourceFilter {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretStoreRef) DeepCopyInto(out *SecretStoreRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretStoreRef.
+func (in *SecretStoreRef) DeepCopy() *SecretStoreRef {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretStoreRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Trap) DeepCopyInto(out *Trap) {
 	*out = *in
-	out.FilesystemHoneytoken = in.FilesystemHoneytoken
+	in.FilesystemHoneytoken.DeepCopyInto(&out.FilesystemHoneytoken)
 	out.HttpEndpoint = in.HttpEndpoint
 	out.HttpPayload = in.HttpPayload
+	out.NetworkHoneypot = in.NetworkHoneypot
+	out.EnvVarHoneytoken = in.EnvVarHoneytoken
+	out.Plugin = in.Plugin
 	out.DecoyDeployment = in.DecoyDeployment
 	out.CaptorDeployment = in.CaptorDeployment
 	in.MatchResources.DeepCopyInto(&out.MatchResources)
@@ -464,6 +918,7 @@ func (in *TrapAnnotation) DeepCopyInto(out *TrapAnnotation) {
 	out.FilesystemHoneytoken = in.FilesystemHoneytoken
 	out.HttpEndpoint = in.HttpEndpoint
 	out.HttpPayload = in.HttpPayload
+	out.EnvVarHoneytoken = in.EnvVarHoneytoken
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrapAnnotation.
@@ -475,3 +930,18 @@ func (in *TrapAnnotation) DeepCopy() *TrapAnnotation {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookSecretRef) DeepCopyInto(out *WebhookSecretRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookSecretRef.
+func (in *WebhookSecretRef) DeepCopy() *WebhookSecretRef {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookSecretRef)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,136 @@
+// Copyright (c) 2025 Dynatrace LLC
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/dynatrace-oss/koney/api/v1alpha1"
+	"github.com/dynatrace-oss/koney/internal/controller/annotations"
+)
+
+// decoyRow is a decoy that was placed on a resource, as recorded in the changes annotation.
+type decoyRow struct {
+	Namespace  string
+	Kind       string
+	Name       string
+	PolicyName string
+	Trap       v1alpha1.TrapAnnotation
+}
+
+// runDecoys shows which pods and deployments (and which of their containers) carry which decoys.
+func runDecoys(ctx context.Context, args []string) error {
+	fs := newFlagSet("decoys", "[-n NAMESPACE] [--policy NAME]")
+	namespace := fs.String("n", "", "Only show decoys in this namespace.")
+	policyName := fs.String("policy", "", "Only show decoys of this deception policy.")
+	_ = fs.Parse(args)
+
+	c, _, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	var resources []client.Object
+	pods := corev1.PodList{}
+	if err := c.List(ctx, &pods, client.InNamespace(*namespace)); err != nil {
+		return err
+	}
+	for i := range pods.Items {
+		resources = append(resources, &pods.Items[i])
+	}
+	deployments := appsv1.DeploymentList{}
+	if err := c.List(ctx, &deployments, client.InNamespace(*namespace)); err != nil {
+		return err
+	}
+	for i := range deployments.Items {
+		resources = append(resources, &deployments.Items[i])
+	}
+
+	var rows []decoyRow
+	for _, resource := range resources {
+		resourceRows, err := collectDecoys(ctx, c, resource)
+		if err != nil {
+			return fmt.Errorf("unable to read the changes of %s/%s: %w", resource.GetNamespace(), resource.GetName(), err)
+		}
+		for _, row := range resourceRows {
+			if *policyName == "" || row.PolicyName == *policyName {
+				rows = append(rows, row)
+			}
+		}
+	}
+
+	table := newTable(os.Stdout)
+	fmt.Fprintln(table, "NAMESPACE\tKIND\tNAME\tPOLICY\tTYPE\tDECOY\tSTRATEGY\tCONTAINERS\tUPDATED")
+	for _, row := range rows {
+		updatedAt := row.Trap.UpdatedAt
+		if updatedAt == "" {
+			updatedAt = row.Trap.CreatedAt
+		}
+		fmt.Fprintf(table, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", row.Namespace, row.Kind, row.Name, row.PolicyName,
+			row.Trap.TrapType(), describeTrapAnnotation(row.Trap), row.Trap.DeploymentStrategy, strings.Join(row.Trap.Containers, ","), updatedAt)
+	}
+	return table.Flush()
+}
+
+// collectDecoys parses the decoys from the changes annotation of a resource,
+// including the changes that were spilled into a companion ConfigMap.
+func collectDecoys(ctx context.Context, c client.Reader, resource client.Object) ([]decoyRow, error) {
+	if err := annotations.LoadSpilledChanges(c, ctx, resource); err != nil {
+		return nil, err
+	}
+	changes, err := annotations.GetChanges(resource)
+	if err != nil {
+		return nil, err
+	}
+
+	kind := "Pod"
+	if _, ok := resource.(*appsv1.Deployment); ok {
+		kind = "Deployment"
+	}
+
+	var rows []decoyRow
+	for _, change := range changes {
+		for _, trap := range change.Traps {
+			rows = append(rows, decoyRow{
+				Namespace:  resource.GetNamespace(),
+				Kind:       kind,
+				Name:       resource.GetName(),
+				PolicyName: change.DeceptionPolicyName,
+				Trap:       trap,
+			})
+		}
+	}
+	return rows, nil
+}
+
+// describeTrapAnnotation returns the configuration that identifies a decoy on a resource.
+func describeTrapAnnotation(trap v1alpha1.TrapAnnotation) string {
+	switch trap.TrapType() {
+	case v1alpha1.FilesystemHoneytokenTrap:
+		return trap.FilesystemHoneytoken.FilePath
+	case v1alpha1.EnvVarHoneytokenTrap:
+		return trap.EnvVarHoneytoken.Name
+	default:
+		return "-"
+	}
+}
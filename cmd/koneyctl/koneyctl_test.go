@@ -0,0 +1,128 @@
+// Copyright (c) 2025 Dynatrace LLC
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"bytes"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/dynatrace-oss/koney/api/v1alpha1"
+)
+
+const validPolicy = `apiVersion: research.dynatrace.com/v1alpha1
+kind: DeceptionPolicy
+metadata:
+  name: valid-policy
+spec:
+  traps:
+    - filesystemHoneytoken:
+        filePath: /run/secrets/koney/service_token
+        fileContent: "someverysecrettoken"
+      match:
+        any:
+          - resources:
+              selector:
+                matchLabels:
+                  app: koney-demo
+`
+
+var _ = Describe("validate", func() {
+	It("should accept a valid policy and ignore other kinds", func() {
+		manifest := validPolicy + "---\napiVersion: v1\nkind: Namespace\nmetadata:\n  name: other\n"
+		out := &bytes.Buffer{}
+
+		problems, err := validateManifests(strings.NewReader(manifest), out)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(problems).To(Equal(0))
+		Expect(out.String()).To(ContainSubstring("1 DeceptionPolicy object(s) valid"))
+	})
+
+	It("should report unknown fields", func() {
+		manifest := strings.Replace(validPolicy, "filePath:", "filepath:", 1)
+		out := &bytes.Buffer{}
+
+		problems, err := validateManifests(strings.NewReader(manifest), out)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(problems).To(Equal(1))
+		Expect(out.String()).To(ContainSubstring("valid-policy"))
+	})
+
+	It("should report invalid traps", func() {
+		manifest := strings.Replace(validPolicy, "/run/secrets/koney/service_token", "relative/path", 1)
+		out := &bytes.Buffer{}
+
+		problems, err := validateManifests(strings.NewReader(manifest), out)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(problems).To(Equal(1))
+		Expect(out.String()).To(ContainSubstring("traps[0]"))
+	})
+
+	It("should accept traps that only reference a preset", func() {
+		manifest := strings.Replace(validPolicy, `    - filesystemHoneytoken:
+        filePath: /run/secrets/koney/service_token
+        fileContent: "someverysecrettoken"
+`, "    - preset: aws-credentials\n", 1)
+		out := &bytes.Buffer{}
+
+		problems, err := validateManifests(strings.NewReader(manifest), out)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(problems).To(Equal(0))
+	})
+
+	It("should fail if there is no policy", func() {
+		_, err := validateManifests(strings.NewReader("apiVersion: v1\nkind: Namespace\n"), &bytes.Buffer{})
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("selectTestDecoy", func() {
+	filesystemDecoy := decoyRow{
+		PolicyName: "policy-a",
+		Trap: v1alpha1.TrapAnnotation{
+			Containers:           []string{"app", "sidecar"},
+			FilesystemHoneytoken: v1alpha1.FilesystemHoneytokenAnnotation{FilePath: "/run/secrets/token"},
+		},
+	}
+	envVarDecoy := decoyRow{
+		PolicyName: "policy-b",
+		Trap: v1alpha1.TrapAnnotation{
+			Containers:       []string{"app"},
+			EnvVarHoneytoken: v1alpha1.EnvVarHoneytokenAnnotation{Name: "API_TOKEN"},
+		},
+	}
+
+	It("should read the file of a filesystem honeytoken", func() {
+		decoy, container, cmd, ok := selectTestDecoy([]decoyRow{filesystemDecoy, envVarDecoy}, "", "")
+		Expect(ok).To(BeTrue())
+		Expect(decoy.PolicyName).To(Equal("policy-a"))
+		Expect(container).To(Equal("app"))
+		Expect(cmd).To(Equal([]string{"cat", "/run/secrets/token"}))
+	})
+
+	It("should honor the policy and container filters", func() {
+		decoy, container, cmd, ok := selectTestDecoy([]decoyRow{filesystemDecoy, envVarDecoy}, "policy-b", "app")
+		Expect(ok).To(BeTrue())
+		Expect(decoy.PolicyName).To(Equal("policy-b"))
+		Expect(container).To(Equal("app"))
+		Expect(cmd).To(Equal([]string{"cat", "/proc/1/environ"}))
+
+		_, _, _, ok = selectTestDecoy([]decoyRow{filesystemDecoy, envVarDecoy}, "policy-b", "sidecar")
+		Expect(ok).To(BeFalse())
+	})
+})
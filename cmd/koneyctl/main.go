@@ -0,0 +1,130 @@
+// Copyright (c) 2025 Dynatrace LLC
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Command koneyctl inspects Koney's deception policies and traps.
+// Installed as kubectl-koney on the PATH, it also works as a kubectl plugin ("kubectl koney").
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
+	_ "k8s.io/client-go/plugin/pkg/client/auth"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
+
+	"github.com/dynatrace-oss/koney/pkg/operator"
+)
+
+// command is a subcommand of koneyctl.
+type command struct {
+	name    string
+	summary string
+	run     func(ctx context.Context, args []string) error
+}
+
+var commands = []command{
+	{name: "traps", summary: "List the traps of all deception policies and their deployment status", run: runTraps},
+	{name: "decoys", summary: "Show which pods and containers carry which decoys", run: runDecoys},
+	{name: "test-alert", summary: "Trigger a test alert by accessing a decoy in a pod", run: runTestAlert},
+	{name: "validate", summary: "Validate DeceptionPolicy manifests offline", run: runValidate},
+}
+
+func main() {
+	flag.Usage = printUsage
+	flag.Parse() // Parses global flags such as --kubeconfig
+
+	if flag.NArg() == 0 {
+		printUsage()
+		os.Exit(2)
+	}
+
+	name, args := flag.Arg(0), flag.Args()[1:]
+	for _, cmd := range commands {
+		if cmd.name == name {
+			if err := cmd.run(context.Background(), args); err != nil {
+				fmt.Fprintf(os.Stderr, "error: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "error: unknown command '%s'\n\n", name)
+	printUsage()
+	os.Exit(2)
+}
+
+// programName returns how the user invoked koneyctl, so that usage messages also fit the kubectl plugin.
+func programName() string {
+	if strings.HasPrefix(filepath.Base(os.Args[0]), "kubectl-") {
+		return "kubectl koney"
+	}
+	return "koneyctl"
+}
+
+func printUsage() {
+	out := flag.CommandLine.Output()
+	fmt.Fprintf(out, "Usage: %s [global flags] <command> [flags]\n\nCommands:\n", programName())
+	for _, cmd := range commands {
+		fmt.Fprintf(out, "  %-12s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintf(out, "\nGlobal flags:\n")
+	flag.PrintDefaults()
+}
+
+// newFlagSet creates the flag set of a subcommand.
+func newFlagSet(name, usage string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: %s %s %s\n", programName(), name, usage)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// newClient creates a client for the cluster of the current kubeconfig context.
+func newClient() (client.Client, *rest.Config, error) {
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	scheme := runtime.NewScheme()
+	if err := operator.AddToScheme(scheme); err != nil {
+		return nil, nil, err
+	}
+
+	c, err := client.New(cfg, client.Options{Scheme: scheme})
+	if err != nil {
+		return nil, nil, err
+	}
+	return c, cfg, nil
+}
+
+// newTable creates a writer that aligns tab-separated columns.
+func newTable(out io.Writer) *tabwriter.Writer {
+	return tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+}
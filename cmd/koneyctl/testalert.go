@@ -0,0 +1,162 @@
+// Copyright (c) 2025 Dynatrace LLC
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/dynatrace-oss/koney/api/v1alpha1"
+	"github.com/dynatrace-oss/koney/internal/controller/constants"
+	"github.com/dynatrace-oss/koney/internal/controller/utils"
+)
+
+// runTestAlert accesses a decoy in a pod, just like an attacker would, so that the captor raises a real alert.
+func runTestAlert(ctx context.Context, args []string) error {
+	fs := newFlagSet("test-alert", "[-n NAMESPACE] [-c CONTAINER] [--policy NAME] POD")
+	namespace := fs.String("n", "default", "The namespace of the pod.")
+	container := fs.String("c", "", "The container to access the decoy in. Defaults to the first container with a decoy.")
+	policyName := fs.String("policy", "", "Only access decoys of this deception policy.")
+	_ = fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return errors.New("exactly one pod must be specified")
+	}
+
+	c, cfg, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	pod := corev1.Pod{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: *namespace, Name: fs.Arg(0)}, &pod); err != nil {
+		return err
+	}
+
+	decoys, err := collectPodDecoys(ctx, c, &pod)
+	if err != nil {
+		return err
+	}
+
+	decoy, containerName, cmd, ok := selectTestDecoy(decoys, *policyName, *container)
+	if !ok {
+		return fmt.Errorf("pod %s/%s has no filesystem or environment variable honeytoken that can be accessed", pod.Namespace, pod.Name)
+	}
+
+	fmt.Printf("Accessing %s decoy '%s' of policy '%s' in container '%s' of pod %s/%s ...\n",
+		decoy.Trap.TrapType(), describeTrapAnnotation(decoy.Trap), decoy.PolicyName, containerName, pod.Namespace, pod.Name)
+	if err := execInContainer(ctx, cfg, &pod, containerName, cmd); err != nil {
+		return err
+	}
+
+	fmt.Printf("Done. The alert forwarder should report the access shortly:\n"+
+		"  kubectl logs -n %s -l control-plane=controller-manager -c alerts --since 5m\n", constants.KoneyNamespace)
+	return nil
+}
+
+// collectPodDecoys returns the decoys of a pod, including the decoys that were placed on the deployments that own it.
+func collectPodDecoys(ctx context.Context, c client.Reader, pod *corev1.Pod) ([]decoyRow, error) {
+	decoys, err := collectDecoys(ctx, c, pod)
+	if err != nil {
+		return nil, err
+	}
+
+	deployments := appsv1.DeploymentList{}
+	if err := c.List(ctx, &deployments, client.InNamespace(pod.Namespace)); err != nil {
+		return nil, err
+	}
+	for i := range deployments.Items {
+		selector, err := metav1.LabelSelectorAsSelector(deployments.Items[i].Spec.Selector)
+		if err != nil || !selector.Matches(labels.Set(pod.Labels)) {
+			continue
+		}
+
+		deploymentDecoys, err := collectDecoys(ctx, c, &deployments.Items[i])
+		if err != nil {
+			return nil, err
+		}
+		decoys = append(decoys, deploymentDecoys...)
+	}
+
+	return decoys, nil
+}
+
+// selectTestDecoy picks the first decoy that can be accessed with a simple command, and the container to access it in.
+func selectTestDecoy(decoys []decoyRow, policyName, container string) (decoyRow, string, []string, bool) {
+	for _, decoy := range decoys {
+		if policyName != "" && decoy.PolicyName != policyName {
+			continue
+		}
+		if len(decoy.Trap.Containers) == 0 || (container != "" && !utils.Contains(decoy.Trap.Containers, container)) {
+			continue
+		}
+
+		containerName := container
+		if containerName == "" {
+			containerName = decoy.Trap.Containers[0]
+		}
+
+		switch decoy.Trap.TrapType() {
+		case v1alpha1.FilesystemHoneytokenTrap:
+			return decoy, containerName, []string{"cat", decoy.Trap.FilesystemHoneytoken.FilePath}, true
+		case v1alpha1.EnvVarHoneytokenTrap:
+			// Captors of environment variable honeytokens watch for reads of the environment of processes
+			return decoy, containerName, []string{"cat", "/proc/1/environ"}, true
+		}
+	}
+
+	return decoyRow{}, "", nil, false
+}
+
+// execInContainer runs a command in a container and discards its output, so that decoy values are not printed.
+func execInContainer(ctx context.Context, cfg *rest.Config, pod *corev1.Pod, containerName string, cmd []string) error {
+	clientset, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return err
+	}
+
+	req := clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Name(pod.Name).
+		Namespace(pod.Namespace).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Command:   cmd,
+			Container: containerName,
+			Stdout:    true,
+			Stderr:    true,
+		}, scheme.ParameterCodec)
+
+	exec, err := remotecommand.NewSPDYExecutor(cfg, "POST", req.URL())
+	if err != nil {
+		return err
+	}
+
+	return exec.StreamWithContext(ctx, remotecommand.StreamOptions{Stdout: io.Discard, Stderr: io.Discard})
+}
@@ -0,0 +1,101 @@
+// Copyright (c) 2025 Dynatrace LLC
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/dynatrace-oss/koney/api/v1alpha1"
+	"github.com/dynatrace-oss/koney/internal/controller"
+	"github.com/dynatrace-oss/koney/internal/controller/presets"
+)
+
+// runTraps lists the traps of all deception policies, together with the deployment status of their policy.
+func runTraps(ctx context.Context, args []string) error {
+	fs := newFlagSet("traps", "[--policy NAME]")
+	policyName := fs.String("policy", "", "Only list the traps of this deception policy.")
+	_ = fs.Parse(args)
+
+	c, _, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	policies, err := listPolicies(ctx, c, *policyName)
+	if err != nil {
+		return err
+	}
+
+	table := newTable(os.Stdout)
+	fmt.Fprintln(table, "POLICY\tTYPE\tTRAP\tDECOY\tCAPTOR\tDECOYS DEPLOYED\tCAPTORS DEPLOYED")
+	for _, policy := range policies {
+		decoysDeployed := describeCondition(policy.Status.GetCondition(controller.DecoysDeployedType))
+		captorsDeployed := describeCondition(policy.Status.GetCondition(controller.CaptorsDeployedType))
+		for _, trap := range presets.ExpandPresets(&policy).Spec.Traps {
+			fmt.Fprintf(table, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", policy.Name, trap.TrapType(), describeTrap(trap),
+				trap.DecoyDeployment.Strategy, trap.CaptorDeployment.Strategy, decoysDeployed, captorsDeployed)
+		}
+	}
+	return table.Flush()
+}
+
+// listPolicies lists all deception policies, or only the one with the given name.
+func listPolicies(ctx context.Context, c client.Reader, policyName string) ([]v1alpha1.DeceptionPolicy, error) {
+	if policyName != "" {
+		policy := v1alpha1.DeceptionPolicy{}
+		if err := c.Get(ctx, client.ObjectKey{Name: policyName}, &policy); err != nil {
+			return nil, err
+		}
+		return []v1alpha1.DeceptionPolicy{policy}, nil
+	}
+
+	policies := v1alpha1.DeceptionPolicyList{}
+	if err := c.List(ctx, &policies); err != nil {
+		return nil, err
+	}
+	return policies.Items, nil
+}
+
+// describeTrap returns the configuration that identifies a trap within its policy.
+func describeTrap(trap v1alpha1.Trap) string {
+	switch trap.TrapType() {
+	case v1alpha1.FilesystemHoneytokenTrap:
+		return trap.FilesystemHoneytoken.FilePath
+	case v1alpha1.EnvVarHoneytokenTrap:
+		return trap.EnvVarHoneytoken.Name
+	case v1alpha1.NetworkHoneypotTrap:
+		return fmt.Sprintf("%s (%s/%d)", trap.NetworkHoneypot.ServiceName, trap.NetworkHoneypot.Protocol, trap.NetworkHoneypot.GetPort())
+	case v1alpha1.PluginTrapType:
+		if trap.Plugin.Type != "" {
+			return trap.Plugin.Name + "/" + trap.Plugin.Type
+		}
+		return trap.Plugin.Name
+	default:
+		return "-"
+	}
+}
+
+// describeCondition summarizes a status condition, e.g., "True (1/1 decoys deployed (0 skipped))".
+func describeCondition(condition *v1alpha1.DeceptionPolicyCondition) string {
+	if condition == nil {
+		return "Unknown"
+	}
+	return fmt.Sprintf("%s (%s)", condition.Status, condition.Message)
+}
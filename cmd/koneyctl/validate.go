@@ -0,0 +1,150 @@
+// Copyright (c) 2025 Dynatrace LLC
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+
+	"github.com/dynatrace-oss/koney/api/v1alpha1"
+	"github.com/dynatrace-oss/koney/internal/controller/presets"
+)
+
+// runValidate validates DeceptionPolicy manifests without a cluster.
+func runValidate(_ context.Context, args []string) error {
+	fs := newFlagSet("validate", "-f FILE")
+	file := fs.String("f", "", "The manifest to validate, or '-' to read from stdin. Other kinds of objects are ignored.")
+	_ = fs.Parse(args)
+
+	if *file == "" {
+		fs.Usage()
+		return errors.New("a manifest must be specified with -f")
+	}
+
+	var in io.Reader = os.Stdin
+	if *file != "-" {
+		f, err := os.Open(*file)
+		if err != nil {
+			return err
+		}
+		defer f.Close() //nolint:errcheck
+		in = f
+	}
+
+	problems, err := validateManifests(in, os.Stdout)
+	if err != nil {
+		return err
+	} else if problems > 0 {
+		return fmt.Errorf("found %d problem(s)", problems)
+	}
+	return nil
+}
+
+// validateManifests validates every DeceptionPolicy in a (multi-document) YAML stream and reports the results to out.
+// It returns the number of problems that were found.
+func validateManifests(in io.Reader, out io.Writer) (int, error) {
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(in))
+	problems := 0
+	numPolicies := 0
+
+	for {
+		document, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return problems, err
+		}
+
+		var typeMeta struct {
+			APIVersion string `json:"apiVersion"`
+			Kind       string `json:"kind"`
+		}
+		if err := yaml.Unmarshal(document, &typeMeta); err != nil {
+			return problems, err
+		}
+		if typeMeta.Kind != "DeceptionPolicy" || typeMeta.APIVersion != v1alpha1.GroupVersion.String() {
+			continue
+		}
+
+		numPolicies++
+		for _, problem := range validatePolicy(document) {
+			fmt.Fprintln(out, problem)
+			problems++
+		}
+	}
+
+	if numPolicies == 0 {
+		return problems, errors.New("no DeceptionPolicy found")
+	} else if problems == 0 {
+		fmt.Fprintf(out, "%d DeceptionPolicy object(s) valid\n", numPolicies)
+	}
+	return problems, nil
+}
+
+// validatePolicy checks a single DeceptionPolicy manifest and returns its problems.
+// Unknown fields are reported as problems, since the API server would silently drop them.
+func validatePolicy(document []byte) []string {
+	policy := v1alpha1.DeceptionPolicy{}
+	if err := yaml.UnmarshalStrict(bytes.TrimSpace(document), &policy); err != nil {
+		return []string{fmt.Sprintf("DeceptionPolicy '%s': %v", policyNameOf(document), err)}
+	}
+
+	var problems []string
+	if policy.Name == "" {
+		problems = append(problems, "DeceptionPolicy without metadata.name")
+	}
+	if len(policy.Spec.Traps) == 0 {
+		problems = append(problems, fmt.Sprintf("DeceptionPolicy '%s': no traps", policy.Name))
+	}
+
+	for i, trap := range policy.Spec.Traps {
+		presets.ExpandTrap(&trap, policy.Name)
+		applyTrapDefaults(&trap)
+		if err := trap.IsValid(); err != nil {
+			problems = append(problems, fmt.Sprintf("DeceptionPolicy '%s': traps[%d]: %v", policy.Name, i, err))
+		}
+	}
+	return problems
+}
+
+// applyTrapDefaults sets the defaults that the API server would set, since some validations depend on them.
+func applyTrapDefaults(trap *v1alpha1.Trap) {
+	if trap.DecoyDeployment.Strategy == "" {
+		trap.DecoyDeployment.Strategy = "volumeMount"
+	}
+	if trap.CaptorDeployment.Strategy == "" {
+		trap.CaptorDeployment.Strategy = "tetragon"
+	}
+}
+
+// policyNameOf returns the name of a policy, even if the manifest cannot be decoded strictly.
+func policyNameOf(document []byte) string {
+	var meta struct {
+		Metadata struct {
+			Name string `json:"name"`
+		} `json:"metadata"`
+	}
+	_ = yaml.Unmarshal(document, &meta)
+	return meta.Metadata.Name
+}
@@ -0,0 +1,142 @@
+// Copyright (c) 2025 Dynatrace LLC
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/dynatrace-oss/koney/api/v1alpha1"
+	"github.com/dynatrace-oss/koney/internal/controller/constants"
+	trapsapi "github.com/dynatrace-oss/koney/internal/controller/traps/api"
+	"github.com/dynatrace-oss/koney/internal/controller/webhookauth"
+)
+
+// operatorAlertTimeout limits how long the operator waits for the alert forwarder to accept an alert.
+const operatorAlertTimeout = 5 * time.Second
+
+// operatorAlert is an alert that the operator raises itself (instead of a captor), e.g., if a decoy was tampered with.
+// The alert forwarder maps it to a regular Koney alert and sends it to the alert sinks.
+type operatorAlert struct {
+	DeceptionPolicyName string            `json:"deception_policy_name"`
+	Reason              string            `json:"reason"`
+	TrapType            string            `json:"trap_type"`
+	Metadata            map[string]string `json:"metadata"`
+	Pod                 string            `json:"pod,omitempty"`
+	Namespace           string            `json:"namespace,omitempty"`
+	Container           string            `json:"container,omitempty"`
+}
+
+// alertTrapType returns how the alert forwarder calls the trap type in alerts.
+func alertTrapType(trapType v1alpha1.TrapType) string {
+	switch trapType {
+	case v1alpha1.FilesystemHoneytokenTrap:
+		return "filesystem_honeytoken"
+	case v1alpha1.HttpEndpointTrap:
+		return "http_endpoint"
+	case v1alpha1.HttpPayloadTrap:
+		return "http_payload"
+	case v1alpha1.NetworkHoneypotTrap:
+		return "network_honeypot"
+	case v1alpha1.EnvVarHoneytokenTrap:
+		return "envvar_honeytoken"
+	case v1alpha1.PluginTrapType:
+		return "plugin"
+	default:
+		return "unknown"
+	}
+}
+
+// newTrapTamperedAlerts creates an alert for every container where the decoy of the trap was tampered with.
+func newTrapTamperedAlerts(deceptionPolicy *v1alpha1.DeceptionPolicy, trap v1alpha1.Trap, outcome trapsapi.ObjectOutcome) []operatorAlert {
+	metadata := map[string]string{}
+	if trap.TrapType() == v1alpha1.FilesystemHoneytokenTrap {
+		metadata["file_path"] = trap.FilesystemHoneytoken.FilePath
+	}
+
+	alerts := make([]operatorAlert, 0, len(outcome.Tampered))
+	for _, container := range outcome.Tampered {
+		alerts = append(alerts, operatorAlert{
+			DeceptionPolicyName: deceptionPolicy.Name,
+			Reason:              EventReason_TrapTampered,
+			TrapType:            alertTrapType(trap.TrapType()),
+			Metadata:            metadata,
+			Pod:                 outcome.Object.Name,
+			Namespace:           outcome.Object.Namespace,
+			Container:           container,
+		})
+	}
+	return alerts
+}
+
+// reportTamperedDecoys raises a TrapTampered alert for every container where a decoy was changed since it was deployed.
+// Alerts are emitted as warning events on the DeceptionPolicy and sent to the alert forwarder.
+func (r *DeceptionPolicyReconciler) reportTamperedDecoys(ctx context.Context, deceptionPolicy *v1alpha1.DeceptionPolicy, trap v1alpha1.Trap, outcomes []trapsapi.ObjectOutcome) {
+	log := log.FromContext(ctx)
+
+	for _, outcome := range outcomes {
+		for _, alert := range newTrapTamperedAlerts(deceptionPolicy, trap, outcome) {
+			if r.Recorder != nil {
+				r.Recorder.Eventf(deceptionPolicy, corev1.EventTypeWarning, EventReason_TrapTampered,
+					"Decoy in container %s of %s %s/%s was changed and has been redeployed", alert.Container, outcome.Object.Kind, outcome.Object.Namespace, outcome.Object.Name)
+			}
+
+			if err := r.sendOperatorAlert(ctx, deceptionPolicy, alert); err != nil {
+				log.Error(err, "unable to send alert to the alert forwarder", "reason", alert.Reason)
+			}
+		}
+	}
+}
+
+// sendOperatorAlert sends an alert to the alert forwarder, authenticated with a URL signed for the deception policy.
+func (r *DeceptionPolicyReconciler) sendOperatorAlert(ctx context.Context, deceptionPolicy *v1alpha1.DeceptionPolicy, alert operatorAlert) error {
+	webhookURL, err := webhookauth.GetSignedURL(r.Client, ctx, webhookauth.WebhookURL(r.currentSettings().AlertWebhookHost, constants.OperatorWebhookPath), deceptionPolicy.Name)
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, operatorAlertTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("alert forwarder responded with status %s", resp.Status)
+	}
+	return nil
+}
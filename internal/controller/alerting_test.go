@@ -0,0 +1,80 @@
+// Copyright (c) 2025 Dynatrace LLC
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/dynatrace-oss/koney/api/v1alpha1"
+	trapsapi "github.com/dynatrace-oss/koney/internal/controller/traps/api"
+)
+
+var _ = Describe("Decoy verification", func() {
+	var deceptionPolicy *v1alpha1.DeceptionPolicy
+
+	BeforeEach(func() {
+		deceptionPolicy = &v1alpha1.DeceptionPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-deception-policy", UID: "test-uid"},
+		}
+	})
+
+	It("should verify decoys at most once per sync interval", func() {
+		reconciler := &DeceptionPolicyReconciler{VerifyDecoys: true, SyncInterval: time.Hour}
+		Expect(reconciler.shouldVerifyDecoys(deceptionPolicy)).To(BeTrue())
+		Expect(reconciler.shouldVerifyDecoys(deceptionPolicy)).To(BeFalse())
+
+		// A shorter interval of the policy takes precedence
+		deceptionPolicy.Spec.SyncInterval = &metav1.Duration{Duration: time.Nanosecond}
+		time.Sleep(time.Millisecond)
+		Expect(reconciler.shouldVerifyDecoys(deceptionPolicy)).To(BeTrue())
+	})
+
+	It("should not verify decoys if verification or syncing is disabled", func() {
+		reconciler := &DeceptionPolicyReconciler{VerifyDecoys: false, SyncInterval: time.Hour}
+		Expect(reconciler.shouldVerifyDecoys(deceptionPolicy)).To(BeFalse())
+
+		reconciler = &DeceptionPolicyReconciler{VerifyDecoys: true}
+		Expect(reconciler.shouldVerifyDecoys(deceptionPolicy)).To(BeFalse())
+	})
+
+	It("should create an alert for every tampered container", func() {
+		trap := v1alpha1.Trap{FilesystemHoneytoken: v1alpha1.FilesystemHoneytoken{FilePath: "/run/secrets/token"}}
+		outcome := trapsapi.ObjectOutcome{
+			Object:     corev1.ObjectReference{Kind: "Pod", Namespace: "default", Name: "test-pod"},
+			Containers: []string{"app", "sidecar"},
+			Tampered:   []string{"sidecar"},
+		}
+
+		alerts := newTrapTamperedAlerts(deceptionPolicy, trap, outcome)
+		Expect(alerts).To(ConsistOf(operatorAlert{
+			DeceptionPolicyName: "test-deception-policy",
+			Reason:              EventReason_TrapTampered,
+			TrapType:            "filesystem_honeytoken",
+			Metadata:            map[string]string{"file_path": "/run/secrets/token"},
+			Pod:                 "test-pod",
+			Namespace:           "default",
+			Container:           "sidecar",
+		}))
+
+		outcome.Tampered = nil
+		Expect(newTrapTamperedAlerts(deceptionPolicy, trap, outcome)).To(BeEmpty())
+	})
+})
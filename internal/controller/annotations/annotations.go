@@ -24,6 +24,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	"github.com/dynatrace-oss/koney/api/v1alpha1"
 	"github.com/dynatrace-oss/koney/internal/controller/constants"
@@ -248,6 +249,11 @@ func AreTheSameTrap(annotationTrap v1alpha1.TrapAnnotation, trap v1alpha1.Trap)
 		return false
 	}
 
+	// Sidecar captors are part of the decoy, so switching to or from them requires to redeploy the decoy
+	if annotationTrap.HasSidecarCaptor() != (trap.CaptorDeployment.Strategy == "sidecar") {
+		return false
+	}
+
 	// Then, check if the trap type is the same
 	if annotationTrap.TrapType() != trap.TrapType() {
 		return false
@@ -259,12 +265,19 @@ func AreTheSameTrap(annotationTrap v1alpha1.TrapAnnotation, trap v1alpha1.Trap)
 		if annotationTrap.FilesystemHoneytoken.FilePath != trap.FilesystemHoneytoken.FilePath {
 			return false
 		}
-		if annotationTrap.FilesystemHoneytoken.FileContentHash != utils.Hash(trap.FilesystemHoneytoken.FileContent) {
+		if !utils.HashMatches(annotationTrap.FilesystemHoneytoken.FileContentHash, trap.FilesystemHoneytoken.FileContent) {
 			return false
 		}
 		if annotationTrap.FilesystemHoneytoken.ReadOnly != trap.FilesystemHoneytoken.ReadOnly {
 			return false
 		}
+	case v1alpha1.EnvVarHoneytokenTrap:
+		if annotationTrap.EnvVarHoneytoken.Name != trap.EnvVarHoneytoken.Name {
+			return false
+		}
+		if !utils.HashMatches(annotationTrap.EnvVarHoneytoken.ValueHash, trap.EnvVarHoneytoken.Value) {
+			return false
+		}
 	case v1alpha1.HttpEndpointTrap:
 		// TODO: Implement.
 		return false
@@ -278,17 +291,25 @@ func AreTheSameTrap(annotationTrap v1alpha1.TrapAnnotation, trap v1alpha1.Trap)
 	return true
 }
 
-// GetAnnotatedResources returns a list of resources that have been annotated with a specific DeceptionPolicy
+// GetAnnotatedResources returns a list of resources that have been annotated with a specific DeceptionPolicy.
+// Changes that were spilled into companion ConfigMaps are loaded into the annotations of the returned resources.
+// Only resources with the label LabelKeyManaged are considered, all other resources were never modified by Koney.
 func GetAnnotatedResources(r client.Reader, ctx context.Context, crdName string) ([]client.Object, error) {
 	var annotatedResources []client.Object
 
-	// Get all pods
+	managedSelector := client.MatchingLabels{constants.LabelKeyManaged: "true"}
+
+	// Get all managed pods
 	pods := &corev1.PodList{}
-	if err := r.List(ctx, pods); err != nil {
+	if err := r.List(ctx, pods, managedSelector); err != nil {
 		return nil, err
 	}
 
 	for _, pod := range pods.Items {
+		if err := LoadSpilledChanges(r, ctx, &pod); err != nil {
+			return nil, err
+		}
+
 		annotationChange, err := GetAnnotationChange(&pod, crdName)
 		if err != nil {
 			return nil, err
@@ -299,13 +320,17 @@ func GetAnnotatedResources(r client.Reader, ctx context.Context, crdName string)
 		}
 	}
 
-	// Get all deployments
+	// Get all managed deployments
 	deployments := &appsv1.DeploymentList{}
-	if err := r.List(ctx, deployments); err != nil {
+	if err := r.List(ctx, deployments, managedSelector); err != nil {
 		return nil, err
 	}
 
 	for _, deployment := range deployments.Items {
+		if err := LoadSpilledChanges(r, ctx, &deployment); err != nil {
+			return nil, err
+		}
+
 		annotationChange, err := GetAnnotationChange(&deployment, crdName)
 		if err != nil {
 			return nil, err
@@ -319,11 +344,63 @@ func GetAnnotatedResources(r client.Reader, ctx context.Context, crdName string)
 	return annotatedResources, nil
 }
 
+// LabelManagedResources places the label LabelKeyManaged on all pods and deployments that have changes but no label yet,
+// e.g., because they were modified by an older version of Koney. Without the label, GetAnnotatedResources would miss them.
+func LabelManagedResources(c client.Client, ctx context.Context) error {
+	log := log.FromContext(ctx)
+
+	pods := &corev1.PodList{}
+	if err := c.List(ctx, pods); err != nil {
+		return err
+	}
+	deployments := &appsv1.DeploymentList{}
+	if err := c.List(ctx, deployments); err != nil {
+		return err
+	}
+
+	var resources []client.Object
+	for i := range pods.Items {
+		resources = append(resources, &pods.Items[i])
+	}
+	for i := range deployments.Items {
+		resources = append(resources, &deployments.Items[i])
+	}
+
+	numLabeled := 0
+	for _, resource := range resources {
+		if _, ok := resource.GetLabels()[constants.LabelKeyManaged]; ok {
+			continue
+		}
+
+		_, hasChanges := resource.GetAnnotations()[constants.AnnotationKeyChanges]
+		_, hasChangesRef := resource.GetAnnotations()[constants.AnnotationKeyChangesRef]
+		if !hasChanges && !hasChangesRef {
+			continue
+		}
+
+		err := utils.PatchResource(c, ctx, resource, func() error {
+			setManagedLabel(resource)
+			return nil
+		})
+		if client.IgnoreNotFound(err) != nil {
+			return err
+		}
+		numLabeled++
+	}
+
+	if numLabeled > 0 {
+		log.Info("Labeled resources that were modified by an older version of Koney", "numLabeled", numLabeled)
+	}
+
+	return nil
+}
+
 func convertTrapToTrapAnnotation(trap v1alpha1.Trap, containers []string) (v1alpha1.TrapAnnotation, error) {
 	annotationTrap := v1alpha1.TrapAnnotation{
-		DeploymentStrategy: trap.DecoyDeployment.Strategy,
-		Containers:         containers,
-		CreatedAt:          time.Now().Format(time.RFC3339),
+		DeploymentStrategy:       trap.DecoyDeployment.Strategy,
+		CaptorDeploymentStrategy: trap.CaptorDeployment.Strategy,
+		Containers:               containers,
+		CreatedAt:                time.Now().Format(time.RFC3339),
 	}
 
 	switch trap.TrapType() {
@@ -333,6 +410,11 @@ func convertTrapToTrapAnnotation(trap v1alpha1.Trap, containers []string) (v1alp
 			FileContentHash: utils.Hash(trap.FilesystemHoneytoken.FileContent),
 			ReadOnly:        trap.FilesystemHoneytoken.ReadOnly,
 		}
+	case v1alpha1.EnvVarHoneytokenTrap:
+		annotationTrap.EnvVarHoneytoken = v1alpha1.EnvVarHoneytokenAnnotation{
+			Name:      trap.EnvVarHoneytoken.Name,
+			ValueHash: utils.Hash(trap.EnvVarHoneytoken.Value),
+		}
 	case v1alpha1.HttpEndpointTrap:
 		annotationTrap.HttpEndpoint = v1alpha1.HttpEndpointAnnotation{}
 	case v1alpha1.HttpPayloadTrap:
@@ -16,6 +16,7 @@
 package annotations
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 
@@ -23,6 +24,10 @@ import (
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	"github.com/dynatrace-oss/koney/api/v1alpha1"
 	"github.com/dynatrace-oss/koney/internal/controller/constants"
@@ -53,7 +58,7 @@ var (
 		"filesystemHoneytoken",
 	}
 
-	changingFields = []string{"deploymentStrategy", "filePath", "fileContentHash", "readOnly"}
+	changingFields = []string{"deploymentStrategy", "captorDeploymentStrategy", "filePath", "fileContentHash", "readOnly"}
 
 	annotationTraps []v1alpha1.Trap
 )
@@ -175,6 +180,8 @@ var _ = Describe("AreTheSameTrap", func() {
 								}
 							}
 							annotationTrap.DeploymentStrategy = differentDeploymentStrategy
+						case "captorDeploymentStrategy":
+							annotationTrap.CaptorDeploymentStrategy = "sidecar"
 						case "filePath":
 							annotationTrap.FilesystemHoneytoken.FilePath = fmt.Sprintf("%s/different", trap.FilesystemHoneytoken.FilePath)
 						case "fileContentHash":
@@ -523,3 +530,62 @@ var _ = Describe("GetAnnotationChange", func() {
 		})
 	})
 })
+
+var _ = Describe("GetAnnotatedResources", func() {
+	ctx := context.Background()
+
+	var fakeClient client.Client
+
+	// newAnnotatedPod creates a pod with a single trap in its annotations
+	newAnnotatedPod := func(name string) *corev1.Pod {
+		pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace}}
+		Expect(AddTrapToAnnotations(pod, testCrdName, annotationTraps[0], []string{"container1"})).To(Succeed())
+		return pod
+	}
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+
+		managedPod := newAnnotatedPod("managed-pod")
+		Expect(SpillChanges(nil, ctx, managedPod, 0)).To(Succeed())
+		Expect(managedPod.Labels).To(HaveKeyWithValue(constants.LabelKeyManaged, "true"))
+
+		// Pods annotated by older versions of Koney have no label
+		legacyPod := newAnnotatedPod("legacy-pod")
+		otherPod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "other-pod", Namespace: testNamespace}}
+
+		fakeClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(managedPod, legacyPod, otherPod).Build()
+	})
+
+	It("should only return labeled resources", func() {
+		resources, err := GetAnnotatedResources(fakeClient, ctx, testCrdName)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resources).To(HaveLen(1))
+		Expect(resources[0].GetName()).To(Equal("managed-pod"))
+	})
+
+	It("should return legacy resources after labeling them", func() {
+		Expect(LabelManagedResources(fakeClient, ctx)).To(Succeed())
+
+		resources, err := GetAnnotatedResources(fakeClient, ctx, testCrdName)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resources).To(HaveLen(2))
+
+		otherPod := &corev1.Pod{}
+		Expect(fakeClient.Get(ctx, client.ObjectKey{Namespace: testNamespace, Name: "other-pod"}, otherPod)).To(Succeed())
+		Expect(otherPod.Labels).ToNot(HaveKey(constants.LabelKeyManaged))
+	})
+
+	It("should remove the label together with the last trap", func() {
+		resources, err := GetAnnotatedResources(fakeClient, ctx, testCrdName)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resources).To(HaveLen(1))
+
+		change, err := GetAnnotationChange(resources[0], testCrdName)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(RemoveTrapAnnotations(resources[0], testCrdName, change.Traps[0])).To(Succeed())
+		Expect(SpillChanges(fakeClient, ctx, resources[0], 0)).To(Succeed())
+		Expect(resources[0].GetLabels()).ToNot(HaveKey(constants.LabelKeyManaged))
+	})
+})
@@ -0,0 +1,349 @@
+// Copyright (c) 2025 Dynatrace LLC
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package annotations
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/dynatrace-oss/koney/api/v1alpha1"
+	"github.com/dynatrace-oss/koney/internal/controller/constants"
+	"github.com/dynatrace-oss/koney/internal/controller/utils"
+)
+
+// configMapKeyChanges is the key in the companion ConfigMap that holds the spilled changes.
+const configMapKeyChanges = "changes"
+
+// LoadSpilledChanges merges the changes that were spilled into the companion ConfigMap of a resource
+// back into the resource's changes annotation. The resource is only modified in memory, so that
+// all other functions of this package can work with the complete list of changes.
+// Call SpillChanges before updating the resource in the Kubernetes API server.
+// A companion ConfigMap that does not exist (anymore) is treated as if no changes were spilled.
+func LoadSpilledChanges(r client.Reader, ctx context.Context, resource client.Object) error {
+	configMapName, ok := resource.GetAnnotations()[constants.AnnotationKeyChangesRef]
+	if !ok {
+		return nil // Nothing was spilled
+	}
+
+	configMap := corev1.ConfigMap{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: resource.GetNamespace(), Name: configMapName}, &configMap); err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			// SpillChanges deletes the ConfigMap before the resource is patched, so if that patch failed,
+			// the annotation still points to it. Nothing is spilled anymore, the reference is dropped with the next patch.
+			log.FromContext(ctx).Info("Companion ConfigMap of changes annotation not found - assuming no spilled changes",
+				"resource", resource.GetName(), "configMap", configMapName)
+			delete(resource.GetAnnotations(), constants.AnnotationKeyChangesRef)
+			return nil
+		}
+		return err
+	}
+
+	var spilledChanges []v1alpha1.ChangeAnnotation
+	if err := json.Unmarshal([]byte(configMap.Data[configMapKeyChanges]), &spilledChanges); err != nil {
+		return err
+	}
+
+	inlineChanges, err := GetChanges(resource)
+	if err != nil {
+		return err
+	}
+
+	return setChanges(resource, mergeChanges(inlineChanges, spilledChanges))
+}
+
+// SpillChanges makes sure that the changes annotation of a resource does not exceed maxSize bytes.
+// If the annotation is too large, the oldest traps are moved into a companion ConfigMap
+// in the same namespace, and the annotation AnnotationKeyChangesRef points to that ConfigMap.
+// If the annotation is small enough again, the companion ConfigMap is deleted.
+// The label LabelKeyManaged is placed on the resource as long as it has any changes, and removed otherwise.
+// The resource itself is not updated in the Kubernetes API server, the caller is responsible for updating the resource.
+// A maxSize of zero or less disables spilling.
+func SpillChanges(c client.Client, ctx context.Context, resource client.Object, maxSize int) error {
+	log := log.FromContext(ctx)
+
+	changes, err := GetChanges(resource)
+	if err != nil {
+		return err
+	}
+
+	inlineChanges, spilledChanges, err := splitChanges(changes, maxSize)
+	if err != nil {
+		return err
+	}
+
+	configMapName, hasConfigMap := resource.GetAnnotations()[constants.AnnotationKeyChangesRef]
+	if len(spilledChanges) == 0 {
+		if hasConfigMap {
+			// Everything fits into the annotation again, so the companion ConfigMap is no longer needed
+			log.Info("Deleting companion ConfigMap of changes annotation", "resource", resource.GetName(), "configMap", configMapName)
+			configMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: resource.GetNamespace(), Name: configMapName}}
+			if err := c.Delete(ctx, configMap); client.IgnoreNotFound(err) != nil {
+				return err
+			}
+			delete(resource.GetAnnotations(), constants.AnnotationKeyChangesRef)
+		}
+
+		if err := setChanges(resource, inlineChanges); err != nil {
+			return err
+		}
+		setManagedLabel(resource)
+		return nil
+	}
+
+	spilledJSON, err := json.Marshal(spilledChanges)
+	if err != nil {
+		return err
+	}
+
+	if !hasConfigMap {
+		// Keep using an existing companion ConfigMap, even if it was named differently by an older version of Koney
+		configMapName = generateConfigMapName(resource)
+	}
+	log.Info("Changes annotation too large - spilling older traps into companion ConfigMap", "resource", resource.GetName(), "configMap", configMapName, "numSpilledChanges", len(spilledChanges))
+
+	configMap := corev1.ConfigMap{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: resource.GetNamespace(), Name: configMapName}, &configMap); err != nil {
+		if client.IgnoreNotFound(err) != nil {
+			return err
+		}
+
+		configMap = corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      configMapName,
+				Namespace: resource.GetNamespace(),
+				Labels: map[string]string{
+					constants.LabelKeyChangesOf: resource.GetName(),
+				},
+				// The ConfigMap is garbage collected together with the resource
+				OwnerReferences: generateOwnerReferences(resource),
+			},
+			Data: map[string]string{configMapKeyChanges: string(spilledJSON)},
+		}
+		if err := c.Create(ctx, &configMap); err != nil {
+			return err
+		}
+	} else if configMap.Data[configMapKeyChanges] != string(spilledJSON) {
+		err := utils.PatchResource(c, ctx, &configMap, func() error {
+			configMap.Data = map[string]string{configMapKeyChanges: string(spilledJSON)}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := setChanges(resource, inlineChanges); err != nil {
+		return err
+	}
+	resource.GetAnnotations()[constants.AnnotationKeyChangesRef] = configMapName
+	setManagedLabel(resource)
+
+	return nil
+}
+
+// splitChanges splits a list of changes into the changes that fit into an annotation of maxSize bytes,
+// and the changes that need to be spilled. The oldest traps (by their last update) are spilled first.
+func splitChanges(changes []v1alpha1.ChangeAnnotation, maxSize int) ([]v1alpha1.ChangeAnnotation, []v1alpha1.ChangeAnnotation, error) {
+	type record struct {
+		deceptionPolicyName string
+		trap                v1alpha1.TrapAnnotation
+	}
+
+	fits := func(changes []v1alpha1.ChangeAnnotation) (bool, error) {
+		changesJSON, err := json.Marshal(changes)
+		return len(changesJSON) <= maxSize, err
+	}
+
+	if ok, err := fits(changes); maxSize <= 0 || ok || err != nil {
+		return changes, nil, err
+	}
+
+	// Flatten the changes into individual records, sorted from the oldest to the newest
+	records := []record{}
+	for _, change := range changes {
+		for _, trap := range change.Traps {
+			records = append(records, record{deceptionPolicyName: change.DeceptionPolicyName, trap: trap})
+		}
+	}
+	sort.SliceStable(records, func(i, j int) bool {
+		return lastModified(records[i].trap).Before(lastModified(records[j].trap))
+	})
+
+	// Move records from the annotation into the spill-over list, until the annotation fits
+	toChanges := func(records []record) []v1alpha1.ChangeAnnotation {
+		result := []v1alpha1.ChangeAnnotation{}
+		for _, record := range records {
+			result = mergeChanges(result, []v1alpha1.ChangeAnnotation{
+				{DeceptionPolicyName: record.deceptionPolicyName, Traps: []v1alpha1.TrapAnnotation{record.trap}},
+			})
+		}
+		return result
+	}
+
+	for numSpilled := 1; numSpilled <= len(records); numSpilled++ {
+		inlineChanges := toChanges(records[numSpilled:])
+		ok, err := fits(inlineChanges)
+		if err != nil {
+			return nil, nil, err
+		} else if ok {
+			return inlineChanges, toChanges(records[:numSpilled]), nil
+		}
+	}
+
+	return []v1alpha1.ChangeAnnotation{}, toChanges(records), nil
+}
+
+// mergeChanges merges two lists of changes, grouped by the DeceptionPolicy name.
+// Traps that are contained in both lists are only added once.
+func mergeChanges(changes []v1alpha1.ChangeAnnotation, otherChanges []v1alpha1.ChangeAnnotation) []v1alpha1.ChangeAnnotation {
+	// Copy the traps to avoid modifying the slices of the given changes
+	merged := make([]v1alpha1.ChangeAnnotation, 0, len(changes))
+	for _, change := range changes {
+		merged = append(merged, v1alpha1.ChangeAnnotation{
+			DeceptionPolicyName: change.DeceptionPolicyName,
+			Traps:               append([]v1alpha1.TrapAnnotation{}, change.Traps...),
+		})
+	}
+
+	for _, otherChange := range otherChanges {
+		index := -1
+		for i := range merged {
+			if merged[i].DeceptionPolicyName == otherChange.DeceptionPolicyName {
+				index = i
+				break
+			}
+		}
+
+		if index < 0 {
+			merged = append(merged, v1alpha1.ChangeAnnotation{DeceptionPolicyName: otherChange.DeceptionPolicyName})
+			index = len(merged) - 1
+		}
+
+		for _, otherTrap := range otherChange.Traps {
+			exists := false
+			for _, trap := range merged[index].Traps {
+				if trap.Equals(&otherTrap, false) {
+					exists = true
+					break
+				}
+			}
+			if !exists {
+				merged[index].Traps = append(merged[index].Traps, otherTrap)
+			}
+		}
+	}
+
+	return merged
+}
+
+// lastModified returns the time when a trap was last updated, or created if it was never updated.
+func lastModified(trap v1alpha1.TrapAnnotation) time.Time {
+	timestamp := trap.UpdatedAt
+	if timestamp == "" {
+		timestamp = trap.CreatedAt
+	}
+
+	parsed, err := time.Parse(time.RFC3339, timestamp)
+	if err != nil {
+		return time.Time{}
+	}
+	return parsed
+}
+
+// GetChanges returns all changes from the changes annotation of a resource.
+func GetChanges(resource client.Object) ([]v1alpha1.ChangeAnnotation, error) {
+	var changes []v1alpha1.ChangeAnnotation
+	if existingChanges, ok := resource.GetAnnotations()[constants.AnnotationKeyChanges]; ok {
+		if err := json.Unmarshal([]byte(existingChanges), &changes); err != nil {
+			return nil, err
+		}
+	}
+	return changes, nil
+}
+
+// setChanges writes all changes into the changes annotation of a resource.
+// If there are no changes, the annotation is removed.
+func setChanges(resource client.Object, changes []v1alpha1.ChangeAnnotation) error {
+	if len(changes) == 0 {
+		delete(resource.GetAnnotations(), constants.AnnotationKeyChanges)
+		return nil
+	}
+
+	changesJSON, err := json.Marshal(changes)
+	if err != nil {
+		return err
+	}
+
+	if resource.GetAnnotations() == nil {
+		resource.SetAnnotations(make(map[string]string))
+	}
+	resource.GetAnnotations()[constants.AnnotationKeyChanges] = string(changesJSON)
+
+	return nil
+}
+
+// setManagedLabel places the label LabelKeyManaged on a resource if it has inline or spilled changes,
+// and removes the label if it has no changes anymore.
+func setManagedLabel(resource client.Object) {
+	_, hasChanges := resource.GetAnnotations()[constants.AnnotationKeyChanges]
+	_, hasChangesRef := resource.GetAnnotations()[constants.AnnotationKeyChangesRef]
+	if !hasChanges && !hasChangesRef {
+		delete(resource.GetLabels(), constants.LabelKeyManaged)
+		return
+	}
+
+	if resource.GetLabels() == nil {
+		resource.SetLabels(make(map[string]string))
+	}
+	resource.GetLabels()[constants.LabelKeyManaged] = "true"
+}
+
+// generateConfigMapName generates the name of the companion ConfigMap of a resource.
+func generateConfigMapName(resource client.Object) string {
+	return "koney-changes-" + utils.ShortHash(fmt.Sprintf("%T/%s", resource, resource.GetName()))
+}
+
+// generateOwnerReferences makes the resource the owner of its companion ConfigMap.
+// Typed objects returned by the client have no TypeMeta set, so the kind is derived from the type.
+func generateOwnerReferences(resource client.Object) []metav1.OwnerReference {
+	var apiVersion, kind string
+	switch resource.(type) {
+	case *corev1.Pod:
+		apiVersion, kind = "v1", "Pod"
+	case *appsv1.Deployment:
+		apiVersion, kind = "apps/v1", "Deployment"
+	default:
+		return nil
+	}
+
+	return []metav1.OwnerReference{
+		{
+			APIVersion: apiVersion,
+			Kind:       kind,
+			Name:       resource.GetName(),
+			UID:        resource.GetUID(),
+		},
+	}
+}
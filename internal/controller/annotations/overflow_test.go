@@ -0,0 +1,153 @@
+// Copyright (c) 2025 Dynatrace LLC
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package annotations
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/dynatrace-oss/koney/api/v1alpha1"
+	"github.com/dynatrace-oss/koney/internal/controller/constants"
+)
+
+// generateTestChanges creates a change annotation with numTraps filesystem honeytoken traps,
+// where the trap with the lowest index is the oldest one.
+func generateTestChanges(numTraps int) []v1alpha1.ChangeAnnotation {
+	change := v1alpha1.ChangeAnnotation{DeceptionPolicyName: testCrdName}
+	for i := 0; i < numTraps; i++ {
+		change.Traps = append(change.Traps, v1alpha1.TrapAnnotation{
+			DeploymentStrategy: "containerExec",
+			Containers:         []string{"container1"},
+			CreatedAt:          fmt.Sprintf("2025-01-01T00:00:%02dZ", i),
+			FilesystemHoneytoken: v1alpha1.FilesystemHoneytokenAnnotation{
+				FilePath:        fmt.Sprintf("/run/secrets/koney/service_token_%d", i),
+				FileContentHash: testFileHash,
+				ReadOnly:        true,
+			},
+		})
+	}
+	return []v1alpha1.ChangeAnnotation{change}
+}
+
+var _ = Describe("splitChanges", func() {
+	Context("when the changes fit into the annotation", func() {
+		It("should not spill any changes", func() {
+			changes := generateTestChanges(3)
+
+			inlineChanges, spilledChanges, err := splitChanges(changes, 64*1024)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(inlineChanges).To(Equal(changes))
+			Expect(spilledChanges).To(BeEmpty())
+		})
+	})
+
+	Context("when the maximum size is disabled", func() {
+		It("should not spill any changes", func() {
+			changes := generateTestChanges(3)
+
+			inlineChanges, spilledChanges, err := splitChanges(changes, 0)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(inlineChanges).To(Equal(changes))
+			Expect(spilledChanges).To(BeEmpty())
+		})
+	})
+
+	Context("when the changes do not fit into the annotation", func() {
+		It("should spill the oldest traps first", func() {
+			changes := generateTestChanges(10)
+
+			// Allow roughly half of the traps to remain in the annotation
+			changesJSON, err := json.Marshal(changes)
+			Expect(err).ToNot(HaveOccurred())
+			maxSize := len(changesJSON) / 2
+
+			inlineChanges, spilledChanges, err := splitChanges(changes, maxSize)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(inlineChanges).To(HaveLen(1))
+			Expect(spilledChanges).To(HaveLen(1))
+
+			inlineJSON, err := json.Marshal(inlineChanges)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(len(inlineJSON)).To(BeNumerically("<=", maxSize))
+
+			// No trap must be lost during the split
+			numInline := len(inlineChanges[0].Traps)
+			numSpilled := len(spilledChanges[0].Traps)
+			Expect(numInline + numSpilled).To(Equal(10))
+
+			// The spilled traps are the oldest ones
+			for i, trap := range spilledChanges[0].Traps {
+				Expect(trap.Equals(&changes[0].Traps[i], false)).To(BeTrue())
+			}
+			for i, trap := range inlineChanges[0].Traps {
+				Expect(trap.Equals(&changes[0].Traps[numSpilled+i], false)).To(BeTrue())
+			}
+		})
+	})
+})
+
+var _ = Describe("mergeChanges", func() {
+	Context("when merging changes that partially overlap", func() {
+		It("should contain every trap exactly once", func() {
+			changes := generateTestChanges(4)
+			first := []v1alpha1.ChangeAnnotation{{DeceptionPolicyName: testCrdName, Traps: changes[0].Traps[:3]}}
+			second := []v1alpha1.ChangeAnnotation{
+				{DeceptionPolicyName: testCrdName, Traps: changes[0].Traps[1:]},
+				{DeceptionPolicyName: "other-crd", Traps: changes[0].Traps[:1]},
+			}
+
+			merged := mergeChanges(first, second)
+			Expect(merged).To(HaveLen(2))
+			Expect(merged[0].DeceptionPolicyName).To(Equal(testCrdName))
+			Expect(merged[0].Traps).To(HaveLen(4))
+			Expect(merged[1].DeceptionPolicyName).To(Equal("other-crd"))
+			Expect(merged[1].Traps).To(HaveLen(1))
+		})
+	})
+})
+
+var _ = Describe("LoadSpilledChanges", func() {
+	Context("when the companion ConfigMap does not exist anymore", func() {
+		It("should keep the inline changes and drop the reference", func() {
+			changes := generateTestChanges(2)
+			changesJSON, err := json.Marshal(changes)
+			Expect(err).ToNot(HaveOccurred())
+
+			pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-pod",
+				Namespace: "default",
+				Annotations: map[string]string{
+					constants.AnnotationKeyChanges:    string(changesJSON),
+					constants.AnnotationKeyChangesRef: "koney-changes-deleted",
+				},
+			}}
+
+			Expect(LoadSpilledChanges(fake.NewClientBuilder().Build(), context.Background(), pod)).To(Succeed())
+			Expect(pod.Annotations).NotTo(HaveKey(constants.AnnotationKeyChangesRef))
+
+			loadedChanges, err := GetChanges(pod)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(loadedChanges).To(Equal(changes))
+		})
+	})
+})
@@ -0,0 +1,277 @@
+// Copyright (c) 2025 Dynatrace LLC
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package audit records every mutation that Koney performs in the cluster, i.e., every resource that it creates, updates,
+// patches, or deletes, and every command that it executes in containers. Compliance teams can review these records
+// in the dedicated "audit" log stream of the manager and, optionally, in a ConfigMap that keeps the most recent ones.
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/dynatrace-oss/koney/api/v1alpha1"
+	"github.com/dynatrace-oss/koney/internal/controller/constants"
+	"github.com/dynatrace-oss/koney/internal/controller/utils"
+)
+
+// Action is the kind of mutation that Koney performed.
+type Action string
+
+const (
+	ActionCreate Action = "create"
+	ActionUpdate Action = "update"
+	ActionPatch  Action = "patch"
+	ActionDelete Action = "delete"
+	ActionExec   Action = "exec"
+)
+
+const (
+	OutcomeSuccess = "success"
+	OutcomeFailure = "failure"
+)
+
+// Target is the object (and container, for exec commands) that a mutation was performed on.
+type Target struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	Container string `json:"container,omitempty"`
+}
+
+// Entry is a single record of the audit log.
+type Entry struct {
+	Time   time.Time `json:"time"`
+	Action Action    `json:"action"`
+	Target Target    `json:"target"`
+	// DeceptionPolicy is the name of the deception policy on whose behalf the mutation was performed, if known.
+	DeceptionPolicy string `json:"deceptionPolicy,omitempty"`
+	// TrapHash identifies the trap on whose behalf the mutation was performed, if known (see TrapHash).
+	TrapHash string `json:"trapHash,omitempty"`
+	// Command is the executable of exec commands. The full command line may contain the content of decoys,
+	// so only its hash is recorded in CommandHash.
+	Command     string `json:"command,omitempty"`
+	CommandHash string `json:"commandHash,omitempty"`
+	Outcome     string `json:"outcome"`
+	Error       string `json:"error,omitempty"`
+}
+
+// Log writes audit entries to the "audit" log stream and keeps the most recent ones for the ConfigMap ring buffer.
+// A nil Log discards all entries.
+type Log struct {
+	logger logr.Logger
+
+	// configMapClient writes the ring buffer ConfigMap. It must not be audited itself.
+	configMapClient client.Client
+	// maxEntries is the number of entries that the ring buffer ConfigMap keeps. Zero disables the ConfigMap.
+	maxEntries int
+
+	mutex   sync.Mutex
+	pending []Entry
+}
+
+// NewLog creates an audit log that writes to the "audit" log stream of the manager.
+// If maxEntries is positive, the most recent entries are also kept in the ConfigMap constants.AuditConfigMapName,
+// which is written with the (unaudited) client c on every Flush.
+func NewLog(c client.Client, maxEntries int) *Log {
+	return &Log{
+		logger:          ctrl.Log.WithName("audit"),
+		configMapClient: c,
+		maxEntries:      maxEntries,
+	}
+}
+
+// Record writes an entry to the log stream. The deception policy and trap are taken from the context (see WithPolicy and WithTrap).
+func (l *Log) Record(ctx context.Context, action Action, target Target, err error) {
+	l.record(ctx, Entry{Action: action, Target: target}, err)
+}
+
+// RecordExec writes an entry for a command that was executed in a container of a pod.
+func (l *Log) RecordExec(ctx context.Context, pod corev1.Pod, containerName string, cmd []string, err error) {
+	entry := Entry{
+		Action:      ActionExec,
+		Target:      Target{Kind: "Pod", Namespace: pod.Namespace, Name: pod.Name, Container: containerName},
+		CommandHash: utils.Hash(strings.Join(cmd, " ")),
+	}
+	if len(cmd) > 0 {
+		entry.Command = cmd[0]
+	}
+	l.record(ctx, entry, err)
+}
+
+func (l *Log) record(ctx context.Context, entry Entry, err error) {
+	if l == nil {
+		return
+	}
+
+	entry.Time = time.Now().UTC()
+	entry.DeceptionPolicy, _ = ctx.Value(policyKey{}).(string)
+	entry.TrapHash, _ = ctx.Value(trapKey{}).(string)
+	entry.Outcome = OutcomeSuccess
+	if err != nil {
+		entry.Outcome = OutcomeFailure
+		entry.Error = err.Error()
+	}
+
+	l.logger.Info("Koney mutated the cluster", "action", entry.Action, "kind", entry.Target.Kind,
+		"namespace", entry.Target.Namespace, "name", entry.Target.Name, "container", entry.Target.Container,
+		"deceptionPolicy", entry.DeceptionPolicy, "trapHash", entry.TrapHash,
+		"command", entry.Command, "commandHash", entry.CommandHash, "outcome", entry.Outcome, "error", entry.Error)
+
+	if l.maxEntries > 0 {
+		l.mutex.Lock()
+		defer l.mutex.Unlock()
+
+		l.pending = append(l.pending, entry)
+		if len(l.pending) > l.maxEntries {
+			l.pending = l.pending[len(l.pending)-l.maxEntries:]
+		}
+	}
+}
+
+// Flush appends the entries that were recorded since the last flush to the ring buffer ConfigMap,
+// dropping the oldest entries if there are more than maxEntries. It does nothing if the ConfigMap is disabled.
+func (l *Log) Flush(ctx context.Context) error {
+	if l == nil || l.maxEntries <= 0 {
+		return nil
+	}
+
+	l.mutex.Lock()
+	pending := l.pending
+	l.pending = nil
+	l.mutex.Unlock()
+
+	if len(pending) == 0 {
+		return nil
+	}
+
+	err := l.flush(ctx, pending)
+	if err != nil {
+		// Keep the entries for the next flush, before the ones that were recorded in the meantime
+		l.mutex.Lock()
+		l.pending = append(pending, l.pending...)
+		if len(l.pending) > l.maxEntries {
+			l.pending = l.pending[len(l.pending)-l.maxEntries:]
+		}
+		l.mutex.Unlock()
+	}
+	return err
+}
+
+func (l *Log) flush(ctx context.Context, pending []Entry) error {
+	configMap := corev1.ConfigMap{}
+	key := client.ObjectKey{Namespace: constants.KoneyNamespace, Name: constants.AuditConfigMapName}
+	err := l.configMapClient.Get(ctx, key, &configMap)
+	if client.IgnoreNotFound(err) != nil {
+		return err
+	}
+
+	exists := err == nil
+	if !exists {
+		configMap = corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+		}
+	}
+
+	return l.writeEntries(ctx, &configMap, pending, exists)
+}
+
+func (l *Log) writeEntries(ctx context.Context, configMap *corev1.ConfigMap, pending []Entry, exists bool) error {
+	mutate := func() error {
+		entries := ParseEntries(configMap.Data[constants.AuditConfigMapKey])
+		entries = append(entries, pending...)
+		if len(entries) > l.maxEntries {
+			entries = entries[len(entries)-l.maxEntries:]
+		}
+
+		data, err := FormatEntries(entries)
+		if err != nil {
+			return err
+		}
+		if configMap.Data == nil {
+			configMap.Data = map[string]string{}
+		}
+		configMap.Data[constants.AuditConfigMapKey] = data
+		return nil
+	}
+
+	if !exists {
+		if err := mutate(); err != nil {
+			return err
+		}
+		return l.configMapClient.Create(ctx, configMap)
+	}
+	return utils.PatchResource(l.configMapClient, ctx, configMap, mutate)
+}
+
+// FormatEntries encodes entries as JSON lines, the format of the ring buffer ConfigMap.
+func FormatEntries(entries []Entry) (string, error) {
+	var builder strings.Builder
+	for _, entry := range entries {
+		line, err := json.Marshal(entry)
+		if err != nil {
+			return "", err
+		}
+		builder.Write(line)
+		builder.WriteByte('\n')
+	}
+	return builder.String(), nil
+}
+
+// ParseEntries decodes JSON lines of the ring buffer ConfigMap. Lines that cannot be decoded are skipped.
+func ParseEntries(data string) []Entry {
+	var entries []Entry
+	for _, line := range strings.Split(data, "\n") {
+		if line == "" {
+			continue
+		}
+		var entry Entry
+		if err := json.Unmarshal([]byte(line), &entry); err == nil {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+type policyKey struct{}
+type trapKey struct{}
+
+// WithPolicy returns a context whose audit entries are attributed to the deception policy.
+func WithPolicy(ctx context.Context, deceptionPolicyName string) context.Context {
+	return context.WithValue(ctx, policyKey{}, deceptionPolicyName)
+}
+
+// WithTrap returns a context whose audit entries are attributed to the trap (see TrapHash).
+func WithTrap(ctx context.Context, trap v1alpha1.Trap) context.Context {
+	return context.WithValue(ctx, trapKey{}, TrapHash(trap))
+}
+
+// TrapHash returns a short hash that identifies the trap, without revealing its content.
+func TrapHash(trap v1alpha1.Trap) string {
+	trapJSON, err := json.Marshal(trap)
+	if err != nil {
+		return ""
+	}
+	return utils.ShortHash(string(trapJSON))
+}
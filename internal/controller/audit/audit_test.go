@@ -0,0 +1,130 @@
+// Copyright (c) 2025 Dynatrace LLC
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package audit
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/dynatrace-oss/koney/api/v1alpha1"
+	"github.com/dynatrace-oss/koney/internal/controller/constants"
+)
+
+var _ = Describe("Audit log", func() {
+	ctx := context.Background()
+
+	var fakeClient client.Client
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		fakeClient = fake.NewClientBuilder().WithScheme(scheme).Build()
+	})
+
+	readEntries := func() []Entry {
+		configMap := corev1.ConfigMap{}
+		Expect(fakeClient.Get(ctx, client.ObjectKey{Namespace: constants.KoneyNamespace, Name: constants.AuditConfigMapName}, &configMap)).To(Succeed())
+		return ParseEntries(configMap.Data[constants.AuditConfigMapKey])
+	}
+
+	It("should record the mutations of a wrapped client with their policy and trap", func() {
+		log := NewLog(fakeClient, 10)
+		auditedClient := WrapClient(fakeClient, log)
+
+		trap := v1alpha1.Trap{FilesystemHoneytoken: v1alpha1.FilesystemHoneytoken{FilePath: "/run/secrets/koney/token", FileContent: "secret"}}
+		trapCtx := WithTrap(WithPolicy(ctx, "test-deception-policy"), trap)
+
+		secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "test-secret", Namespace: "default"}}
+		Expect(auditedClient.Create(trapCtx, secret)).To(Succeed())
+		Expect(auditedClient.Delete(trapCtx, secret)).To(Succeed())
+		Expect(auditedClient.Delete(ctx, secret)).NotTo(Succeed())
+		Expect(log.Flush(ctx)).To(Succeed())
+
+		entries := readEntries()
+		Expect(entries).To(HaveLen(3))
+		Expect(entries[0].Action).To(Equal(ActionCreate))
+		Expect(entries[0].Target).To(Equal(Target{Kind: "Secret", Namespace: "default", Name: "test-secret"}))
+		Expect(entries[0].DeceptionPolicy).To(Equal("test-deception-policy"))
+		Expect(entries[0].TrapHash).To(Equal(TrapHash(trap)))
+		Expect(entries[0].Outcome).To(Equal(OutcomeSuccess))
+		Expect(entries[1].Action).To(Equal(ActionDelete))
+		Expect(entries[2].DeceptionPolicy).To(BeEmpty())
+		Expect(entries[2].Outcome).To(Equal(OutcomeFailure))
+		Expect(entries[2].Error).NotTo(BeEmpty())
+	})
+
+	It("should record exec commands without their arguments", func() {
+		log := NewLog(fakeClient, 10)
+		auditedClient := WrapClient(fakeClient, log)
+
+		pod := corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "test-pod", Namespace: "default"}}
+		RecordExec(auditedClient, ctx, pod, "nginx", []string{"sh", "-c", "echo secret > /tmp/token"}, errors.New("exit code 1"))
+		Expect(log.Flush(ctx)).To(Succeed())
+
+		entries := readEntries()
+		Expect(entries).To(HaveLen(1))
+		Expect(entries[0].Action).To(Equal(ActionExec))
+		Expect(entries[0].Target).To(Equal(Target{Kind: "Pod", Namespace: "default", Name: "test-pod", Container: "nginx"}))
+		Expect(entries[0].Command).To(Equal("sh"))
+		Expect(entries[0].CommandHash).NotTo(BeEmpty())
+		Expect(entries[0].Outcome).To(Equal(OutcomeFailure))
+	})
+
+	It("should only keep the most recent entries in the ConfigMap", func() {
+		log := NewLog(fakeClient, 3)
+		auditedClient := WrapClient(fakeClient, log)
+
+		for _, name := range []string{"a", "b"} {
+			Expect(auditedClient.Create(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}})).To(Succeed())
+		}
+		Expect(log.Flush(ctx)).To(Succeed())
+
+		for _, name := range []string{"c", "d"} {
+			Expect(auditedClient.Create(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}})).To(Succeed())
+		}
+		Expect(log.Flush(ctx)).To(Succeed())
+
+		entries := readEntries()
+		Expect(entries).To(HaveLen(3))
+		Expect(entries[0].Target.Name).To(Equal("b"))
+		Expect(entries[2].Target.Name).To(Equal("d"))
+	})
+
+	It("should not write a ConfigMap if it is disabled", func() {
+		log := NewLog(fakeClient, 0)
+		Expect(WrapClient(fakeClient, log).Create(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "default"}})).To(Succeed())
+		Expect(log.Flush(ctx)).To(Succeed())
+
+		configMap := corev1.ConfigMap{}
+		err := fakeClient.Get(ctx, client.ObjectKey{Namespace: constants.KoneyNamespace, Name: constants.AuditConfigMapName}, &configMap)
+		Expect(client.IgnoreNotFound(err)).To(Succeed())
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should not wrap clients without a log", func() {
+		Expect(WrapClient(fakeClient, nil)).To(BeIdenticalTo(fakeClient))
+		Expect(LogFromClient(fakeClient)).To(BeNil())
+	})
+})
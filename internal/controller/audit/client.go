@@ -0,0 +1,95 @@
+// Copyright (c) 2025 Dynatrace LLC
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package audit
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// auditedClient records every create, update, patch, and delete of the wrapped client in the audit log.
+// Reads and writes of subresources (e.g., the status of deception policies) are not audited.
+type auditedClient struct {
+	client.Client
+	log *Log
+}
+
+// WrapClient returns a client that records all its mutations in the audit log.
+// If the log is nil, the client is returned as it is.
+func WrapClient(c client.Client, log *Log) client.Client {
+	if log == nil {
+		return c
+	}
+	return &auditedClient{Client: c, log: log}
+}
+
+// LogFromClient returns the audit log of a client that was wrapped with WrapClient, or nil otherwise.
+// Use it to record mutations that do not go through the client, e.g., commands executed in containers.
+func LogFromClient(c client.Client) *Log {
+	if auditedClient, ok := c.(*auditedClient); ok {
+		return auditedClient.log
+	}
+	return nil
+}
+
+// RecordExec records a command that was executed in a container, if the client was wrapped with WrapClient.
+func RecordExec(c client.Client, ctx context.Context, pod corev1.Pod, containerName string, cmd []string, err error) {
+	LogFromClient(c).RecordExec(ctx, pod, containerName, cmd, err)
+}
+
+func (c *auditedClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	err := c.Client.Create(ctx, obj, opts...)
+	c.log.Record(ctx, ActionCreate, c.target(obj), err)
+	return err
+}
+
+func (c *auditedClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	err := c.Client.Update(ctx, obj, opts...)
+	c.log.Record(ctx, ActionUpdate, c.target(obj), err)
+	return err
+}
+
+func (c *auditedClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	err := c.Client.Patch(ctx, obj, patch, opts...)
+	c.log.Record(ctx, ActionPatch, c.target(obj), err)
+	return err
+}
+
+func (c *auditedClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	err := c.Client.Delete(ctx, obj, opts...)
+	c.log.Record(ctx, ActionDelete, c.target(obj), err)
+	return err
+}
+
+// target references the object by its kind, namespace, and name.
+func (c *auditedClient) target(obj client.Object) Target {
+	return Target{Kind: kindOf(obj, c.Scheme()), Namespace: obj.GetNamespace(), Name: obj.GetName()}
+}
+
+// kindOf returns the kind of the object, which typed objects from the client usually come without.
+func kindOf(obj client.Object, scheme *runtime.Scheme) string {
+	if kind := obj.GetObjectKind().GroupVersionKind().Kind; kind != "" {
+		return kind
+	}
+	if gvk, err := apiutil.GVKForObject(obj, scheme); err == nil {
+		return gvk.Kind
+	}
+	return ""
+}
@@ -0,0 +1,174 @@
+// Copyright (c) 2025 Dynatrace LLC
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/dynatrace-oss/koney/api/v1alpha1"
+	"github.com/dynatrace-oss/koney/internal/controller/matching"
+	"github.com/dynatrace-oss/koney/internal/controller/presets"
+	"github.com/dynatrace-oss/koney/internal/controller/utils"
+)
+
+// policyConflict is a filesystem honeytoken that is deployed to the same containers as a filesystem honeytoken
+// of another DeceptionPolicy, with the same file path, but a different content.
+type policyConflict struct {
+	// FilePath is the file path of both conflicting traps.
+	FilePath string
+	// OtherPolicy is the other DeceptionPolicy.
+	OtherPolicy *v1alpha1.DeceptionPolicy
+	// Yielded is true if the other policy takes precedence, i.e., if the trap of this policy is not deployed.
+	Yielded bool
+}
+
+// findPolicyConflicts returns the conflicts of the given traps with the traps of all other DeceptionPolicies.
+// Traps are indexed by their file path first, so targets are only matched for traps that could actually conflict.
+func findPolicyConflicts(r client.Reader, ctx context.Context, deceptionPolicy *v1alpha1.DeceptionPolicy, traps []v1alpha1.Trap, excludedNamespaces []string) ([]policyConflict, error) {
+	deceptionPolicies, err := listAllDeceptionPolicies(r, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	type otherTrap struct {
+		policy *v1alpha1.DeceptionPolicy
+		trap   v1alpha1.Trap
+	}
+
+	otherTrapsByPath := map[string][]otherTrap{}
+	for i := range deceptionPolicies {
+		if deceptionPolicies[i].Name == deceptionPolicy.Name || deceptionPolicies[i].GetDeletionTimestamp() != nil {
+			continue
+		}
+		otherPolicy := presets.ExpandPresets(&deceptionPolicies[i])
+		for _, trap := range otherPolicy.Spec.Traps {
+			if trap.TrapType() == v1alpha1.FilesystemHoneytokenTrap {
+				filePath := trap.FilesystemHoneytoken.FilePath
+				otherTrapsByPath[filePath] = append(otherTrapsByPath[filePath], otherTrap{policy: otherPolicy, trap: trap})
+			}
+		}
+	}
+
+	var conflicts []policyConflict
+	for _, trap := range traps {
+		if trap.TrapType() != v1alpha1.FilesystemHoneytokenTrap {
+			continue
+		}
+
+		var targets map[string]bool
+		for _, other := range otherTrapsByPath[trap.FilesystemHoneytoken.FilePath] {
+			if reflect.DeepEqual(trap.FilesystemHoneytoken, other.trap.FilesystemHoneytoken) {
+				// Identical honeytokens do not conflict, both policies deploy the same decoy
+				continue
+			}
+
+			if targets == nil {
+				if targets, err = getTrapTargets(r, ctx, trap, excludedNamespaces); err != nil {
+					return nil, err
+				}
+			}
+
+			otherTargets, err := getTrapTargets(r, ctx, other.trap, excludedNamespaces)
+			if err != nil {
+				return nil, err
+			}
+
+			for target := range otherTargets {
+				if targets[target] {
+					conflicts = append(conflicts, policyConflict{
+						FilePath:    trap.FilesystemHoneytoken.FilePath,
+						OtherPolicy: other.policy,
+						Yielded:     other.policy.TakesPrecedenceOver(deceptionPolicy),
+					})
+					break
+				}
+			}
+		}
+	}
+
+	return conflicts, nil
+}
+
+// getTrapTargets returns the containers that a trap is deployed to, as keys that identify the object and the container.
+func getTrapTargets(r client.Reader, ctx context.Context, trap v1alpha1.Trap, excludedNamespaces []string) (map[string]bool, error) {
+	matchingResult, err := matching.GetDeployableObjectsWithContainers(r, ctx, trap, nil, excludedNamespaces)
+	if err != nil {
+		return nil, err
+	}
+
+	targets := map[string]bool{}
+	for object, containers := range matchingResult.DeployableObjects {
+		for _, container := range containers {
+			targets[fmt.Sprintf("%T/%s/%s/%s", object, object.GetNamespace(), object.GetName(), container)] = true
+		}
+	}
+	return targets, nil
+}
+
+// filterYieldedTraps removes the traps that are not deployed, because a conflicting trap of another policy takes precedence.
+func filterYieldedTraps(traps []v1alpha1.Trap, conflicts []policyConflict) []v1alpha1.Trap {
+	return slices.DeleteFunc(slices.Clone(traps), func(trap v1alpha1.Trap) bool {
+		return trap.TrapType() == v1alpha1.FilesystemHoneytokenTrap && slices.ContainsFunc(conflicts, func(conflict policyConflict) bool {
+			return conflict.Yielded && conflict.FilePath == trap.FilesystemHoneytoken.FilePath
+		})
+	})
+}
+
+// describePolicyConflicts returns a message for the PolicyConflict condition, from the point of view of the policy with the conflicts.
+func describePolicyConflicts(conflicts []policyConflict) string {
+	descriptions := make([]string, 0, len(conflicts))
+	for _, conflict := range conflicts {
+		if conflict.Yielded {
+			descriptions = append(descriptions, fmt.Sprintf("%s is not deployed, DeceptionPolicy %s takes precedence", conflict.FilePath, conflict.OtherPolicy.Name))
+		} else {
+			descriptions = append(descriptions, fmt.Sprintf("%s takes precedence over DeceptionPolicy %s", conflict.FilePath, conflict.OtherPolicy.Name))
+		}
+	}
+	return strings.Join(descriptions, "; ")
+}
+
+// reportPolicyConflicts emits an event for every trap that is not deployed due to a conflict,
+// and puts the PolicyConflict condition on the other policies, so that the conflict is visible on both policies right away.
+// The other policies update their condition themselves when they are reconciled the next time.
+func (r *DeceptionPolicyReconciler) reportPolicyConflicts(ctx context.Context, deceptionPolicy *v1alpha1.DeceptionPolicy, conflicts []policyConflict) {
+	log := log.FromContext(ctx)
+
+	for _, conflict := range conflicts {
+		if conflict.Yielded && r.Recorder != nil {
+			r.Recorder.Eventf(deceptionPolicy, corev1.EventTypeWarning, EventReason_PolicyConflict,
+				"Filesystem honeytoken %s is not deployed, because DeceptionPolicy %s takes precedence", conflict.FilePath, conflict.OtherPolicy.Name)
+		}
+
+		otherConflicts := []policyConflict{{FilePath: conflict.FilePath, OtherPolicy: deceptionPolicy, Yielded: !conflict.Yielded}}
+		otherPolicy := conflict.OtherPolicy
+		err := utils.PatchResourceStatus(r.Client, ctx, otherPolicy, func() error {
+			otherPolicy.Status.PutCondition(PolicyConflictType, metav1.ConditionTrue, PolicyConflictReason_Conflict, describePolicyConflicts(otherConflicts))
+			return nil
+		})
+		if client.IgnoreNotFound(err) != nil {
+			log.Error(err, "PolicyConflict condition cannot be set on the other DeceptionPolicy", "DeceptionPolicy", deceptionPolicy.Name, "other", otherPolicy.Name)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright (c) 2025 Dynatrace LLC
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/dynatrace-oss/koney/api/v1alpha1"
+)
+
+var _ = Describe("Policy conflicts", func() {
+	ctx := context.Background()
+
+	var fakeClient client.Client
+
+	newPod := func(name string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name, Labels: map[string]string{"app": name}},
+			Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}},
+			Status: corev1.PodStatus{
+				Phase:      corev1.PodRunning,
+				Conditions: []corev1.PodCondition{{Type: corev1.ContainersReady, Status: corev1.ConditionTrue}},
+				ContainerStatuses: []corev1.ContainerStatus{{
+					Name:  "app",
+					Ready: true,
+					State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+				}},
+			},
+		}
+	}
+
+	newPolicy := func(name string, priority int32, app, content string) *v1alpha1.DeceptionPolicy {
+		return &v1alpha1.DeceptionPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: name, CreationTimestamp: metav1.NewTime(time.Now().Truncate(time.Second))},
+			Spec: v1alpha1.DeceptionPolicySpec{
+				Priority: priority,
+				Traps: []v1alpha1.Trap{{
+					FilesystemHoneytoken: v1alpha1.FilesystemHoneytoken{FilePath: "/run/secrets/token", FileContent: content},
+					DecoyDeployment:      v1alpha1.DecoyDeployment{Strategy: "containerExec"},
+					MatchResources: v1alpha1.MatchResources{Any: []v1alpha1.ResourceFilter{{ResourceDescription: v1alpha1.ResourceDescription{
+						Selector:          &metav1.LabelSelector{MatchLabels: map[string]string{"app": app}},
+						ContainerSelector: "*",
+					}}}},
+				}},
+			},
+		}
+	}
+
+	setup := func(objects ...client.Object) {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(v1alpha1.AddToScheme(scheme)).To(Succeed())
+
+		fakeClient = fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(objects...).
+			WithStatusSubresource(&v1alpha1.DeceptionPolicy{}).
+			Build()
+	}
+
+	It("should detect traps with the same path and different content in the same containers", func() {
+		winner := newPolicy("winner", 10, "web", "first")
+		loser := newPolicy("loser", 0, "web", "second")
+		setup(newPod("web"), winner, loser)
+
+		conflicts, err := findPolicyConflicts(fakeClient, ctx, loser, loser.Spec.Traps, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(conflicts).To(HaveLen(1))
+		Expect(conflicts[0].FilePath).To(Equal("/run/secrets/token"))
+		Expect(conflicts[0].OtherPolicy.Name).To(Equal("winner"))
+		Expect(conflicts[0].Yielded).To(BeTrue())
+		Expect(filterYieldedTraps(loser.Spec.Traps, conflicts)).To(BeEmpty())
+
+		conflicts, err = findPolicyConflicts(fakeClient, ctx, winner, winner.Spec.Traps, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(conflicts).To(HaveLen(1))
+		Expect(conflicts[0].Yielded).To(BeFalse())
+		Expect(filterYieldedTraps(winner.Spec.Traps, conflicts)).To(HaveLen(1))
+	})
+
+	It("should ignore traps with the same content or without common targets", func() {
+		policy := newPolicy("policy", 0, "web", "first")
+		sameContent := newPolicy("same-content", 0, "web", "first")
+		otherTargets := newPolicy("other-targets", 0, "db", "second")
+		setup(newPod("web"), newPod("db"), policy, sameContent, otherTargets)
+
+		conflicts, err := findPolicyConflicts(fakeClient, ctx, policy, policy.Spec.Traps, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(conflicts).To(BeEmpty())
+	})
+
+	It("should put the PolicyConflict condition on the other policy", func() {
+		winner := newPolicy("winner", 10, "web", "first")
+		loser := newPolicy("loser", 0, "web", "second")
+		setup(newPod("web"), winner, loser)
+
+		recorder := record.NewFakeRecorder(10)
+		r := &DeceptionPolicyReconciler{Client: fakeClient, Recorder: recorder}
+
+		conflicts, err := findPolicyConflicts(fakeClient, ctx, loser, loser.Spec.Traps, nil)
+		Expect(err).NotTo(HaveOccurred())
+		r.reportPolicyConflicts(ctx, loser, conflicts)
+		Expect(recorder.Events).To(Receive(ContainSubstring(EventReason_PolicyConflict)))
+
+		stored := &v1alpha1.DeceptionPolicy{}
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(winner), stored)).To(Succeed())
+		condition := stored.Status.GetCondition(PolicyConflictType)
+		Expect(condition).NotTo(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Message).To(Equal("/run/secrets/token takes precedence over DeceptionPolicy loser"))
+	})
+})
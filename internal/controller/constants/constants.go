@@ -25,6 +25,28 @@ const (
 	// Koney needs this annotation when cleaning up or updating traps. Also, this makes it easier to see modified resources.
 	AnnotationKeyChanges = "koney/changes"
 
+	// AnnotationKeyChangesRef is the annotation key that points to the companion ConfigMap of a resource.
+	// If the changes annotation would grow too large, older changes are spilled into that ConfigMap.
+	AnnotationKeyChangesRef = "koney/changes-ref"
+
+	// LabelKeyChangesOf is the label key that is placed on companion ConfigMaps, referencing the name of the annotated resource.
+	LabelKeyChangesOf = "koney/changes-of"
+
+	// LabelKeyManaged is the label key that is placed on resources next to the changes annotation (with the value "true").
+	// Unlike annotations, labels can be selected by the API server, so Koney only needs to list the resources that it modified.
+	LabelKeyManaged = "koney/managed"
+
+	// DefaultMaxAnnotationSize is the default maximum size (in bytes) of the changes annotation.
+	// Kubernetes limits the total size of all annotations of a resource to 256 KiB.
+	DefaultMaxAnnotationSize = 64 * 1024
+
+	// DefaultMaxConcurrentDeployments is the default maximum number of resources that decoys are deployed to at the same time.
+	DefaultMaxConcurrentDeployments = 10
+
+	// FieldManager is the name of the field manager that Koney uses for all its writes,
+	// so that its changes can be told apart from changes of other controllers (see metadata.managedFields).
+	FieldManager = "koney"
+
 	// FinalizerName is the name of the finalizer that Koney places on each DeceptionPolicy.
 	// The presence of this finalizer means that traps still need to be cleaned up (e.g., when the DeceptionPolicy is deleted).
 	FinalizerName = "koney/finalizer"
@@ -33,15 +55,125 @@ const (
 	// Koney might create resources such as a TracingPolicy for captors.
 	LabelKeyDeceptionPolicyRef = "koney/deception-policy"
 
+	// AnnotationKeyNamespacedPolicyRef is the annotation key that is placed on DeceptionPolicies that Koney manages for a NamespacedDeceptionPolicy.
+	// The value is the namespace and name of the NamespacedDeceptionPolicy, separated by a slash.
+	AnnotationKeyNamespacedPolicyRef = "koney/namespaced-deception-policy"
+
+	// LabelKeyGeneratedContent is the label key that is placed on Secrets that hold honeytoken contents generated by Koney (with the value "true").
+	LabelKeyGeneratedContent = "koney/generated-content"
+
+	// AnnotationKeyGeneratedAt is the annotation key that is placed on Secrets with generated honeytoken contents.
+	// The value is the time (in RFC 3339 format) when the content was generated, which is used to rotate it.
+	AnnotationKeyGeneratedAt = "koney/generated-at"
+
+	// AnnotationKeyGenerator is the annotation key that is placed on Secrets with generated honeytoken contents.
+	// The value is a hash of the generator settings, so that the content is regenerated if they change.
+	AnnotationKeyGenerator = "koney/generator"
+
+	// LabelKeyNetworkHoneypotRef is the label key that is placed on resources that make up a network honeypot.
+	// The value identifies the network honeypot trap, so that the listener pods can be selected by Services and captors.
+	LabelKeyNetworkHoneypotRef = "koney/network-honeypot"
+
+	// NetworkHoneypotImage is the container image that runs the listener of network honeypots.
+	NetworkHoneypotImage = "alpine/socat:1.8.0.1"
+
+	// SidecarCaptorImage is the container image of sidecar captors, which watch decoys with inotifyd and report with wget.
+	SidecarCaptorImage = "busybox:1.37"
+
+	// SidecarCaptorNamePrefix is the prefix of the names of sidecar captor containers.
+	// Containers with this prefix are never selected for traps.
+	SidecarCaptorNamePrefix = "koney-captor-"
+
+	// DecoyInitContainerImage is the container image of init containers, which copy decoys into emptyDir volumes.
+	DecoyInitContainerImage = "busybox:1.37"
+
+	// DecoyInitContainerNamePrefix is the prefix of the names of init containers that copy decoys.
+	DecoyInitContainerNamePrefix = "koney-init-"
+
+	// AnnotationKeyEnvVarName is the annotation key that is placed on TracingPolicies of environment variable honeytokens.
+	// The value is the name of the decoy environment variable.
+	AnnotationKeyEnvVarName = "koney/envvar-name"
+
+	// AnnotationKeyEnvVarValue is the annotation key that is placed on TracingPolicies of environment variable honeytokens.
+	// The alert forwarder uses the value to check if the decoy appears in the arguments of processes.
+	AnnotationKeyEnvVarValue = "koney/envvar-value"
+
 	// If reconciliation fails, retry after this interval.
 	NormalFailureRetryInterval = 1 * time.Minute
 
 	// If resources are not ready yet for traps (e.g., containers are still starting), retry reconciliation after this shorter interval.
 	ShortStatusCheckInterval = 10 * time.Second
 
+	// DefaultSyncInterval is how often traps are checked again after they were deployed successfully,
+	// unless a DeceptionPolicy sets its own interval.
+	DefaultSyncInterval = 10 * time.Minute
+
 	// WildcardContainerSelectorRegex is a regex that matches wildcard characters in container selector fields.
 	WildcardContainerSelectorRegex = `\*|\?|\[|\]`
 
-	// TetragonWebhookUrl is the URL of the alert forwarder that receives alerts from Tetragon.
-	TetragonWebhookUrl = "http://koney-alert-forwarder-service." + KoneyNamespace + ".svc:8000/handlers/tetragon"
+	// DefaultAlertWebhookHost is the host of the alert forwarder that captors send their alerts to.
+	// Clusters where captors cannot resolve cluster DNS names may use the (IPv4 or IPv6) address of the Service instead.
+	DefaultAlertWebhookHost = "koney-alert-forwarder-service." + KoneyNamespace + ".svc"
+
+	// AlertWebhookPort is the port where the alert forwarder receives alerts.
+	AlertWebhookPort = "8000"
+
+	// TetragonWebhookPath is the path of the alert forwarder handler that receives alerts from Tetragon.
+	TetragonWebhookPath = "/handlers/tetragon"
+
+	// SidecarWebhookPath is the path of the alert forwarder handler that receives alerts from sidecar captors.
+	SidecarWebhookPath = "/handlers/sidecar"
+
+	// PluginWebhookPath is the path of the alert forwarder handler that receives alerts from captors of trap plugins.
+	PluginWebhookPath = "/handlers/plugin"
+
+	// OperatorWebhookPath is the path of the alert forwarder handler that receives alerts raised by the operator itself,
+	// e.g., if a decoy was tampered with.
+	OperatorWebhookPath = "/handlers/operator"
+
+	// TetragonWebhookUrl is the default URL of the alert forwarder that receives alerts from Tetragon.
+	TetragonWebhookUrl = "http://" + DefaultAlertWebhookHost + ":" + AlertWebhookPort + TetragonWebhookPath
+
+	// SidecarWebhookUrl is the default URL of the alert forwarder that receives alerts from sidecar captors.
+	SidecarWebhookUrl = "http://" + DefaultAlertWebhookHost + ":" + AlertWebhookPort + SidecarWebhookPath
+
+	// PluginWebhookUrl is the default URL of the alert forwarder that receives alerts from captors of trap plugins.
+	PluginWebhookUrl = "http://" + DefaultAlertWebhookHost + ":" + AlertWebhookPort + PluginWebhookPath
+
+	// WebhookAuthSecretName is the name of the secret in the Koney namespace that holds the HMAC key
+	// which authenticates webhook calls of captors to the alert forwarder.
+	WebhookAuthSecretName = "koney-webhook-auth"
+
+	// WebhookAuthSecretKey is the key in the webhook auth secret that holds the HMAC key.
+	WebhookAuthSecretKey = "hmac-key"
+
+	// WebhookPolicyParam is the query parameter of webhook calls that holds the name of the deception policy.
+	WebhookPolicyParam = "policy"
+
+	// WebhookSignatureParam is the query parameter of webhook calls that holds the HMAC signature of the deception policy name.
+	WebhookSignatureParam = "signature"
+
+	// DefaultPluginDir is the default directory where the manager discovers trap plugins by their Unix sockets.
+	DefaultPluginDir = "/var/run/koney/plugins"
+
+	// PluginCallTimeout is the maximum time that a single call to a trap plugin may take.
+	PluginCallTimeout = 30 * time.Second
+
+	// DefaultFalcoNamespace is the default namespace where Falco is assumed to be running.
+	DefaultFalcoNamespace = "falco"
+
+	// FalcoRulesConfigMapName is the name of the ConfigMap that holds the Falco rules of all deception policies.
+	// Each deception policy owns one key in this ConfigMap. Falco must mount the ConfigMap into its rules directory.
+	FalcoRulesConfigMapName = "koney-falco-rules"
+
+	// AuditConfigMapName is the name of the ConfigMap in the Koney namespace that keeps the most recent audit log entries,
+	// i.e., the mutations that Koney performed in the cluster.
+	AuditConfigMapName = "koney-audit-log"
+
+	// AuditConfigMapKey is the key in the audit ConfigMap that holds the entries as JSON lines (oldest first).
+	AuditConfigMapKey = "entries.jsonl"
+
+	// FalcoTagDeceptionPolicyRef is the prefix of the Falco rule tag that references the deception policy.
+	// The alert forwarder uses this tag to map Falco alerts to deception policies.
+	FalcoTagDeceptionPolicyRef = "koney/deception-policy="
 )
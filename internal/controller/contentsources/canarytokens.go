@@ -0,0 +1,60 @@
+// Copyright (c) 2025 Dynatrace LLC
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package contentsources
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/dynatrace-oss/koney/api/v1alpha1"
+	"github.com/dynatrace-oss/koney/pkg/tokenprovider"
+)
+
+// resolveCanaryToken returns the content of a canary token that is minted by a token provider.
+// The token is minted only once (and again on every rotation), since every call of the provider mints a new token.
+func resolveCanaryToken(c client.Client, ctx context.Context, deceptionPolicy *v1alpha1.DeceptionPolicy, filePath string, source *v1alpha1.CanaryTokenSource, providers tokenprovider.Providers, now time.Time) (string, error) {
+	log := log.FromContext(ctx)
+
+	return resolveContentOfGenerator(c, ctx, deceptionPolicy, filePath, source, func() (string, error) {
+		providerName := source.GetProvider()
+		provider, err := providers.Get(providerName)
+		if err != nil {
+			return "", err
+		}
+
+		memo := source.Memo
+		if memo == "" {
+			memo = fmt.Sprintf("Koney honeytoken %s of the DeceptionPolicy %s", filePath, deceptionPolicy.Name)
+		}
+
+		token, err := provider.MintToken(ctx, tokenprovider.TokenRequest{
+			Kind:       source.Kind,
+			Memo:       memo,
+			Email:      source.Email,
+			WebhookURL: source.WebhookURL,
+		})
+		if err != nil {
+			return "", fmt.Errorf("cannot mint canary token with provider '%s': %w", providerName, err)
+		}
+
+		log.Info("Minted canary token for trap", "provider", providerName, "token", token.ID, "filePath", filePath)
+		return token.Content, nil
+	}, now)
+}
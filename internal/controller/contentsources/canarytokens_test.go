@@ -0,0 +1,122 @@
+// Copyright (c) 2025 Dynatrace LLC
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package contentsources
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/dynatrace-oss/koney/api/v1alpha1"
+	"github.com/dynatrace-oss/koney/pkg/tokenprovider"
+)
+
+// countingProvider mints tokens that are numbered by the calls of MintToken.
+type countingProvider struct {
+	requests []tokenprovider.TokenRequest
+}
+
+func (p *countingProvider) MintToken(_ context.Context, req tokenprovider.TokenRequest) (*tokenprovider.Token, error) {
+	p.requests = append(p.requests, req)
+	id := fmt.Sprintf("token-%d", len(p.requests))
+	return &tokenprovider.Token{ID: id, Content: "https://canarytokens.example.com/" + id}, nil
+}
+
+var _ = Describe("Canary token content sources", func() {
+	ctx := context.Background()
+
+	const filePath = "/run/secrets/koney/url"
+
+	var (
+		fakeClient client.Client
+		provider   *countingProvider
+		providers  tokenprovider.Providers
+		policy     *v1alpha1.DeceptionPolicy
+	)
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(v1alpha1.AddToScheme(scheme)).To(Succeed())
+
+		fakeClient = fake.NewClientBuilder().WithScheme(scheme).Build()
+		provider = &countingProvider{}
+		providers = tokenprovider.Providers{"canarytokens": provider}
+		policy = &v1alpha1.DeceptionPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-deception-policy", UID: "test-uid"},
+			Spec: v1alpha1.DeceptionPolicySpec{
+				Rotate: &metav1.Duration{Duration: 24 * time.Hour},
+				Traps: []v1alpha1.Trap{{
+					FilesystemHoneytoken: v1alpha1.FilesystemHoneytoken{
+						FilePath: filePath,
+						FileContentFrom: &v1alpha1.FileContentSource{CanaryToken: &v1alpha1.CanaryTokenSource{
+							Kind:  "web",
+							Email: "security@example.com",
+						}},
+					},
+					DecoyDeployment: v1alpha1.DecoyDeployment{Strategy: "containerExec"},
+					MatchResources: v1alpha1.MatchResources{Any: []v1alpha1.ResourceFilter{{ResourceDescription: v1alpha1.ResourceDescription{
+						Namespaces: []string{"default"},
+					}}}},
+				}},
+			},
+		}
+	})
+
+	It("should mint the token once and keep it in a Secret", func() {
+		for range 2 {
+			resolvedPolicy, err := ResolveTrapContents(fakeClient, ctx, policy, providers)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resolvedPolicy.Spec.Traps[0].FilesystemHoneytoken.FileContent).To(Equal("https://canarytokens.example.com/token-1"))
+		}
+
+		Expect(provider.requests).To(HaveLen(1))
+		Expect(provider.requests[0]).To(Equal(tokenprovider.TokenRequest{
+			Kind:  "web",
+			Memo:  "Koney honeytoken /run/secrets/koney/url of the DeceptionPolicy test-deception-policy",
+			Email: "security@example.com",
+		}))
+	})
+
+	It("should mint a new token on rotation", func() {
+		source := policy.Spec.Traps[0].FilesystemHoneytoken.FileContentFrom.CanaryToken
+		now := time.Now()
+
+		content, err := resolveCanaryToken(fakeClient, ctx, policy, filePath, source, providers, now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(content).To(HaveSuffix("token-1"))
+
+		content, err = resolveCanaryToken(fakeClient, ctx, policy, filePath, source, providers, now.Add(25*time.Hour))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(content).To(HaveSuffix("token-2"))
+	})
+
+	It("should fail if the provider is not configured", func() {
+		policy.Spec.Traps[0].FilesystemHoneytoken.FileContentFrom.CanaryToken.Provider = "thinkst-canary"
+
+		_, err := ResolveTrapContents(fakeClient, ctx, policy, providers)
+		Expect(err).To(MatchError(tokenprovider.ErrProviderNotFound))
+		Expect(provider.requests).To(BeEmpty())
+	})
+})
@@ -0,0 +1,87 @@
+// Copyright (c) 2025 Dynatrace LLC
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package contentsources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/dynatrace-oss/koney/api/v1alpha1"
+	"github.com/dynatrace-oss/koney/pkg/tokenprovider"
+)
+
+// ErrContentNotReady is returned if the content of a trap is sourced from somewhere else,
+// but the content is not available yet (e.g., because the external secret was not synchronized yet).
+var ErrContentNotReady = errors.New("trap content is not available yet")
+
+// ResolveTrapContents returns a copy of the DeceptionPolicy, where the contents of all traps
+// that source their content from elsewhere (e.g., from an external secret store) are resolved,
+// i.e., the contents are placed inline, as if they were specified in the DeceptionPolicy directly.
+// Invalid traps are returned as they are, so that they can still be reported during validation.
+// Canary tokens are minted with the given token providers.
+func ResolveTrapContents(c client.Client, ctx context.Context, deceptionPolicy *v1alpha1.DeceptionPolicy, providers tokenprovider.Providers) (*v1alpha1.DeceptionPolicy, error) {
+	resolvedPolicy := deceptionPolicy.DeepCopy()
+
+	var errs error
+	for i := range resolvedPolicy.Spec.Traps {
+		trap := &resolvedPolicy.Spec.Traps[i]
+		if trap.IsValid() != nil {
+			continue
+		}
+
+		switch trap.TrapType() {
+		case v1alpha1.FilesystemHoneytokenTrap:
+			if trap.FilesystemHoneytoken.FileContentFrom == nil {
+				continue
+			}
+
+			content, err := resolveFileContentSource(c, ctx, deceptionPolicy, trap.FilesystemHoneytoken.FilePath, trap.FilesystemHoneytoken.FileContentFrom, providers)
+			if err != nil {
+				errs = errors.Join(errs, fmt.Errorf("cannot resolve content of filesystem honeytoken '%s': %w", trap.FilesystemHoneytoken.FilePath, err))
+				continue
+			}
+
+			trap.FilesystemHoneytoken.FileContent = content
+			trap.FilesystemHoneytoken.FileContentFrom = nil
+		default:
+			continue
+		}
+	}
+
+	return resolvedPolicy, errs
+}
+
+// resolveFileContentSource returns the content that a FileContentSource of the file at filePath points to.
+func resolveFileContentSource(c client.Client, ctx context.Context, deceptionPolicy *v1alpha1.DeceptionPolicy, filePath string, source *v1alpha1.FileContentSource, providers tokenprovider.Providers) (string, error) {
+	switch {
+	case source.ExternalSecret != nil:
+		return resolveExternalSecret(c, ctx, deceptionPolicy, source.ExternalSecret)
+	case source.SecretKeyRef != nil:
+		return resolveSecretKeyRef(c, ctx, source.SecretKeyRef)
+	case source.ConfigMapKeyRef != nil:
+		return resolveConfigMapKeyRef(c, ctx, source.ConfigMapKeyRef)
+	case source.Generate != nil:
+		return resolveGeneratedContent(c, ctx, deceptionPolicy, filePath, source.Generate, time.Now())
+	case source.CanaryToken != nil:
+		return resolveCanaryToken(c, ctx, deceptionPolicy, filePath, source.CanaryToken, providers, time.Now())
+	default:
+		return "", errors.New("content source is unknown")
+	}
+}
@@ -0,0 +1,204 @@
+// Copyright (c) 2025 Dynatrace LLC
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package contentsources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/dynatrace-oss/koney/api/v1alpha1"
+	"github.com/dynatrace-oss/koney/internal/controller/constants"
+	"github.com/dynatrace-oss/koney/internal/controller/utils"
+)
+
+// externalSecretContentKey is the key in the synchronized Secret that holds the honeytoken content.
+const externalSecretContentKey = "content"
+
+// ExternalSecretGVK is the GroupVersionKind of ExternalSecrets of the external-secrets operator.
+// We use unstructured objects to avoid a dependency on the external-secrets API module.
+var ExternalSecretGVK = schema.GroupVersionKind{
+	Group:   "external-secrets.io",
+	Version: "v1beta1",
+	Kind:    "ExternalSecret",
+}
+
+// GenerateExternalSecretName generates the name of an ExternalSecret (and its target Secret) based on the source.
+func GenerateExternalSecretName(source *v1alpha1.ExternalSecretSource) (string, error) {
+	sourceJSON, err := json.Marshal(source)
+	if err != nil {
+		return "", err
+	}
+
+	return "koney-external-secret-" + utils.ShortHash(string(sourceJSON)), nil
+}
+
+// resolveExternalSecret makes sure that an ExternalSecret exists for the source and returns the synchronized value.
+// If the external-secrets operator did not synchronize the value yet, ErrContentNotReady is returned.
+func resolveExternalSecret(c client.Client, ctx context.Context, deceptionPolicy *v1alpha1.DeceptionPolicy, source *v1alpha1.ExternalSecretSource) (string, error) {
+	name, err := GenerateExternalSecretName(source)
+	if err != nil {
+		return "", err
+	}
+
+	if err := createExternalSecret(c, ctx, deceptionPolicy, source, name); err != nil {
+		if _, ok := err.(*meta.NoKindMatchError); ok {
+			return "", fmt.Errorf("external-secrets operator is not installed: %w", err)
+		}
+
+		return "", err
+	}
+
+	// The external-secrets operator writes the value into a Secret with the same name
+	secret := corev1.Secret{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: constants.KoneyNamespace, Name: name}, &secret); err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			return "", ErrContentNotReady
+		}
+
+		return "", err
+	}
+
+	content, ok := secret.Data[externalSecretContentKey]
+	if !ok {
+		return "", ErrContentNotReady
+	}
+
+	return string(content), nil
+}
+
+// createExternalSecret creates an ExternalSecret in the Koney namespace for the source.
+// The function does nothing if the ExternalSecret already exists.
+func createExternalSecret(c client.Client, ctx context.Context, deceptionPolicy *v1alpha1.DeceptionPolicy, source *v1alpha1.ExternalSecretSource, name string) error {
+	log := log.FromContext(ctx)
+
+	externalSecret := &unstructured.Unstructured{}
+	externalSecret.SetGroupVersionKind(ExternalSecretGVK)
+	if err := c.Get(ctx, client.ObjectKey{Namespace: constants.KoneyNamespace, Name: name}, externalSecret); err == nil {
+		return nil // Already exists
+	} else if client.IgnoreNotFound(err) != nil {
+		return err
+	}
+
+	remoteRef := map[string]any{"key": source.RemoteRef.Key}
+	if source.RemoteRef.Property != "" {
+		remoteRef["property"] = source.RemoteRef.Property
+	}
+	if source.RemoteRef.Version != "" {
+		remoteRef["version"] = source.RemoteRef.Version
+	}
+
+	refreshInterval := source.RefreshInterval
+	if refreshInterval == "" {
+		refreshInterval = "1h"
+	}
+
+	secretStoreKind := source.SecretStoreRef.Kind
+	if secretStoreKind == "" {
+		secretStoreKind = "ClusterSecretStore"
+	}
+
+	externalSecret = &unstructured.Unstructured{Object: map[string]any{
+		"spec": map[string]any{
+			"refreshInterval": refreshInterval,
+			"secretStoreRef": map[string]any{
+				"name": source.SecretStoreRef.Name,
+				"kind": secretStoreKind,
+			},
+			"target": map[string]any{
+				"name":           name,
+				"creationPolicy": "Owner",
+			},
+			"data": []any{
+				map[string]any{
+					"secretKey": externalSecretContentKey,
+					"remoteRef": remoteRef,
+				},
+			},
+		},
+	}}
+	externalSecret.SetGroupVersionKind(ExternalSecretGVK)
+	externalSecret.SetName(name)
+	externalSecret.SetNamespace(constants.KoneyNamespace)
+	externalSecret.SetLabels(map[string]string{
+		constants.LabelKeyDeceptionPolicyRef: deceptionPolicy.Name,
+	})
+	externalSecret.SetOwnerReferences([]metav1.OwnerReference{
+		{
+			APIVersion:         deceptionPolicy.APIVersion,
+			Kind:               deceptionPolicy.Kind,
+			Name:               deceptionPolicy.Name,
+			UID:                deceptionPolicy.UID,
+			BlockOwnerDeletion: &[]bool{true}[0], // A pointer to a bool
+			Controller:         &[]bool{true}[0],
+		},
+	})
+
+	log.Info("Creating ExternalSecret to source trap content", "ExternalSecret", name)
+	return c.Create(ctx, externalSecret)
+}
+
+// CleanupRemovedExternalSecrets deletes all ExternalSecrets of a DeceptionPolicy
+// that are no longer referenced by any of its traps.
+func CleanupRemovedExternalSecrets(c client.Client, ctx context.Context, deceptionPolicy *v1alpha1.DeceptionPolicy) error {
+	log := log.FromContext(ctx)
+
+	externalSecrets := &unstructured.UnstructuredList{}
+	externalSecrets.SetGroupVersionKind(ExternalSecretGVK.GroupVersion().WithKind(ExternalSecretGVK.Kind + "List"))
+	if err := c.List(ctx, externalSecrets, client.InNamespace(constants.KoneyNamespace), client.MatchingLabels{constants.LabelKeyDeceptionPolicyRef: deceptionPolicy.Name}); err != nil {
+		// If the error is *meta.NoKindMatchError, ignore it
+		if _, ok := err.(*meta.NoKindMatchError); ok {
+			// The external-secrets operator is not installed
+			return nil
+		}
+
+		return err
+	}
+
+	externalSecretNamesFromTraps := []string{}
+	for _, trap := range deceptionPolicy.Spec.Traps {
+		if trap.FilesystemHoneytoken.FileContentFrom == nil || trap.FilesystemHoneytoken.FileContentFrom.ExternalSecret == nil {
+			continue
+		}
+
+		name, err := GenerateExternalSecretName(trap.FilesystemHoneytoken.FileContentFrom.ExternalSecret)
+		if err != nil {
+			return err
+		}
+		externalSecretNamesFromTraps = append(externalSecretNamesFromTraps, name)
+	}
+
+	for i := range externalSecrets.Items {
+		if utils.Contains(externalSecretNamesFromTraps, externalSecrets.Items[i].GetName()) {
+			continue
+		}
+
+		log.Info("Deleting ExternalSecret for removed trap", "ExternalSecret", externalSecrets.Items[i].GetName())
+		if err := c.Delete(ctx, &externalSecrets.Items[i]); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+
+	return nil
+}
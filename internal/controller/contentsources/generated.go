@@ -0,0 +1,220 @@
+// Copyright (c) 2025 Dynatrace LLC
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package contentsources
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/dynatrace-oss/koney/api/v1alpha1"
+	"github.com/dynatrace-oss/koney/internal/controller/constants"
+	"github.com/dynatrace-oss/koney/internal/controller/utils"
+)
+
+// generatedContentKey is the key in the Secret that holds the generated honeytoken content.
+const generatedContentKey = "content"
+
+// generatedContentAlphabet are the characters that generated contents are made of.
+const generatedContentAlphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789"
+
+// GenerateContentSecretName generates the name of the Secret that holds the generated content of a filesystem honeytoken.
+func GenerateContentSecretName(deceptionPolicyName, filePath string) string {
+	return "koney-generated-" + utils.ShortHash(deceptionPolicyName+":"+filePath)
+}
+
+// resolveGeneratedContent returns the randomly generated content of a filesystem honeytoken.
+func resolveGeneratedContent(c client.Client, ctx context.Context, deceptionPolicy *v1alpha1.DeceptionPolicy, filePath string, source *v1alpha1.GeneratedContent, now time.Time) (string, error) {
+	return resolveContentOfGenerator(c, ctx, deceptionPolicy, filePath, source, func() (string, error) {
+		return generateRandomContent(source)
+	}, now)
+}
+
+// resolveContentOfGenerator returns the content of a filesystem honeytoken that Koney generates itself (or lets generate),
+// and which is kept in a Secret. The settings describe the generator, and generate is called to (re)generate the content
+// if it does not exist yet, if the settings changed, or if the content is due for rotation.
+func resolveContentOfGenerator(c client.Client, ctx context.Context, deceptionPolicy *v1alpha1.DeceptionPolicy, filePath string, settings any, generate func() (string, error), now time.Time) (string, error) {
+	log := log.FromContext(ctx)
+
+	generator, err := generatorFingerprint(settings)
+	if err != nil {
+		return "", err
+	}
+
+	name := GenerateContentSecretName(deceptionPolicy.Name, filePath)
+	secret := corev1.Secret{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: constants.KoneyNamespace, Name: name}, &secret); client.IgnoreNotFound(err) != nil {
+		return "", err
+	}
+
+	if content, ok := secret.Data[generatedContentKey]; ok && secret.Annotations[constants.AnnotationKeyGenerator] == generator {
+		if interval := deceptionPolicy.Spec.GetRotationInterval(); interval <= 0 || timeUntilRotation(&secret, interval, now) > 0 {
+			return string(content), nil
+		}
+		log.Info("Rotating generated content of trap", "Secret", name, "filePath", filePath)
+	}
+
+	content, err := generate()
+	if err != nil {
+		return "", err
+	}
+
+	secretExists := secret.Name != ""
+	secret.Name = name
+	secret.Namespace = constants.KoneyNamespace
+	secret.Labels = map[string]string{
+		constants.LabelKeyDeceptionPolicyRef: deceptionPolicy.Name,
+		constants.LabelKeyGeneratedContent:   "true",
+	}
+	secret.Annotations = map[string]string{
+		constants.AnnotationKeyGeneratedAt: now.UTC().Format(time.RFC3339),
+		constants.AnnotationKeyGenerator:   generator,
+	}
+	secret.OwnerReferences = []metav1.OwnerReference{
+		{
+			APIVersion:         v1alpha1.GroupVersion.String(),
+			Kind:               "DeceptionPolicy",
+			Name:               deceptionPolicy.Name,
+			UID:                deceptionPolicy.UID,
+			BlockOwnerDeletion: &[]bool{true}[0], // A pointer to a bool
+			Controller:         &[]bool{true}[0],
+		},
+	}
+	secret.Data = map[string][]byte{generatedContentKey: []byte(content)}
+
+	if secretExists {
+		err = c.Update(ctx, &secret)
+	} else {
+		log.Info("Creating Secret with generated content of trap", "Secret", name, "filePath", filePath)
+		err = c.Create(ctx, &secret)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return content, nil
+}
+
+// timeUntilRotation returns how long it takes until the content of the Secret is rotated next.
+// If the content is due for rotation already, zero (or a negative duration) is returned.
+func timeUntilRotation(secret *corev1.Secret, interval time.Duration, now time.Time) time.Duration {
+	generatedAt, err := time.Parse(time.RFC3339, secret.Annotations[constants.AnnotationKeyGeneratedAt])
+	if err != nil {
+		return 0 // If we don't know when the content was generated, we better rotate it
+	}
+
+	return generatedAt.Add(interval).Sub(now)
+}
+
+// generatorFingerprint returns a hash of the generator settings.
+func generatorFingerprint(settings any) (string, error) {
+	sourceJSON, err := json.Marshal(settings)
+	if err != nil {
+		return "", err
+	}
+
+	return utils.ShortHash(string(sourceJSON)), nil
+}
+
+// generateRandomContent generates a random content with the prefix and length of the source.
+func generateRandomContent(source *v1alpha1.GeneratedContent) (string, error) {
+	alphabetSize := big.NewInt(int64(len(generatedContentAlphabet)))
+
+	content := make([]byte, source.GetLength())
+	for i := range content {
+		n, err := rand.Int(rand.Reader, alphabetSize)
+		if err != nil {
+			return "", fmt.Errorf("cannot generate random content: %w", err)
+		}
+		content[i] = generatedContentAlphabet[n.Int64()]
+	}
+
+	return source.Prefix + string(content), nil
+}
+
+// TimeUntilNextRotation returns how long it takes until the next generated content of the DeceptionPolicy is rotated.
+// If the DeceptionPolicy does not rotate any generated contents, zero is returned.
+func TimeUntilNextRotation(c client.Client, ctx context.Context, deceptionPolicy *v1alpha1.DeceptionPolicy) (time.Duration, error) {
+	interval := deceptionPolicy.Spec.GetRotationInterval()
+	if interval <= 0 {
+		return 0, nil
+	}
+
+	secrets := &corev1.SecretList{}
+	if err := c.List(ctx, secrets, client.InNamespace(constants.KoneyNamespace), client.MatchingLabels{
+		constants.LabelKeyDeceptionPolicyRef: deceptionPolicy.Name,
+		constants.LabelKeyGeneratedContent:   "true",
+	}); err != nil {
+		return 0, err
+	}
+
+	var next time.Duration
+	now := time.Now()
+	for i := range secrets.Items {
+		// Rotations that are due already are retried as soon as possible, but not in a busy loop
+		untilRotation := max(timeUntilRotation(&secrets.Items[i], interval, now), time.Second)
+		if next == 0 || untilRotation < next {
+			next = untilRotation
+		}
+	}
+
+	return next, nil
+}
+
+// CleanupRemovedGeneratedContents deletes all Secrets with generated contents of a DeceptionPolicy
+// that are no longer referenced by any of its traps.
+func CleanupRemovedGeneratedContents(c client.Client, ctx context.Context, deceptionPolicy *v1alpha1.DeceptionPolicy) error {
+	log := log.FromContext(ctx)
+
+	secrets := &corev1.SecretList{}
+	if err := c.List(ctx, secrets, client.InNamespace(constants.KoneyNamespace), client.MatchingLabels{
+		constants.LabelKeyDeceptionPolicyRef: deceptionPolicy.Name,
+		constants.LabelKeyGeneratedContent:   "true",
+	}); err != nil {
+		return err
+	}
+
+	secretNamesFromTraps := []string{}
+	for _, trap := range deceptionPolicy.Spec.Traps {
+		source := trap.FilesystemHoneytoken.FileContentFrom
+		if source == nil || (source.Generate == nil && source.CanaryToken == nil) {
+			continue
+		}
+
+		secretNamesFromTraps = append(secretNamesFromTraps, GenerateContentSecretName(deceptionPolicy.Name, trap.FilesystemHoneytoken.FilePath))
+	}
+
+	for i := range secrets.Items {
+		if utils.Contains(secretNamesFromTraps, secrets.Items[i].Name) {
+			continue
+		}
+
+		log.Info("Deleting Secret with generated content for removed trap", "Secret", secrets.Items[i].Name)
+		if err := c.Delete(ctx, &secrets.Items[i]); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+
+	return nil
+}
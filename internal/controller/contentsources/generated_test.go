@@ -0,0 +1,160 @@
+// Copyright (c) 2025 Dynatrace LLC
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package contentsources
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/dynatrace-oss/koney/api/v1alpha1"
+	"github.com/dynatrace-oss/koney/internal/controller/constants"
+)
+
+var _ = Describe("Generated content sources", func() {
+	ctx := context.Background()
+
+	const filePath = "/run/secrets/token"
+
+	var fakeClient client.Client
+	var policy *v1alpha1.DeceptionPolicy
+
+	getSecret := func() *corev1.Secret {
+		secret := &corev1.Secret{}
+		Expect(fakeClient.Get(ctx, client.ObjectKey{
+			Namespace: constants.KoneyNamespace,
+			Name:      GenerateContentSecretName(policy.Name, filePath),
+		}, secret)).To(Succeed())
+		return secret
+	}
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(v1alpha1.AddToScheme(scheme)).To(Succeed())
+
+		fakeClient = fake.NewClientBuilder().WithScheme(scheme).Build()
+		policy = &v1alpha1.DeceptionPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-deception-policy", UID: "test-uid"},
+			Spec: v1alpha1.DeceptionPolicySpec{
+				Rotate: &metav1.Duration{Duration: 168 * time.Hour},
+				Traps: []v1alpha1.Trap{{
+					FilesystemHoneytoken: v1alpha1.FilesystemHoneytoken{
+						FilePath:        filePath,
+						FileContentFrom: &v1alpha1.FileContentSource{Generate: &v1alpha1.GeneratedContent{Prefix: "AKIA"}},
+					},
+					DecoyDeployment: v1alpha1.DecoyDeployment{Strategy: "containerExec"},
+					MatchResources: v1alpha1.MatchResources{Any: []v1alpha1.ResourceFilter{{ResourceDescription: v1alpha1.ResourceDescription{
+						Namespaces: []string{"default"},
+					}}}},
+				}},
+			},
+		}
+	})
+
+	It("should generate the content once and keep it in a Secret", func() {
+		resolvedPolicy, err := ResolveTrapContents(fakeClient, ctx, policy, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		content := resolvedPolicy.Spec.Traps[0].FilesystemHoneytoken.FileContent
+		Expect(content).To(HavePrefix("AKIA"))
+		Expect(content).To(HaveLen(len("AKIA") + v1alpha1.DefaultGeneratedContentLength))
+		Expect(resolvedPolicy.Spec.Traps[0].FilesystemHoneytoken.FileContentFrom).To(BeNil())
+
+		secret := getSecret()
+		Expect(string(secret.Data[generatedContentKey])).To(Equal(content))
+		Expect(secret.Labels).To(HaveKeyWithValue(constants.LabelKeyDeceptionPolicyRef, policy.Name))
+		Expect(secret.OwnerReferences).To(HaveLen(1))
+
+		resolvedAgain, err := ResolveTrapContents(fakeClient, ctx, policy, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resolvedAgain.Spec.Traps[0].FilesystemHoneytoken.FileContent).To(Equal(content))
+	})
+
+	It("should regenerate the content if the generator settings change", func() {
+		source := policy.Spec.Traps[0].FilesystemHoneytoken.FileContentFrom.Generate
+		content, err := resolveGeneratedContent(fakeClient, ctx, policy, filePath, source, time.Now())
+		Expect(err).NotTo(HaveOccurred())
+
+		source.Length = 64
+		newContent, err := resolveGeneratedContent(fakeClient, ctx, policy, filePath, source, time.Now())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(newContent).NotTo(Equal(content))
+		Expect(newContent).To(HaveLen(len("AKIA") + 64))
+	})
+
+	It("should rotate the content only when it is due", func() {
+		source := policy.Spec.Traps[0].FilesystemHoneytoken.FileContentFrom.Generate
+		generatedAt := time.Now().Add(-100 * time.Hour)
+		content, err := resolveGeneratedContent(fakeClient, ctx, policy, filePath, source, generatedAt)
+		Expect(err).NotTo(HaveOccurred())
+
+		sameContent, err := resolveGeneratedContent(fakeClient, ctx, policy, filePath, source, generatedAt.Add(100*time.Hour))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(sameContent).To(Equal(content))
+
+		untilRotation, err := TimeUntilNextRotation(fakeClient, ctx, policy)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(untilRotation).To(BeNumerically("~", 68*time.Hour, time.Minute))
+
+		rotatedContent, err := resolveGeneratedContent(fakeClient, ctx, policy, filePath, source, generatedAt.Add(200*time.Hour))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rotatedContent).NotTo(Equal(content))
+		Expect(getSecret().Annotations).To(HaveKeyWithValue(constants.AnnotationKeyGeneratedAt, generatedAt.Add(200*time.Hour).UTC().Format(time.RFC3339)))
+	})
+
+	It("should never rotate the content if the policy does not set an interval", func() {
+		policy.Spec.Rotate = nil
+		source := policy.Spec.Traps[0].FilesystemHoneytoken.FileContentFrom.Generate
+		content, err := resolveGeneratedContent(fakeClient, ctx, policy, filePath, source, time.Now().Add(-1000*time.Hour))
+		Expect(err).NotTo(HaveOccurred())
+
+		sameContent, err := resolveGeneratedContent(fakeClient, ctx, policy, filePath, source, time.Now())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(sameContent).To(Equal(content))
+
+		untilRotation, err := TimeUntilNextRotation(fakeClient, ctx, policy)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(untilRotation).To(BeZero())
+	})
+
+	It("should delete the Secrets of removed traps", func() {
+		_, err := ResolveTrapContents(fakeClient, ctx, policy, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		policy.Spec.Traps = nil
+		Expect(CleanupRemovedGeneratedContents(fakeClient, ctx, policy)).To(Succeed())
+
+		secrets := &corev1.SecretList{}
+		Expect(fakeClient.List(ctx, secrets, client.InNamespace(constants.KoneyNamespace))).To(Succeed())
+		Expect(secrets.Items).To(BeEmpty())
+	})
+
+	It("should only generate alphanumeric characters", func() {
+		content, err := generateRandomContent(&v1alpha1.GeneratedContent{Length: 256})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(strings.Trim(content, generatedContentAlphabet)).To(BeEmpty())
+	})
+})
@@ -0,0 +1,95 @@
+// Copyright (c) 2025 Dynatrace LLC
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package contentsources
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/dynatrace-oss/koney/api/v1alpha1"
+	"github.com/dynatrace-oss/koney/internal/controller/constants"
+)
+
+// resolveSecretKeyRef returns the value of a key of a Secret in the Koney namespace.
+// If the Secret or the key does not exist (yet), ErrContentNotReady is returned.
+func resolveSecretKeyRef(r client.Reader, ctx context.Context, selector *v1alpha1.ContentKeySelector) (string, error) {
+	secret := corev1.Secret{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: constants.KoneyNamespace, Name: selector.Name}, &secret); err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			return "", fmt.Errorf("%w: secret '%s' does not exist", ErrContentNotReady, selector.Name)
+		}
+
+		return "", err
+	}
+
+	content, ok := secret.Data[selector.Key]
+	if !ok {
+		return "", fmt.Errorf("%w: secret '%s' has no key '%s'", ErrContentNotReady, selector.Name, selector.Key)
+	}
+
+	return string(content), nil
+}
+
+// resolveConfigMapKeyRef returns the value of a key of a ConfigMap in the Koney namespace, which may hold text or binary data.
+// If the ConfigMap or the key does not exist (yet), ErrContentNotReady is returned.
+func resolveConfigMapKeyRef(r client.Reader, ctx context.Context, selector *v1alpha1.ContentKeySelector) (string, error) {
+	configMap := corev1.ConfigMap{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: constants.KoneyNamespace, Name: selector.Name}, &configMap); err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			return "", fmt.Errorf("%w: config map '%s' does not exist", ErrContentNotReady, selector.Name)
+		}
+
+		return "", err
+	}
+
+	if content, ok := configMap.Data[selector.Key]; ok {
+		return content, nil
+	} else if content, ok := configMap.BinaryData[selector.Key]; ok {
+		return string(content), nil
+	}
+
+	return "", fmt.Errorf("%w: config map '%s' has no key '%s'", ErrContentNotReady, selector.Name, selector.Key)
+}
+
+// ReferencesObject returns true if a trap of the DeceptionPolicy sources its content from the given Secret or ConfigMap.
+func ReferencesObject(deceptionPolicy *v1alpha1.DeceptionPolicy, obj client.Object) bool {
+	if obj.GetNamespace() != constants.KoneyNamespace {
+		return false
+	}
+
+	for _, trap := range deceptionPolicy.Spec.Traps {
+		source := trap.FilesystemHoneytoken.FileContentFrom
+		if source == nil {
+			continue
+		}
+
+		switch obj.(type) {
+		case *corev1.Secret:
+			if source.SecretKeyRef != nil && source.SecretKeyRef.Name == obj.GetName() {
+				return true
+			}
+		case *corev1.ConfigMap:
+			if source.ConfigMapKeyRef != nil && source.ConfigMapKeyRef.Name == obj.GetName() {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,116 @@
+// Copyright (c) 2025 Dynatrace LLC
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package contentsources
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/dynatrace-oss/koney/api/v1alpha1"
+	"github.com/dynatrace-oss/koney/internal/controller/constants"
+)
+
+var _ = Describe("Secret and ConfigMap content sources", func() {
+	ctx := context.Background()
+
+	var fakeClient client.Client
+
+	newPolicy := func(source *v1alpha1.FileContentSource) *v1alpha1.DeceptionPolicy {
+		return &v1alpha1.DeceptionPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-deception-policy"},
+			Spec: v1alpha1.DeceptionPolicySpec{
+				Traps: []v1alpha1.Trap{{
+					FilesystemHoneytoken: v1alpha1.FilesystemHoneytoken{FilePath: "/run/secrets/token", FileContentFrom: source},
+					DecoyDeployment:      v1alpha1.DecoyDeployment{Strategy: "containerExec"},
+					MatchResources: v1alpha1.MatchResources{Any: []v1alpha1.ResourceFilter{{ResourceDescription: v1alpha1.ResourceDescription{
+						Namespaces: []string{"default"},
+					}}}},
+				}},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(v1alpha1.AddToScheme(scheme)).To(Succeed())
+
+		fakeClient = fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Namespace: constants.KoneyNamespace, Name: "decoys"},
+					Data:       map[string][]byte{"token": []byte("secret-token")},
+				},
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Namespace: constants.KoneyNamespace, Name: "decoys"},
+					Data:       map[string]string{"config": "config-token"},
+					BinaryData: map[string][]byte{"binary": []byte("binary-token")},
+				},
+			).
+			Build()
+	})
+
+	It("should resolve the content from a Secret", func() {
+		policy := newPolicy(&v1alpha1.FileContentSource{SecretKeyRef: &v1alpha1.ContentKeySelector{Name: "decoys", Key: "token"}})
+
+		resolvedPolicy, err := ResolveTrapContents(fakeClient, ctx, policy, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resolvedPolicy.Spec.Traps[0].FilesystemHoneytoken.FileContent).To(Equal("secret-token"))
+		Expect(resolvedPolicy.Spec.Traps[0].FilesystemHoneytoken.FileContentFrom).To(BeNil())
+
+		// The original policy must still reference the Secret, not its content
+		Expect(policy.Spec.Traps[0].FilesystemHoneytoken.FileContent).To(BeEmpty())
+	})
+
+	It("should resolve the content from text and binary data of a ConfigMap", func() {
+		content, err := resolveConfigMapKeyRef(fakeClient, ctx, &v1alpha1.ContentKeySelector{Name: "decoys", Key: "config"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(content).To(Equal("config-token"))
+
+		content, err = resolveConfigMapKeyRef(fakeClient, ctx, &v1alpha1.ContentKeySelector{Name: "decoys", Key: "binary"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(content).To(Equal("binary-token"))
+	})
+
+	It("should report missing objects and keys as not ready", func() {
+		_, err := resolveSecretKeyRef(fakeClient, ctx, &v1alpha1.ContentKeySelector{Name: "missing", Key: "token"})
+		Expect(errors.Is(err, ErrContentNotReady)).To(BeTrue())
+
+		_, err = resolveSecretKeyRef(fakeClient, ctx, &v1alpha1.ContentKeySelector{Name: "decoys", Key: "missing"})
+		Expect(errors.Is(err, ErrContentNotReady)).To(BeTrue())
+
+		_, err = resolveConfigMapKeyRef(fakeClient, ctx, &v1alpha1.ContentKeySelector{Name: "decoys", Key: "missing"})
+		Expect(errors.Is(err, ErrContentNotReady)).To(BeTrue())
+	})
+
+	It("should tell which policies reference a Secret or ConfigMap", func() {
+		policy := newPolicy(&v1alpha1.FileContentSource{SecretKeyRef: &v1alpha1.ContentKeySelector{Name: "decoys", Key: "token"}})
+
+		Expect(ReferencesObject(policy, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: constants.KoneyNamespace, Name: "decoys"}})).To(BeTrue())
+		Expect(ReferencesObject(policy, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "decoys"}})).To(BeFalse())
+		Expect(ReferencesObject(policy, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: constants.KoneyNamespace, Name: "decoys"}})).To(BeFalse())
+	})
+})
@@ -19,14 +19,22 @@ import (
 	"context"
 	"errors"
 	"fmt"
-
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	ciliumiov1alpha1 "github.com/cilium/tetragon/pkg/k8s/apis/cilium.io/v1alpha1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
-	"k8s.io/client-go/util/retry"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -37,7 +45,15 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/dynatrace-oss/koney/api/v1alpha1"
+	"github.com/dynatrace-oss/koney/internal/controller/annotations"
+	"github.com/dynatrace-oss/koney/internal/controller/audit"
 	"github.com/dynatrace-oss/koney/internal/controller/constants"
+	"github.com/dynatrace-oss/koney/internal/controller/contentsources"
+	"github.com/dynatrace-oss/koney/internal/controller/presets"
+	trapsapi "github.com/dynatrace-oss/koney/internal/controller/traps/api"
+	"github.com/dynatrace-oss/koney/internal/controller/traps/plugintrap"
+	"github.com/dynatrace-oss/koney/internal/controller/utils"
+	"github.com/dynatrace-oss/koney/pkg/tokenprovider"
 )
 
 // DeceptionPolicyReconciler reconciles a DeceptionPolicy object
@@ -46,18 +62,75 @@ type DeceptionPolicyReconciler struct {
 	Scheme    *runtime.Scheme
 	Clientset kubernetes.Clientset
 	Config    rest.Config
+
+	// MaxAnnotationSize is the maximum size (in bytes) of the changes annotation on resources.
+	// If the annotation would grow larger, older changes are spilled into a companion ConfigMap.
+	MaxAnnotationSize int
+
+	// MaxConcurrentDeployments is the maximum number of resources (e.g., pods) that decoys are deployed to at the same time.
+	// Deploying decoys with the containerExec strategy takes several round trips to each pod, so this speeds up large clusters.
+	MaxConcurrentDeployments int
+
+	// RetryInterval is how soon reconciliation is retried if resources are not ready for traps yet,
+	// unless a DeceptionPolicy sets its own interval. If zero, ShortStatusCheckInterval is used.
+	RetryInterval time.Duration
+
+	// SyncInterval is how often traps are checked again after they were deployed successfully,
+	// unless a DeceptionPolicy sets its own interval. If zero, traps are only checked when something changes.
+	SyncInterval time.Duration
+
+	// VerifyDecoys checks once per sync interval if deployed decoys are still in place,
+	// and redeploys decoys that were removed or changed. Changed decoys raise a TrapTampered alert.
+	VerifyDecoys bool
+
+	// FalcoNamespace is the namespace where Falco is running.
+	// Captors with the falco strategy are rendered into a ConfigMap in this namespace.
+	FalcoNamespace string
+
+	// AlertWebhookHost is the host (a DNS name, or an IPv4 or IPv6 address) of the alert forwarder
+	// that captors send their alerts to. If empty, the default Service name of the alert forwarder is used.
+	AlertWebhookHost string
+
+	// Plugins discovers the out-of-tree plugins that implement plugin traps.
+	Plugins *plugintrap.Registry
+
+	// TokenProviders mint the contents of honeytokens that are canary tokens of external providers.
+	TokenProviders tokenprovider.Providers
+
+	// Recorder emits events about the deployment of traps on the DeceptionPolicy.
+	Recorder record.EventRecorder
+
+	// Audit records the mutations that Koney performs. It must be the same log that the client was wrapped with
+	// (see audit.WrapClient), so that its entries are written to the audit ConfigMap after every reconciliation.
+	Audit *audit.Log
+
+	// managedLabelsMutex guards managedLabelsDone, which is set once all resources modified by Koney are labeled.
+	managedLabelsMutex sync.Mutex
+	managedLabelsDone  bool
+
+	// decoyVerificationsMutex guards decoyVerifications, which remembers when the decoys of each DeceptionPolicy were last verified.
+	decoyVerificationsMutex sync.Mutex
+	decoyVerifications      map[types.UID]time.Time
+
+	// settings are the operator-wide settings that were loaded from the KoneyConfig during the last reconciliation.
+	settings atomic.Pointer[operatorSettings]
 }
 
 // +kubebuilder:rbac:groups=research.dynatrace.com,resources=deceptionpolicies,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=research.dynatrace.com,resources=deceptionpolicies/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=research.dynatrace.com,resources=deceptionpolicies/finalizers,verbs=update
-// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch;update
+// +kubebuilder:rbac:groups=research.dynatrace.com,resources=koneyconfigs,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch;update;patch
 // +kubebuilder:rbac:groups=core,resources=pods/status,verbs=get
 // +kubebuilder:rbac:groups=core,resources=pods/exec,verbs=create
 // +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch;update;create;delete
-// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch;update;patch;create;delete
+// +kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=deployments/status,verbs=get
 // +kubebuilder:rbac:groups=cilium.io,resources=tracingpolicies,verbs=get;list;watch;update;patch;create;delete
+// +kubebuilder:rbac:groups=external-secrets.io,resources=externalsecrets,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -65,6 +138,15 @@ func (r *DeceptionPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 	log := log.FromContext(ctx)
 	log.Info("Reconciling DeceptionPolicy ...", "DeceptionPolicy", req.NamespacedName)
 
+	// Mutations of the cluster are attributed to the DeceptionPolicy in the audit log,
+	// which is written to the audit ConfigMap once the reconciliation is done
+	ctx = audit.WithPolicy(ctx, req.Name)
+	defer func() {
+		if err := r.Audit.Flush(ctx); err != nil {
+			log.Error(err, "Audit log cannot be written to its ConfigMap", "ConfigMap", constants.AuditConfigMapName)
+		}
+	}()
+
 	// Fetch the DeceptionPolicy instance
 	var deceptionPolicy v1alpha1.DeceptionPolicy
 	if err := r.Get(ctx, req.NamespacedName, &deceptionPolicy); err != nil {
@@ -77,6 +159,18 @@ func (r *DeceptionPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		return ctrl.Result{}, err
 	}
 
+	// Operator-wide settings can be changed in the KoneyConfig at any time, so they are read on every reconciliation
+	settings, err := r.loadSettings(ctx)
+	if err != nil {
+		log.Error(err, "KoneyConfig cannot be fetched - stopping reconciliation", "DeceptionPolicy", req.NamespacedName)
+		return ctrl.Result{}, err
+	}
+	// Resources modified by older versions of Koney must be labeled before traps can be cleaned up
+	if err := r.labelManagedResourcesOnce(ctx); err != nil {
+		log.Error(err, "Managed resources cannot be labeled - stopping reconciliation", "DeceptionPolicy", req.NamespacedName)
+		return ctrl.Result{}, err
+	}
+
 	// Do not reconcile if the DeceptionPolicy is marked for deletion
 	// Run the finalizers to clean-up the deployed traps instead
 	markedForDeletion, err := r.runFinalizerIfMarkedForDeletion(ctx, req, &deceptionPolicy)
@@ -139,6 +233,17 @@ func (r *DeceptionPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		Message:            "",
 	}
 
+	policyConflictCondition := v1alpha1.DeceptionPolicyCondition{
+		Type:               PolicyConflictType,
+		Status:             metav1.ConditionUnknown,
+		LastTransitionTime: metav1.Now(),
+		Reason:             PolicyConflictReason_Pending,
+		Message:            "",
+	}
+
+	// Namespaces that were skipped are only known after the decoys were reconciled, until then the last ones are kept
+	skippedNamespaces := slices.Clone(deceptionPolicy.Status.SkippedNamespaces)
+
 	defer func() {
 		// Eventually, update status conditions
 		err := r.updateStatusConditions(ctx, req, &deceptionPolicy, []v1alpha1.DeceptionPolicyCondition{
@@ -146,24 +251,59 @@ func (r *DeceptionPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 			policyValidCondition,
 			decoysDeployedCondition,
 			captorsDeployedCondition,
-		})
+			policyConflictCondition,
+		}, skippedNamespaces)
 		if err != nil {
 			log.Error(err, "Status conditions cannot be set", "DeceptionPolicy", req.NamespacedName)
 			reconcileErr = errors.Join(reconcileErr, err)
 		}
 	}()
 
+	// Traps that use a preset are expanded into regular traps first
+	expandedPolicy := presets.ExpandPresets(&deceptionPolicy)
+
+	// If some traps that source their content from elsewhere were removed, remove the related sources
+	if err := errors.Join(
+		contentsources.CleanupRemovedExternalSecrets(r.Client, ctx, expandedPolicy),
+		contentsources.CleanupRemovedGeneratedContents(r.Client, ctx, expandedPolicy),
+	); err != nil {
+		log.Error(err, "Clean-up of content sources that were removed failed", "DeceptionPolicy", req.NamespacedName)
+		reconcileErr = errors.Join(reconcileErr, err)
+		return ctrl.Result{}, reconcileErr
+	}
+
+	// Resolve the contents of traps that are sourced from elsewhere (e.g., from external secret stores),
+	// from now on, we only work with the resolved copy of the DeceptionPolicy
+	resolvedPolicy, err := contentsources.ResolveTrapContents(r.Client, ctx, expandedPolicy, r.TokenProviders)
+	if err != nil {
+		decoysDeployedCondition.Status = metav1.ConditionFalse
+		decoysDeployedCondition.Reason = DecoysDeployedReason_ContentUnavailable
+		decoysDeployedCondition.Message = DecoysDeployedMessage_ContentUnavailable
+
+		if errors.Is(err, contentsources.ErrContentNotReady) {
+			log.Info("Trap contents are not available yet - will retry soon", "DeceptionPolicy", req.NamespacedName, "reason", err.Error())
+			return ctrl.Result{RequeueAfter: r.retryInterval(&deceptionPolicy)}, reconcileErr
+		}
+
+		log.Error(err, "Trap contents cannot be resolved", "DeceptionPolicy", req.NamespacedName)
+		reconcileErr = errors.Join(reconcileErr, err)
+		return ctrl.Result{}, reconcileErr
+	}
+
+	// Traps without a captor strategy are monitored with the default strategy of the KoneyConfig
+	applyDefaultCaptorStrategy(resolvedPolicy, settings.DefaultCaptorStrategy)
+
 	// If some traps were removed from the DeceptionPolicy, remove the related deployed decoys and captors
-	if err := r.cleanupRemovedTraps(ctx, &deceptionPolicy); err != nil {
+	if err := r.cleanupRemovedTraps(ctx, resolvedPolicy); err != nil {
 		log.Error(err, "Clean-up of traps that were removed failed", "DeceptionPolicy", req.NamespacedName)
 		reconcileErr = errors.Join(reconcileErr, err)
 		return ctrl.Result{}, reconcileErr
 	}
 
-	validTraps := r.filterValidTraps(ctx, &deceptionPolicy)
-	numTraps := len(deceptionPolicy.Spec.Traps)
+	validTraps := r.filterValidTraps(ctx, resolvedPolicy)
+	numTraps := len(resolvedPolicy.Spec.Traps)
 	numTrapsValid := len(validTraps)
-	numTrapsInvalid := len(deceptionPolicy.Spec.Traps) - len(validTraps)
+	numTrapsInvalid := len(resolvedPolicy.Spec.Traps) - len(validTraps)
 
 	if numTraps > 0 {
 		policyValidCondition.Message = fmt.Sprintf("%d/%d traps are valid", len(validTraps), numTraps)
@@ -178,18 +318,39 @@ func (r *DeceptionPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 
 	// Check if strict validation is enabled and we possibly need to stop the reconciliation
 	if numTrapsInvalid > 0 {
-		if *deceptionPolicy.Spec.StrictValidation {
+		if *resolvedPolicy.Spec.StrictValidation {
 			log.Info(fmt.Sprintf("DeceptionPolicy has %d invalid traps (out of %d) and strictValidation is enabled - stopping reconciliation", numTrapsInvalid, numTraps), "DeceptionPolicy", req.NamespacedName)
 			return ctrl.Result{}, reconcileErr
-		} else if !*deceptionPolicy.Spec.StrictValidation && numTrapsValid > 0 {
+		} else if !*resolvedPolicy.Spec.StrictValidation && numTrapsValid > 0 {
 			log.Info(fmt.Sprintf("DeceptionPolicy has %d invalid traps, which we ignore - continue with %d valid traps", numTrapsInvalid, numTrapsValid), "DeceptionPolicy", req.NamespacedName)
 		}
 	}
 
-	decoyResult := r.reconcileDecoys(ctx, &deceptionPolicy, validTraps)
+	// If traps conflict with traps of other policies, only the policy that takes precedence deploys them
+	conflicts, err := findPolicyConflicts(r, ctx, resolvedPolicy, validTraps, settings.ExcludedNamespaces)
+	if err != nil {
+		log.Error(err, "Conflicts with other DeceptionPolicies cannot be checked", "DeceptionPolicy", req.NamespacedName)
+		reconcileErr = errors.Join(reconcileErr, err)
+		return ctrl.Result{}, reconcileErr
+	}
+
+	if len(conflicts) > 0 {
+		policyConflictCondition.Status = metav1.ConditionTrue
+		policyConflictCondition.Reason = PolicyConflictReason_Conflict
+		policyConflictCondition.Message = describePolicyConflicts(conflicts)
+		r.reportPolicyConflicts(ctx, &deceptionPolicy, conflicts)
+		validTraps = filterYieldedTraps(validTraps, conflicts)
+	} else {
+		policyConflictCondition.Status = metav1.ConditionFalse
+		policyConflictCondition.Reason = PolicyConflictReason_None
+	}
+
+	decoyResult := r.reconcileDecoys(ctx, resolvedPolicy, validTraps)
 	translateReconcileResultToStatusCondition(&decoyResult, &decoysDeployedCondition, DecoyDeployedStatusConditions)
+	r.recordOutcomeEvents(&deceptionPolicy, decoyResult.Outcomes)
+	skippedNamespaces = decoyResult.SkippedNamespaces
 
-	captorResult := r.reconcileCaptors(ctx, &deceptionPolicy, validTraps)
+	captorResult := r.reconcileCaptors(ctx, resolvedPolicy, validTraps)
 	translateReconcileResultToStatusCondition(&captorResult, &captorsDeployedCondition, CaptorDeployedStatusConditions)
 
 	// We might encounter resources that are not ready yet, so we should retry later
@@ -203,11 +364,67 @@ func (r *DeceptionPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 	} else if shouldRequeue {
 		// If we encountered resources that are not yet ready for traps, check status again shortly
 		log.Info("Reconciliation successful, but some resources are not ready yet - will retry soon", "DeceptionPolicy", req.NamespacedName)
-		return ctrl.Result{RequeueAfter: constants.ShortStatusCheckInterval}, nil
+		return ctrl.Result{RequeueAfter: r.retryInterval(&deceptionPolicy)}, nil
 	}
 
+	// Check the traps again periodically, e.g., to redeploy decoys that were removed in the meantime
 	log.Info("Reconciliation successful", "DeceptionPolicy", req.NamespacedName)
-	return ctrl.Result{}, reconcileErr
+	return ctrl.Result{RequeueAfter: r.requeueInterval(ctx, &deceptionPolicy)}, reconcileErr
+}
+
+// retryInterval returns how soon reconciliation is retried if resources are not ready for traps yet.
+func (r *DeceptionPolicyReconciler) retryInterval(deceptionPolicy *v1alpha1.DeceptionPolicy) time.Duration {
+	defaultInterval := r.RetryInterval
+	if defaultInterval <= 0 {
+		defaultInterval = constants.ShortStatusCheckInterval
+	}
+	return deceptionPolicy.Spec.GetRetryInterval(defaultInterval)
+}
+
+// shouldVerifyDecoys returns true if the decoys of the DeceptionPolicy are due to be verified,
+// i.e., if they were not verified within the last sync interval. Calling it marks the decoys as verified.
+func (r *DeceptionPolicyReconciler) shouldVerifyDecoys(deceptionPolicy *v1alpha1.DeceptionPolicy) bool {
+	interval := r.syncInterval(deceptionPolicy)
+	if !r.VerifyDecoys || interval <= 0 {
+		return false
+	}
+
+	r.decoyVerificationsMutex.Lock()
+	defer r.decoyVerificationsMutex.Unlock()
+
+	now := time.Now()
+	if lastVerification, ok := r.decoyVerifications[deceptionPolicy.UID]; ok && now.Sub(lastVerification) < interval {
+		return false
+	}
+
+	if r.decoyVerifications == nil {
+		r.decoyVerifications = map[types.UID]time.Time{}
+	}
+	r.decoyVerifications[deceptionPolicy.UID] = now
+	return true
+}
+
+// syncInterval returns how often traps are checked again after they were deployed successfully (zero disables this).
+func (r *DeceptionPolicyReconciler) syncInterval(deceptionPolicy *v1alpha1.DeceptionPolicy) time.Duration {
+	return deceptionPolicy.Spec.GetSyncInterval(r.SyncInterval)
+}
+
+// requeueInterval returns when traps are checked again after they were deployed successfully,
+// which is after the sync interval, or earlier if generated contents are due for rotation before.
+func (r *DeceptionPolicyReconciler) requeueInterval(ctx context.Context, deceptionPolicy *v1alpha1.DeceptionPolicy) time.Duration {
+	log := log.FromContext(ctx)
+
+	interval := r.syncInterval(deceptionPolicy)
+	untilRotation, err := contentsources.TimeUntilNextRotation(r.Client, ctx, deceptionPolicy)
+	if err != nil {
+		log.Error(err, "unable to determine the next rotation of generated contents", "DeceptionPolicy", deceptionPolicy.Name)
+		return interval
+	}
+
+	if untilRotation > 0 && (interval <= 0 || untilRotation < interval) {
+		return untilRotation
+	}
+	return interval
 }
 
 func (r *DeceptionPolicyReconciler) runFinalizerIfMarkedForDeletion(ctx context.Context, req ctrl.Request, deceptionPolicy *v1alpha1.DeceptionPolicy) (bool, error) {
@@ -223,16 +440,13 @@ func (r *DeceptionPolicyReconciler) runFinalizerIfMarkedForDeletion(ctx context.
 			}
 
 			// Remove the finalizer after the clean-up was successful
-			err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
-				if err := r.Get(ctx, req.NamespacedName, deceptionPolicy); err != nil {
-					return err
-				}
-				if dirty := controllerutil.RemoveFinalizer(deceptionPolicy, constants.FinalizerName); !dirty {
-					return nil // Already removed
-				}
-				// TODO: Can we use patch instead of update to avoid conflicts?
-				return r.Update(ctx, deceptionPolicy)
-			})
+			err := r.Get(ctx, req.NamespacedName, deceptionPolicy)
+			if err == nil {
+				err = utils.PatchResource(r.Client, ctx, deceptionPolicy, func() error {
+					controllerutil.RemoveFinalizer(deceptionPolicy, constants.FinalizerName)
+					return nil
+				})
+			}
 			if err != nil {
 				return markedForDeletion, err
 			}
@@ -246,16 +460,13 @@ func (r *DeceptionPolicyReconciler) putFinalizer(ctx context.Context, req ctrl.R
 	missingFinalizer := !controllerutil.ContainsFinalizer(deceptionPolicy, constants.FinalizerName)
 	if missingFinalizer {
 		// Add the finalizer if it's missing
-		err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
-			if err := r.Get(ctx, req.NamespacedName, deceptionPolicy); err != nil {
-				return err
-			}
-			if dirty := controllerutil.AddFinalizer(deceptionPolicy, constants.FinalizerName); !dirty {
-				return nil // Already added
-			}
-			// TODO: Can we use patch instead of update to avoid conflicts?
-			return r.Update(ctx, deceptionPolicy)
-		})
+		err := r.Get(ctx, req.NamespacedName, deceptionPolicy)
+		if err == nil {
+			err = utils.PatchResource(r.Client, ctx, deceptionPolicy, func() error {
+				controllerutil.AddFinalizer(deceptionPolicy, constants.FinalizerName)
+				return nil
+			})
+		}
 		if err != nil {
 			return missingFinalizer, err
 		}
@@ -298,6 +509,17 @@ func translateReconcileResultToStatusCondition(result *TrapReconcileResult, cond
 			condition.Reason = fields.Reasons.PartialSuccess
 		}
 
+		// name the objects where the deployment failed, so users don't have to dig through the logs
+		if failed := result.FailedOutcomes(); len(failed) > 0 {
+			condition.Message += ", failed for " + describeOutcomeObjects(failed, maxObjectsInStatusMessage)
+		}
+
+		// containers without a shell will never work with containerExec, so we suggest a strategy that does
+		if unsupported := result.UnsupportedOutcomes(); len(unsupported) > 0 {
+			condition.Message += ", unsupported by containerExec for " + describeOutcomeObjects(unsupported, maxObjectsInStatusMessage) +
+				" (containers without a shell, use the volumeMount or initContainer strategy instead)"
+		}
+
 		// respect overrides
 		if result.OverrideStatusConditionReason != "" {
 			condition.Reason = result.OverrideStatusConditionReason
@@ -308,20 +530,104 @@ func translateReconcileResultToStatusCondition(result *TrapReconcileResult, cond
 	}
 }
 
+// describeOutcomeObjects lists the objects of the outcomes (e.g., "Pod ns/name"), but at most maxObjects of them.
+func describeOutcomeObjects(outcomes []trapsapi.ObjectOutcome, maxObjects int) string {
+	var descriptions []string
+	for i, outcome := range outcomes {
+		if i == maxObjects {
+			descriptions = append(descriptions, fmt.Sprintf("and %d more", len(outcomes)-maxObjects))
+			break
+		}
+		descriptions = append(descriptions, fmt.Sprintf("%s %s/%s", outcome.Object.Kind, outcome.Object.Namespace, outcome.Object.Name))
+	}
+	return strings.Join(descriptions, ", ")
+}
+
+// recordOutcomeEvents emits a warning event on the DeceptionPolicy for every object where the deployment failed,
+// or where the decoy cannot be deployed to some containers with the chosen strategy.
+func (r *DeceptionPolicyReconciler) recordOutcomeEvents(deceptionPolicy *v1alpha1.DeceptionPolicy, outcomes []trapsapi.ObjectOutcome) {
+	if r.Recorder == nil {
+		return
+	}
+
+	for _, outcome := range outcomes {
+		if outcome.Error != nil {
+			r.Recorder.Eventf(deceptionPolicy, corev1.EventTypeWarning, EventReason_DecoyDeploymentFailed,
+				"Unable to deploy decoy to %s %s/%s: %v", outcome.Object.Kind, outcome.Object.Namespace, outcome.Object.Name, outcome.Error)
+		}
+		if len(outcome.Unsupported) > 0 {
+			r.Recorder.Eventf(deceptionPolicy, corev1.EventTypeWarning, EventReason_DecoyUnsupported,
+				"Unable to deploy decoy to containers %s of %s %s/%s: no shell for the containerExec strategy, use the volumeMount or initContainer strategy instead",
+				strings.Join(outcome.Unsupported, ", "), outcome.Object.Kind, outcome.Object.Namespace, outcome.Object.Name)
+		}
+	}
+}
+
+// labelManagedResourcesOnce labels all resources that were modified by an older version of Koney.
+// This only lists all pods and deployments once, until it succeeds for the first time.
+func (r *DeceptionPolicyReconciler) labelManagedResourcesOnce(ctx context.Context) error {
+	r.managedLabelsMutex.Lock()
+	defer r.managedLabelsMutex.Unlock()
+
+	if r.managedLabelsDone {
+		return nil
+	}
+
+	if err := annotations.LabelManagedResources(r.Client, ctx); err != nil {
+		return err
+	}
+
+	r.managedLabelsDone = true
+	return nil
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *DeceptionPolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	r.Clientset = *kubernetes.NewForConfigOrDie(mgr.GetConfig())
 	r.Config = *mgr.GetConfig()
+	if r.Recorder == nil {
+		r.Recorder = mgr.GetEventRecorderFor("koney")
+	}
 
 	watchHandler := handler.EnqueueRequestsFromMapFunc(
 		func(ctx context.Context, obj client.Object) []reconcile.Request {
 			return HandleWatchEvent(r, ctx, obj)
 		})
 
-	return ctrl.NewControllerManagedBy(mgr).
+	contentSourceHandler := handler.EnqueueRequestsFromMapFunc(
+		func(ctx context.Context, obj client.Object) []reconcile.Request {
+			return HandleContentSourceWatchEvent(r, ctx, obj)
+		})
+
+	builder := ctrl.NewControllerManagedBy(mgr).
 		For(&v1alpha1.DeceptionPolicy{}).
 		Watches(&corev1.Pod{}, watchHandler).
 		Watches(&appsv1.Deployment{}, watchHandler).
+		Watches(&corev1.Secret{}, contentSourceHandler).
+		Watches(&corev1.ConfigMap{}, contentSourceHandler)
+
+	// Watch tracing policies to restore captors that were changed or deleted by someone else,
+	// but only if Tetragon is installed, because the controller would not start otherwise
+	tracingPolicyKind := ciliumiov1alpha1.SchemeGroupVersion.WithKind(ciliumiov1alpha1.TPKindDefinition).GroupKind()
+	if _, err := mgr.GetRESTMapper().RESTMapping(tracingPolicyKind); err == nil {
+		builder = builder.Watches(&ciliumiov1alpha1.TracingPolicy{}, handler.EnqueueRequestsFromMapFunc(HandleTracingPolicyWatchEvent))
+	} else {
+		mgr.GetLogger().Info("Tetragon is not installed - captors are not restored when their tracing policies change", "reason", err.Error())
+	}
+
+	// Watch the KoneyConfig to apply changes of the operator-wide settings to all policies,
+	// but only if its CRD is installed, because the controller would not start otherwise
+	koneyConfigKind := v1alpha1.GroupVersion.WithKind("KoneyConfig").GroupKind()
+	if _, err := mgr.GetRESTMapper().RESTMapping(koneyConfigKind); err == nil {
+		builder = builder.Watches(&v1alpha1.KoneyConfig{}, handler.EnqueueRequestsFromMapFunc(
+			func(ctx context.Context, obj client.Object) []reconcile.Request {
+				return HandleKoneyConfigWatchEvent(r, ctx, obj)
+			}))
+	} else {
+		mgr.GetLogger().Info("KoneyConfig CRD is not installed - operator-wide settings are only read from flags", "reason", err.Error())
+	}
+
+	return builder.
 		WithEventFilter(predicate.Funcs{
 			GenericFunc: func(e event.GenericEvent) bool { return false },
 			CreateFunc:  func(e event.CreateEvent) bool { return true },
@@ -332,11 +638,20 @@ func (r *DeceptionPolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
 					// For pods and deployments, consider generation changes and label changes
 					// - Generation changes means spec changes, e.g., new container images that need new decoys
 					// - Label changes could affect what is matched by the deception policies
-					return predicate.Or(predicate.GenerationChangedPredicate{}, predicate.LabelChangedPredicate{}).Update(e)
+					return predicate.GenerationChangedPredicate{}.Update(e) || foreignLabelsChanged(e)
 				case *v1alpha1.DeceptionPolicy:
 					// For deception policies, only consider generation changes
 					// (skips update on status, metadata, labels, etc.)
 					return predicate.GenerationChangedPredicate{}.Update(e)
+				case *ciliumiov1alpha1.TracingPolicy:
+					// For tracing policies, consider spec changes and label changes (which could detach them from the deception policy)
+					return predicate.GenerationChangedPredicate{}.Update(e) || foreignLabelsChanged(e)
+				case *corev1.Secret, *corev1.ConfigMap:
+					// Secrets and config maps have no generation, any change could affect the content of traps
+					return true
+				case *v1alpha1.KoneyConfig:
+					// For the KoneyConfig, only consider spec changes
+					return predicate.GenerationChangedPredicate{}.Update(e)
 				}
 				return false
 			},
@@ -349,9 +664,33 @@ func (r *DeceptionPolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
 					return false
 				case *v1alpha1.DeceptionPolicy:
 					return true
+				case *ciliumiov1alpha1.TracingPolicy:
+					// Tracing policies that were deleted by someone else are created again
+					return true
+				case *corev1.Secret, *corev1.ConfigMap:
+					// Traps whose content source was deleted are reported as unavailable
+					return true
+				case *v1alpha1.KoneyConfig:
+					// Without a KoneyConfig, the settings fall back to the command-line flags
+					return true
 				}
 				return false
 			},
 		}).
 		Complete(r)
 }
+
+// foreignLabelsChanged returns true if the labels of an object changed, ignoring the label that Koney itself places on resources.
+// Otherwise, labeling a resource as managed by Koney would trigger yet another reconciliation.
+func foreignLabelsChanged(e event.UpdateEvent) bool {
+	if e.ObjectOld == nil || e.ObjectNew == nil {
+		return false
+	}
+
+	oldLabels := maps.Clone(e.ObjectOld.GetLabels())
+	newLabels := maps.Clone(e.ObjectNew.GetLabels())
+	delete(oldLabels, constants.LabelKeyManaged)
+	delete(newLabels, constants.LabelKeyManaged)
+
+	return !maps.Equal(oldLabels, newLabels)
+}
@@ -17,17 +17,22 @@ package controller
 
 import (
 	"context"
+	goerrors "errors"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/dynatrace-oss/koney/api/v1alpha1"
 	"github.com/dynatrace-oss/koney/internal/controller/constants"
+	trapsapi "github.com/dynatrace-oss/koney/internal/controller/traps/api"
 )
 
 var _ = Describe("DeceptionPolicy Controller", func() {
@@ -65,8 +70,9 @@ var _ = Describe("DeceptionPolicy Controller", func() {
 
 		It("should successfully reconcile the resource", func() {
 			controllerReconciler := &DeceptionPolicyReconciler{
-				Client: k8sClient,
-				Scheme: k8sClient.Scheme(),
+				Client:         k8sClient,
+				Scheme:         k8sClient.Scheme(),
+				FalcoNamespace: constants.DefaultFalcoNamespace,
 			}
 
 			By("Reconciling the DeceptionPolicy for the first time")
@@ -89,4 +95,86 @@ var _ = Describe("DeceptionPolicy Controller", func() {
 		})
 	})
 
+	Context("When reporting per-object outcomes", func() {
+		failedOutcome := trapsapi.ObjectOutcome{
+			Object: corev1.ObjectReference{Kind: "Pod", Namespace: "default", Name: "broken"},
+			Error:  goerrors.New("exec failed"),
+		}
+		deployedOutcome := trapsapi.ObjectOutcome{
+			Object:     corev1.ObjectReference{Kind: "Pod", Namespace: "default", Name: "fine"},
+			Containers: []string{"nginx"},
+		}
+
+		It("should name failed objects in the status condition message", func() {
+			result := TrapReconcileResult{NumTraps: 1, NumFailures: 1, Outcomes: []trapsapi.ObjectOutcome{deployedOutcome, failedOutcome}}
+			condition := v1alpha1.DeceptionPolicyCondition{}
+			translateReconcileResultToStatusCondition(&result, &condition, DecoyDeployedStatusConditions)
+
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Reason).To(Equal(DecoysDeployedReason_GenericError))
+			Expect(condition.Message).To(Equal("0/1 decoys deployed (0 skipped), failed for Pod default/broken"))
+		})
+
+		It("should keep the status condition message if no objects failed", func() {
+			result := TrapReconcileResult{NumTraps: 1, NumSuccesses: 1, Outcomes: []trapsapi.ObjectOutcome{deployedOutcome}}
+			condition := v1alpha1.DeceptionPolicyCondition{}
+			translateReconcileResultToStatusCondition(&result, &condition, DecoyDeployedStatusConditions)
+
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(condition.Message).To(Equal("1/1 decoys deployed (0 skipped)"))
+		})
+
+		It("should suggest another strategy for containers without a shell", func() {
+			unsupportedOutcome := trapsapi.ObjectOutcome{
+				Object:        corev1.ObjectReference{Kind: "Pod", Namespace: "default", Name: "distroless"},
+				Unsupported:   []string{"app"},
+				SkippedReason: trapsapi.SkippedReasonUnsupported,
+			}
+			result := TrapReconcileResult{NumTraps: 1, NumSuccesses: 1, Outcomes: []trapsapi.ObjectOutcome{unsupportedOutcome}}
+			condition := v1alpha1.DeceptionPolicyCondition{}
+			translateReconcileResultToStatusCondition(&result, &condition, DecoyDeployedStatusConditions)
+
+			Expect(condition.Message).To(Equal("1/1 decoys deployed (0 skipped), unsupported by containerExec for Pod default/distroless " +
+				"(containers without a shell, use the volumeMount or initContainer strategy instead)"))
+
+			recorder := record.NewFakeRecorder(10)
+			controllerReconciler := &DeceptionPolicyReconciler{Recorder: recorder}
+			controllerReconciler.recordOutcomeEvents(&v1alpha1.DeceptionPolicy{}, result.Outcomes)
+			Expect(<-recorder.Events).To(HavePrefix("Warning DecoyUnsupported Unable to deploy decoy to containers app of Pod default/distroless"))
+		})
+
+		It("should limit the number of objects named in the message", func() {
+			outcomes := []trapsapi.ObjectOutcome{failedOutcome, failedOutcome, failedOutcome, failedOutcome, failedOutcome}
+			Expect(describeOutcomeObjects(outcomes, 3)).To(Equal("Pod default/broken, Pod default/broken, Pod default/broken, and 2 more"))
+		})
+
+		It("should emit warning events only for failed objects", func() {
+			recorder := record.NewFakeRecorder(10)
+			controllerReconciler := &DeceptionPolicyReconciler{Recorder: recorder}
+			controllerReconciler.recordOutcomeEvents(&v1alpha1.DeceptionPolicy{}, []trapsapi.ObjectOutcome{deployedOutcome, failedOutcome})
+
+			Expect(recorder.Events).To(HaveLen(1))
+			Expect(<-recorder.Events).To(Equal("Warning DecoyDeploymentFailed Unable to deploy decoy to Pod default/broken: exec failed"))
+		})
+	})
+
+	Context("When filtering label changes of watched resources", func() {
+		newUpdateEvent := func(oldLabels, newLabels map[string]string) event.UpdateEvent {
+			return event.UpdateEvent{
+				ObjectOld: &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Labels: oldLabels}},
+				ObjectNew: &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Labels: newLabels}},
+			}
+		}
+
+		It("should ignore the label that Koney places on managed resources", func() {
+			e := newUpdateEvent(map[string]string{"app": "nginx"}, map[string]string{"app": "nginx", constants.LabelKeyManaged: "true"})
+			Expect(foreignLabelsChanged(e)).To(BeFalse())
+		})
+
+		It("should consider changes of other labels", func() {
+			e := newUpdateEvent(map[string]string{"app": "nginx"}, map[string]string{"app": "httpd", constants.LabelKeyManaged: "true"})
+			Expect(foreignLabelsChanged(e)).To(BeTrue())
+		})
+	})
+
 })
@@ -19,12 +19,18 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 
+	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	"github.com/dynatrace-oss/koney/api/v1alpha1"
+	"github.com/dynatrace-oss/koney/internal/controller/audit"
 	trapsapi "github.com/dynatrace-oss/koney/internal/controller/traps/api"
+	"github.com/dynatrace-oss/koney/internal/controller/traps/envtoken"
 	"github.com/dynatrace-oss/koney/internal/controller/traps/filesystoken"
+	"github.com/dynatrace-oss/koney/internal/controller/traps/nethoneypot"
+	"github.com/dynatrace-oss/koney/internal/controller/traps/plugintrap"
 )
 
 // TrapReconcileResult unifies the deployment result after reconciling either decoys or captors.
@@ -41,6 +47,10 @@ type TrapReconcileResult struct {
 	OverrideStatusConditionReason string
 	// OverrideStatusConditionMessage is a message that should be set when updating the status, instead of the default one.
 	OverrideStatusConditionMessage string
+	// Outcomes lists the per-object outcomes of all traps (only reported for decoys).
+	Outcomes []trapsapi.ObjectOutcome
+	// SkippedNamespaces lists the excluded namespaces (sorted) where traps matched, but were not deployed to (only reported for decoys).
+	SkippedNamespaces []string
 	// Errors contains all the errors that happened during the reconciliation.
 	Errors error
 }
@@ -56,33 +66,105 @@ func (r TrapReconcileResult) NumSkipped() int {
 	return r.NumTraps - r.NumSuccesses - r.NumFailures
 }
 
+// FailedOutcomes returns the outcomes of objects where the deployment failed.
+func (r TrapReconcileResult) FailedOutcomes() []trapsapi.ObjectOutcome {
+	var failed []trapsapi.ObjectOutcome
+	for _, outcome := range r.Outcomes {
+		if outcome.Error != nil {
+			failed = append(failed, outcome)
+		}
+	}
+	return failed
+}
+
+// UnsupportedOutcomes returns the outcomes of objects with containers that the decoy cannot be deployed to with the chosen strategy.
+func (r TrapReconcileResult) UnsupportedOutcomes() []trapsapi.ObjectOutcome {
+	var unsupported []trapsapi.ObjectOutcome
+	for _, outcome := range r.Outcomes {
+		if len(outcome.Unsupported) > 0 {
+			unsupported = append(unsupported, outcome)
+		}
+	}
+	return unsupported
+}
+
 func (r *DeceptionPolicyReconciler) buildFilesystemTokenReconciler(deceptionPolicy *v1alpha1.DeceptionPolicy) filesystoken.FilesystemHoneytokenReconciler {
-	return filesystoken.FilesystemHoneytokenReconciler{Client: r.Client, Clientset: r.Clientset, Config: r.Config, DeceptionPolicy: deceptionPolicy}
+	settings := r.currentSettings()
+	return filesystoken.FilesystemHoneytokenReconciler{Client: r.Client, Clientset: r.Clientset, Config: r.Config, MaxAnnotationSize: r.MaxAnnotationSize, MaxConcurrentDeployments: settings.MaxConcurrentDeployments, FalcoNamespace: r.FalcoNamespace, AlertWebhookHost: settings.AlertWebhookHost, ExcludedNamespaces: settings.ExcludedNamespaces, DeceptionPolicy: deceptionPolicy}
+}
+
+func (r *DeceptionPolicyReconciler) buildEnvVarTokenReconciler(deceptionPolicy *v1alpha1.DeceptionPolicy) envtoken.EnvVarHoneytokenReconciler {
+	settings := r.currentSettings()
+	return envtoken.EnvVarHoneytokenReconciler{Client: r.Client, Scheme: r.Scheme, MaxAnnotationSize: r.MaxAnnotationSize, AlertWebhookHost: settings.AlertWebhookHost, ExcludedNamespaces: settings.ExcludedNamespaces, DeceptionPolicy: deceptionPolicy}
+}
+
+func (r *DeceptionPolicyReconciler) buildNetworkHoneypotReconciler(deceptionPolicy *v1alpha1.DeceptionPolicy) nethoneypot.NetworkHoneypotReconciler {
+	settings := r.currentSettings()
+	return nethoneypot.NetworkHoneypotReconciler{Client: r.Client, Scheme: r.Scheme, AlertWebhookHost: settings.AlertWebhookHost, ExcludedNamespaces: settings.ExcludedNamespaces, DeceptionPolicy: deceptionPolicy}
+}
+
+func (r *DeceptionPolicyReconciler) buildPluginTrapReconciler(deceptionPolicy *v1alpha1.DeceptionPolicy) plugintrap.PluginTrapReconciler {
+	settings := r.currentSettings()
+	return plugintrap.PluginTrapReconciler{Client: r.Client, Plugins: r.Plugins, AlertWebhookHost: settings.AlertWebhookHost, ExcludedNamespaces: settings.ExcludedNamespaces, DeceptionPolicy: deceptionPolicy}
 }
 
 func (r *DeceptionPolicyReconciler) reconcileDecoys(ctx context.Context, deceptionPolicy *v1alpha1.DeceptionPolicy, reconcileTraps []v1alpha1.Trap) TrapReconcileResult {
 	log := log.FromContext(ctx)
 
+	// Traps that were interrupted (e.g., by a restart of the controller) are deployed first
+	progress := r.loadDecoyProgress(deceptionPolicy)
+
+	// Decoys that were deployed before are only verified once per sync interval
+	verifyDecoys := r.shouldVerifyDecoys(deceptionPolicy)
+
 	results := make([]trapsapi.DecoyDeploymentResult, 0, len(reconcileTraps))
-	for _, trap := range reconcileTraps {
+	for _, trap := range progress.orderTraps(deceptionPolicy, reconcileTraps) {
+		index := trapIndex(deceptionPolicy, trap)
+		progress.start(ctx, index)
+
+		// Mutations of the cluster are attributed to the trap in the audit log
+		ctx := audit.WithTrap(ctx, trap)
+
+		var result trapsapi.DecoyDeploymentResult
 		switch trap.TrapType() {
 		case v1alpha1.FilesystemHoneytokenTrap:
 			rd := r.buildFilesystemTokenReconciler(deceptionPolicy)
-			result := rd.DeployDecoy(ctx, deceptionPolicy, trap)
-			results = append(results, result)
+			rd.VerifyDecoys = verifyDecoys
+			result = rd.DeployDecoy(ctx, deceptionPolicy, trap)
 			if result.GetErrors() != nil {
 				log.Error(result.GetErrors(), "FilesystemHoneytoken decoy deployment had errors", "trap", trap.FilesystemHoneytoken)
 			}
+		case v1alpha1.NetworkHoneypotTrap:
+			rd := r.buildNetworkHoneypotReconciler(deceptionPolicy)
+			result = rd.DeployDecoy(ctx, deceptionPolicy, trap)
+			if result.GetErrors() != nil {
+				log.Error(result.GetErrors(), "NetworkHoneypot decoy deployment had errors", "trap", trap.NetworkHoneypot)
+			}
+		case v1alpha1.EnvVarHoneytokenTrap:
+			rd := r.buildEnvVarTokenReconciler(deceptionPolicy)
+			result = rd.DeployDecoy(ctx, deceptionPolicy, trap)
+			if result.GetErrors() != nil {
+				log.Error(result.GetErrors(), "EnvVarHoneytoken decoy deployment had errors", "trap", trap.EnvVarHoneytoken.Name)
+			}
+		case v1alpha1.PluginTrapType:
+			rd := r.buildPluginTrapReconciler(deceptionPolicy)
+			result = rd.DeployDecoy(ctx, deceptionPolicy, trap)
+			if result.GetErrors() != nil {
+				log.Error(result.GetErrors(), "Plugin decoy deployment had errors", "plugin", trap.Plugin.Name)
+			}
 		case v1alpha1.HttpEndpointTrap:
 			log.Error(nil, "HttpEndpointTrap not implemented yet", "trap", trap.HttpEndpoint)
-			results = append(results, trapsapi.DecoyDeploymentResult{Trap: &trap, Errors: errors.New("HttpEndpointTrap not implemented yet")})
+			result = trapsapi.DecoyDeploymentResult{Trap: &trap, Errors: errors.New("HttpEndpointTrap not implemented yet")}
 		case v1alpha1.HttpPayloadTrap:
 			log.Error(nil, "HttpPayloadTrap not implemented yet")
-			results = append(results, trapsapi.DecoyDeploymentResult{Trap: &trap, Errors: errors.New("HttpPayloadTrap not implemented yet")})
+			result = trapsapi.DecoyDeploymentResult{Trap: &trap, Errors: errors.New("HttpPayloadTrap not implemented yet")}
 		default:
 			log.Error(nil, fmt.Sprintf("trap type %T unknown", trap))
-			results = append(results, trapsapi.DecoyDeploymentResult{Trap: &trap, Errors: errors.New("trap type unknown")})
+			result = trapsapi.DecoyDeploymentResult{Trap: &trap, Errors: errors.New("trap type unknown")}
 		}
+
+		results = append(results, result)
+		progress.finish(ctx, index, result.Outcomes)
 	}
 
 	// Summarize the decoy deployment results
@@ -98,8 +180,18 @@ func (r *DeceptionPolicyReconciler) reconcileDecoys(ctx context.Context, decepti
 			log.Info("Encountered resources that are not yet ready for decoys - will retry soon", "trap", result.GetTrap())
 			reconcileResult.ShouldRequeue = true
 		}
+
+		if trap := result.GetTrap(); trap != nil {
+			recordDecoyOutcomes(string(trap.TrapType()), result.Outcomes)
+			r.reportTamperedDecoys(ctx, deceptionPolicy, *trap, result.Outcomes)
+		}
+		reconcileResult.Outcomes = append(reconcileResult.Outcomes, result.Outcomes...)
+		reconcileResult.SkippedNamespaces = append(reconcileResult.SkippedNamespaces, result.SkippedNamespaces...)
 	}
 
+	slices.Sort(reconcileResult.SkippedNamespaces)
+	reconcileResult.SkippedNamespaces = slices.Compact(reconcileResult.SkippedNamespaces)
+
 	return reconcileResult
 }
 
@@ -108,6 +200,8 @@ func (r *DeceptionPolicyReconciler) reconcileCaptors(ctx context.Context, decept
 
 	results := make([]trapsapi.CaptorDeploymentResult, 0, len(reconcileTraps))
 	for _, trap := range reconcileTraps {
+		ctx := audit.WithTrap(ctx, trap)
+
 		switch trap.TrapType() {
 		case v1alpha1.FilesystemHoneytokenTrap:
 			rd := r.buildFilesystemTokenReconciler(deceptionPolicy)
@@ -116,6 +210,27 @@ func (r *DeceptionPolicyReconciler) reconcileCaptors(ctx context.Context, decept
 			if result.GetErrors() != nil {
 				log.Error(result.GetErrors(), "FilesystemHoneytoken captor deployment had errors", "trap", trap.FilesystemHoneytoken)
 			}
+		case v1alpha1.NetworkHoneypotTrap:
+			rd := r.buildNetworkHoneypotReconciler(deceptionPolicy)
+			result := rd.DeployCaptor(ctx, deceptionPolicy, trap)
+			results = append(results, result)
+			if result.GetErrors() != nil {
+				log.Error(result.GetErrors(), "NetworkHoneypot captor deployment had errors", "trap", trap.NetworkHoneypot)
+			}
+		case v1alpha1.EnvVarHoneytokenTrap:
+			rd := r.buildEnvVarTokenReconciler(deceptionPolicy)
+			result := rd.DeployCaptor(ctx, deceptionPolicy, trap)
+			results = append(results, result)
+			if result.GetErrors() != nil {
+				log.Error(result.GetErrors(), "EnvVarHoneytoken captor deployment had errors", "trap", trap.EnvVarHoneytoken.Name)
+			}
+		case v1alpha1.PluginTrapType:
+			rd := r.buildPluginTrapReconciler(deceptionPolicy)
+			result := rd.DeployCaptor(ctx, deceptionPolicy, trap)
+			results = append(results, result)
+			if result.GetErrors() != nil {
+				log.Error(result.GetErrors(), "Plugin captor deployment had errors", "plugin", trap.Plugin.Name)
+			}
 		case v1alpha1.HttpEndpointTrap:
 			log.Error(nil, "HttpEndpointTrap not implemented yet", "trap", trap.HttpEndpoint)
 			results = append(results, trapsapi.CaptorDeploymentResult{Trap: &trap, Errors: errors.New("HttpEndpointTrap not implemented yet")})
@@ -141,6 +256,10 @@ func (r *DeceptionPolicyReconciler) reconcileCaptors(ctx context.Context, decept
 			reconcileResult.OverrideStatusConditionReason = CaptorsDeployedReason_MissingTetragon
 			reconcileResult.OverrideStatusConditionMessage = CaptorsDeployedMessage_MissingTetragon
 		}
+		if result.Restored && r.Recorder != nil {
+			r.Recorder.Eventf(deceptionPolicy, corev1.EventTypeWarning, EventReason_CaptorRestored,
+				"Captor of %s trap was changed or removed by someone else and has been restored", result.GetTrap().TrapType())
+		}
 		if result.ImpliesRetry() {
 			log.Info("Encountered resources that are not yet ready for captors - will retry soon", "trap", result.GetTrap())
 			reconcileResult.ShouldRequeue = true
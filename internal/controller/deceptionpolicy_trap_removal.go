@@ -53,7 +53,20 @@ func (r *DeceptionPolicyReconciler) cleanupDeceptionPolicy(ctx context.Context,
 		}
 	}
 
-	return nil
+	// Falco rules are not owned by the DeceptionPolicy, since they share one ConfigMap with other policies
+	if err := filesystoken.RemoveFalcoRules(r.Client, ctx, r.FalcoNamespace, deceptionPolicy.Name); err != nil {
+		return err
+	}
+
+	// Network honeypots are standalone resources without annotations, remove all of them
+	rd := r.buildNetworkHoneypotReconciler(deceptionPolicy)
+	if err := rd.RemoveDecoys(ctx, deceptionPolicy, nil); err != nil {
+		return err
+	}
+
+	// Plugins keep track of their traps themselves, let them remove all of them
+	rp := r.buildPluginTrapReconciler(deceptionPolicy)
+	return rp.RemoveTraps(ctx, deceptionPolicy, nil)
 }
 
 // cleanupTrap cleans up a trap from a pod
@@ -65,6 +78,12 @@ func (r *DeceptionPolicyReconciler) cleanupTrap(ctx context.Context, deceptionPo
 			return err
 		}
 
+	case v1alpha1.EnvVarHoneytokenTrap:
+		rd := r.buildEnvVarTokenReconciler(deceptionPolicy)
+		if err := rd.RemoveDecoy(ctx, deceptionPolicy.Name, trapAnnotation, resource); err != nil {
+			return err
+		}
+
 	case v1alpha1.HttpEndpointTrap:
 		// TODO: Implement.
 		return nil
@@ -85,11 +104,29 @@ func (r *DeceptionPolicyReconciler) cleanupRemovedTraps(ctx context.Context, dec
 		return err
 	}
 
+	// Remove the Falco rules if no trap is monitored with Falco anymore
+	// (rules of individual traps are dropped when the remaining captors are deployed)
+	if err := filesystoken.PruneFalcoRules(r.Client, ctx, r.FalcoNamespace, deceptionPolicy); err != nil {
+		return err
+	}
+
 	// Remove the decoys
 	if err := r.cleanupRemovedDecoys(ctx, deceptionPolicy); err != nil {
 		return err
 	}
 
+	// Remove the network honeypots
+	rd := r.buildNetworkHoneypotReconciler(deceptionPolicy)
+	if err := rd.RemoveDecoys(ctx, deceptionPolicy, deceptionPolicy.Spec.Traps); err != nil {
+		return err
+	}
+
+	// Remove the traps of plugins
+	rp := r.buildPluginTrapReconciler(deceptionPolicy)
+	if err := rp.RemoveTraps(ctx, deceptionPolicy, deceptionPolicy.Spec.Traps); err != nil {
+		return err
+	}
+
 	return nil
 }
 
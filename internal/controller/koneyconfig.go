@@ -0,0 +1,105 @@
+// Copyright (c) 2025 Dynatrace LLC
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"context"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/dynatrace-oss/koney/api/v1alpha1"
+	"github.com/dynatrace-oss/koney/internal/controller/matching"
+)
+
+// defaultCaptorStrategy is the captor strategy of traps that do not set one, unless the KoneyConfig says otherwise.
+const defaultCaptorStrategy = "tetragon"
+
+// operatorSettings are the operator-wide settings, i.e., the command-line flags overridden by the KoneyConfig.
+type operatorSettings struct {
+	// MaxConcurrentDeployments is the maximum number of resources that decoys are deployed to at the same time.
+	MaxConcurrentDeployments int
+	// AlertWebhookHost is the host of the alert forwarder that captors send alerts to.
+	AlertWebhookHost string
+	// DefaultCaptorStrategy is the captor strategy of traps that do not set one.
+	DefaultCaptorStrategy string
+	// ExcludedNamespaces are namespaces where no traps are deployed (in addition to the namespace of Koney itself).
+	ExcludedNamespaces []string
+}
+
+// flagSettings returns the settings that were configured with command-line flags.
+func (r *DeceptionPolicyReconciler) flagSettings() operatorSettings {
+	return operatorSettings{
+		MaxConcurrentDeployments: r.MaxConcurrentDeployments,
+		AlertWebhookHost:         r.AlertWebhookHost,
+		DefaultCaptorStrategy:    defaultCaptorStrategy,
+		ExcludedNamespaces:       matching.SystemNamespaces,
+	}
+}
+
+// currentSettings returns the settings that were loaded last, or the command-line flags if none were loaded yet.
+func (r *DeceptionPolicyReconciler) currentSettings() operatorSettings {
+	if settings := r.settings.Load(); settings != nil {
+		return *settings
+	}
+	return r.flagSettings()
+}
+
+// loadSettings reads the KoneyConfig and applies it on top of the command-line flags.
+// If there is no KoneyConfig (or the CRD is not installed), only the command-line flags are used.
+func (r *DeceptionPolicyReconciler) loadSettings(ctx context.Context) (operatorSettings, error) {
+	settings := r.flagSettings()
+
+	var koneyConfig v1alpha1.KoneyConfig
+	if err := r.Get(ctx, types.NamespacedName{Name: v1alpha1.KoneyConfigName}, &koneyConfig); err != nil {
+		if !apierrors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+			return r.currentSettings(), err
+		}
+	} else {
+		settings = applyKoneyConfig(settings, koneyConfig.Spec)
+	}
+
+	r.settings.Store(&settings)
+	return settings, nil
+}
+
+// applyKoneyConfig overrides the settings with the values that are set in the KoneyConfig.
+func applyKoneyConfig(settings operatorSettings, spec v1alpha1.KoneyConfigSpec) operatorSettings {
+	if spec.MaxConcurrentDeployments != nil && *spec.MaxConcurrentDeployments > 0 {
+		settings.MaxConcurrentDeployments = int(*spec.MaxConcurrentDeployments)
+	}
+	if spec.AlertWebhookHost != "" {
+		settings.AlertWebhookHost = spec.AlertWebhookHost
+	}
+	if spec.DefaultCaptorStrategy != "" {
+		settings.DefaultCaptorStrategy = spec.DefaultCaptorStrategy
+	}
+	if spec.ExcludedNamespaces != nil {
+		// An empty list is a deliberate choice to allow traps in the system namespaces
+		settings.ExcludedNamespaces = spec.ExcludedNamespaces
+	}
+	return settings
+}
+
+// applyDefaultCaptorStrategy sets the default captor strategy on all traps that do not set one.
+func applyDefaultCaptorStrategy(deceptionPolicy *v1alpha1.DeceptionPolicy, strategy string) {
+	for i := range deceptionPolicy.Spec.Traps {
+		if deceptionPolicy.Spec.Traps[i].CaptorDeployment.Strategy == "" {
+			deceptionPolicy.Spec.Traps[i].CaptorDeployment.Strategy = strategy
+		}
+	}
+}
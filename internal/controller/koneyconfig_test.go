@@ -0,0 +1,111 @@
+// Copyright (c) 2025 Dynatrace LLC
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/dynatrace-oss/koney/api/v1alpha1"
+	"github.com/dynatrace-oss/koney/internal/controller/matching"
+)
+
+var _ = Describe("KoneyConfig", func() {
+	ctx := context.Background()
+
+	newReconciler := func(objects ...client.Object) *DeceptionPolicyReconciler {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(v1alpha1.AddToScheme(scheme)).To(Succeed())
+
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+		return &DeceptionPolicyReconciler{Client: fakeClient, MaxConcurrentDeployments: 16, AlertWebhookHost: "forwarder"}
+	}
+
+	It("should use the command-line flags without a KoneyConfig", func() {
+		r := newReconciler()
+		Expect(r.currentSettings()).To(Equal(operatorSettings{
+			MaxConcurrentDeployments: 16,
+			AlertWebhookHost:         "forwarder",
+			DefaultCaptorStrategy:    "tetragon",
+			ExcludedNamespaces:       matching.SystemNamespaces,
+		}))
+
+		settings, err := r.loadSettings(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(settings).To(Equal(r.flagSettings()))
+	})
+
+	It("should override the command-line flags with the KoneyConfig", func() {
+		r := newReconciler(&v1alpha1.KoneyConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: v1alpha1.KoneyConfigName},
+			Spec: v1alpha1.KoneyConfigSpec{
+				DefaultCaptorStrategy:    "falco",
+				MaxConcurrentDeployments: &[]int32{4}[0],
+				ExcludedNamespaces:       []string{"kube-system"},
+			},
+		})
+
+		settings, err := r.loadSettings(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(settings.DefaultCaptorStrategy).To(Equal("falco"))
+		Expect(settings.MaxConcurrentDeployments).To(Equal(4))
+		Expect(settings.ExcludedNamespaces).To(ConsistOf("kube-system"))
+		Expect(settings.AlertWebhookHost).To(Equal("forwarder"))
+		Expect(r.currentSettings()).To(Equal(settings))
+	})
+
+	It("should allow an empty list of excluded namespaces", func() {
+		r := newReconciler(&v1alpha1.KoneyConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: v1alpha1.KoneyConfigName},
+			Spec:       v1alpha1.KoneyConfigSpec{ExcludedNamespaces: []string{}},
+		})
+
+		settings, err := r.loadSettings(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(settings.ExcludedNamespaces).To(BeEmpty())
+	})
+
+	It("should ignore KoneyConfigs with other names", func() {
+		r := newReconciler(&v1alpha1.KoneyConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "other"},
+			Spec:       v1alpha1.KoneyConfigSpec{DefaultCaptorStrategy: "falco"},
+		})
+
+		settings, err := r.loadSettings(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(settings.DefaultCaptorStrategy).To(Equal("tetragon"))
+		Expect(HandleKoneyConfigWatchEvent(r.Client, ctx, &v1alpha1.KoneyConfig{ObjectMeta: metav1.ObjectMeta{Name: "other"}})).To(BeEmpty())
+	})
+
+	It("should only set the default captor strategy on traps without one", func() {
+		policy := &v1alpha1.DeceptionPolicy{Spec: v1alpha1.DeceptionPolicySpec{Traps: []v1alpha1.Trap{
+			{},
+			{CaptorDeployment: v1alpha1.CaptorDeployment{Strategy: "tetragon"}},
+		}}}
+
+		applyDefaultCaptorStrategy(policy, "falco")
+		Expect(policy.Spec.Traps[0].CaptorDeployment.Strategy).To(Equal("falco"))
+		Expect(policy.Spec.Traps[1].CaptorDeployment.Strategy).To(Equal("tetragon"))
+	})
+})
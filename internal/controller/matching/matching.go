@@ -19,17 +19,25 @@ import (
 	"context"
 	"fmt"
 	"path/filepath"
+	"slices"
+	"strings"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/dynatrace-oss/koney/api/v1alpha1"
+	"github.com/dynatrace-oss/koney/internal/controller/constants"
 	"github.com/dynatrace-oss/koney/internal/controller/utils"
 )
 
+// SystemNamespaces are the namespaces where no traps are deployed by default,
+// because decoys could break the cluster there. The KoneyConfig can override this list.
+var SystemNamespaces = []string{"kube-system", "kube-public", "kube-node-lease", constants.KoneyNamespace}
+
 type MatchingResult struct {
 	// DeployableObjects is a map of objects (pods or deployments) and their containers to which traps can be deployed (running and ready).
 	DeployableObjects map[client.Object][]string
@@ -40,6 +48,10 @@ type MatchingResult struct {
 	// AllDeployableObjectsWereReady indicates if all the objects that we wanted to deploy the trap to were ready, or if some were filtered out.
 	// If no resources were matched in the first place (i.e., AtLeastOneObjectWasMatched = false), this field should be ignored.
 	AllDeployableObjectsWereReady bool
+	// NotReadyObjects lists the objects that were matched, but filtered out entirely because they were not ready.
+	NotReadyObjects []client.Object
+	// SkippedNamespaces lists the excluded namespaces (sorted) where objects matched, but no traps must be deployed.
+	SkippedNamespaces []string
 }
 
 // GetDeployableObjectsWithContainers returns a map of resources (pods or deployments) and their containers to which traps can be deployed.
@@ -47,31 +59,37 @@ type MatchingResult struct {
 // - Only resources (and containers) that match the given MatchResources are returned.
 // - Only resources that have no deletion timestamp set are returned.
 // - If a createdAfter timestamp is given, only resources created after the given timestamp are returned.
+// - Only resources outside of the excluded namespaces (and the namespace of Koney itself) are returned.
 // Additionally, the function filters out resources that are not ready, e.g., pods that are just starting, not ready, or terminating.
 //
-// The deployment strategy determines which resources are returned: pods (if the strategy is containerExec) or deployments (if the strategy is volumeMount).
+// The deployment strategy determines which resources are returned: pods (if the strategy is containerExec) or deployments (if the strategy is volumeMount or initContainer).
 // The function returns a matching result and an error. The matching result reports if at least one object matched the three criteria above,
 // and if all of those objects were also ready. The final set of deployable objects both matches all criteria and is ready.
-func GetDeployableObjectsWithContainers(r client.Reader, ctx context.Context, trap v1alpha1.Trap, createdAfter *metav1.Time) (MatchingResult, error) {
+func GetDeployableObjectsWithContainers(r client.Reader, ctx context.Context, trap v1alpha1.Trap, createdAfter *metav1.Time, excludedNamespaces []string) (MatchingResult, error) {
 	var (
-		matchingObjects map[client.Object][]string
-		filteredObjects map[client.Object][]string
-		allObjectsReady bool
-		err             error
+		matchingObjects   map[client.Object][]string
+		filteredObjects   map[client.Object][]string
+		skippedNamespaces []string
+		allObjectsReady   bool
+		err               error
 	)
 
 	switch trap.DecoyDeployment.Strategy {
 	case "containerExec":
 		matchingObjects, err = getMatchingPodsWithContainers(r, ctx, trap.MatchResources)
 		matchingObjects = filterObjectsWithoutDeletionTimestamp(matchingObjects)
+		matchingObjects = filterObjectsNotManagedByKoney(matchingObjects)
+		matchingObjects, skippedNamespaces = filterObjectsNotInExcludedNamespaces(matchingObjects, excludedNamespaces)
 		if createdAfter != nil {
 			matchingObjects = filterObjectsCreatedAfterTimestamp(matchingObjects, *createdAfter)
 		}
 
 		filteredObjects, allObjectsReady = filterPodsReadyForTraps(matchingObjects)
-	case "volumeMount":
+	case "volumeMount", "initContainer":
 		matchingObjects, err = getMatchingDeploymentsWithContainers(r, ctx, trap.MatchResources)
 		matchingObjects = filterObjectsWithoutDeletionTimestamp(matchingObjects)
+		matchingObjects = filterObjectsNotManagedByKoney(matchingObjects)
+		matchingObjects, skippedNamespaces = filterObjectsNotInExcludedNamespaces(matchingObjects, excludedNamespaces)
 		if createdAfter != nil {
 			matchingObjects = filterObjectsCreatedAfterTimestamp(matchingObjects, *createdAfter)
 		}
@@ -92,131 +110,143 @@ func GetDeployableObjectsWithContainers(r client.Reader, ctx context.Context, tr
 		allObjectsReady = false
 	}
 
+	var notReadyObjects []client.Object
+	for object := range matchingObjects {
+		if _, ok := filteredObjects[object]; !ok {
+			notReadyObjects = append(notReadyObjects, object)
+		}
+	}
+
 	return MatchingResult{
 		DeployableObjects:             filteredObjects,
 		AtLeastOneObjectWasMatched:    len(matchingObjects) > 0,
 		AllDeployableObjectsWereReady: allObjectsReady,
+		NotReadyObjects:               notReadyObjects,
+		SkippedNamespaces:             skippedNamespaces,
 	}, nil
 }
 
+// GetMatchingNamespaces returns the names of the namespaces that match the given MatchResources.
+// If a ResourceFilter only specifies namespaces, these namespaces are returned as they are.
+// Otherwise, the namespaces of the pods that match the ResourceFilter are returned.
+// Excluded namespaces (and the namespace of Koney itself) are never returned, but reported as skipped namespaces (sorted).
+func GetMatchingNamespaces(r client.Reader, ctx context.Context, matchResources v1alpha1.MatchResources, excludedNamespaces []string) ([]string, []string, error) {
+	namespaces := []string{}
+	skippedNamespaces := []string{}
+
+	addNamespace := func(namespace string) {
+		if isExcludedNamespace(namespace, excludedNamespaces) {
+			if !utils.Contains(skippedNamespaces, namespace) {
+				skippedNamespaces = append(skippedNamespaces, namespace)
+			}
+		} else if !utils.Contains(namespaces, namespace) {
+			namespaces = append(namespaces, namespace)
+		}
+	}
+
+	// Pods are only listed (once) if at least one ResourceFilter selects labels
+	var pods []client.Object
+	listedPods := false
+
+	for _, resourceFilter := range matchResources.Any {
+		if !selectsLabels(resourceFilter) {
+			for _, namespace := range resourceFilter.Namespaces {
+				addNamespace(namespace)
+			}
+			continue
+		}
+
+		if !listedPods {
+			if err := listItemsAsObjects(r, ctx, &pods, &corev1.PodList{}); err != nil {
+				return nil, nil, err
+			}
+			listedPods = true
+		}
+
+		for _, object := range pods {
+			if matchesResourceFilter(object, resourceFilter) && object.GetDeletionTimestamp() == nil {
+				addNamespace(object.GetNamespace())
+			}
+		}
+	}
+
+	slices.Sort(skippedNamespaces)
+	return namespaces, skippedNamespaces, nil
+}
+
 func getMatchingPodsWithContainers(r client.Reader, ctx context.Context, matchResources v1alpha1.MatchResources) (map[client.Object][]string, error) {
-	return getMatchingObjectsWithContainers(r, ctx, matchResources, func() client.ObjectList { return &corev1.PodList{} })
+	return getMatchingObjectsWithContainers(r, ctx, matchResources, &corev1.PodList{})
 }
 
 func getMatchingDeploymentsWithContainers(r client.Reader, ctx context.Context, matchResources v1alpha1.MatchResources) (map[client.Object][]string, error) {
-	return getMatchingObjectsWithContainers(r, ctx, matchResources, func() client.ObjectList { return &appsv1.DeploymentList{} })
+	return getMatchingObjectsWithContainers(r, ctx, matchResources, &appsv1.DeploymentList{})
 }
 
 // getMatchingObjectsWithContainers returns a map of objects (pods or deployments) that match the given MatchResources with their containers.
 // Resources are matched using with a logical OR between different ResourceFilters and a logical AND between the namespaces and labels of a ResourceFilter.
-func getMatchingObjectsWithContainers(r client.Reader, ctx context.Context, matchResources v1alpha1.MatchResources, emptyList func() client.ObjectList) (map[client.Object][]string, error) {
+// The objects are listed only once and all ResourceFilters are evaluated in memory. With the client of the manager,
+// the list is served from the informer cache, so the number of ResourceFilters does not affect the load on the API server.
+func getMatchingObjectsWithContainers(r client.Reader, ctx context.Context, matchResources v1alpha1.MatchResources, list client.ObjectList) (map[client.Object][]string, error) {
 	matchingObjectsWithContainers := map[client.Object][]string{}
 
-	for _, resourceFilter := range matchResources.Any {
-		matchingObjects, err := getMatchingObjectsByNamespaceAndLabels(r, ctx, resourceFilter, emptyList)
-		if err != nil {
-			return nil, err
-		}
+	if len(matchResources.Any) == 0 {
+		return matchingObjectsWithContainers, nil
+	}
+
+	objects := []client.Object{}
+	if err := listItemsAsObjects(r, ctx, &objects, list); err != nil {
+		return nil, err
+	}
+
+	for _, object := range objects {
+		for _, resourceFilter := range matchResources.Any {
+			if !matchesResourceFilter(object, resourceFilter) {
+				continue
+			}
 
-		for _, matchingObject := range matchingObjects {
-			selectedContainers, err := selectContainers(matchingObject, resourceFilter.ContainerSelector)
+			selectedContainers, err := selectContainers(object, resourceFilter.ContainerSelector)
 			if err != nil {
 				return nil, err
 			} else if len(selectedContainers) == 0 {
 				continue // If no containers match the containerSelector, skip the object
-			} else {
-				// If the object is already in the map, append the selected containers to the existing list (avoiding duplicates)
-				objectFromMap := getObjectFromMap(matchingObject.GetName(), matchingObjectsWithContainers)
-				if objectFromMap != nil {
-					containers := matchingObjectsWithContainers[objectFromMap]
-
-					for _, container := range selectedContainers {
-						if !utils.Contains(containers, container) {
-							containers = append(containers, container)
-						}
-					}
-
-					// Add the updated entry to the map
-					matchingObjectsWithContainers[objectFromMap] = containers
-				} else {
-					// Else, create a new entry in the map
-					matchingObjectsWithContainers[matchingObject] = selectedContainers
+			}
+
+			// If the object is already in the map, append the selected containers to the existing list (avoiding duplicates)
+			containers := matchingObjectsWithContainers[object]
+			for _, container := range selectedContainers {
+				if !utils.Contains(containers, container) {
+					containers = append(containers, container)
 				}
 			}
+			matchingObjectsWithContainers[object] = containers
 		}
 	}
 
 	return matchingObjectsWithContainers, nil
 }
 
-// getMatchingObjectsByNamespaceAndLabels returns a list of objects (pods or deployments)
-// that match the given resource filter with a logical AND between the namespaces and labels.
-func getMatchingObjectsByNamespaceAndLabels(r client.Reader, ctx context.Context, resourceFilter v1alpha1.ResourceFilter, makeList func() client.ObjectList) ([]client.Object, error) {
-	matchingObjects := []client.Object{} // The objects that match the MatchResources
-
-	matchingByNamespace := []client.Object{} // The objects that match the namespaces for this ResourceFilter
-	matchingByLabels := []client.Object{}    // The objects that match the labels for this ResourceFilter
-
-	if len(resourceFilter.Namespaces) > 0 {
-		// Get the objects that match one of the namespaces
-		for _, namespace := range resourceFilter.Namespaces {
-			items := []client.Object{}
-			if err := listItemsAsObjects(r, ctx, &items, makeList(), client.InNamespace(namespace)); err != nil {
-				return nil, err
-			}
-
-			for _, object := range items {
-				if !utils.Contains(extractObjectNames(matchingByNamespace), object.GetName()) {
-					matchingByNamespace = append(matchingByNamespace, object)
-				}
-			}
-		}
-	}
+// matchesResourceFilter returns true if the object matches the given resource filter,
+// with a logical AND between the namespaces and labels. A filter without namespaces and labels matches nothing.
+func matchesResourceFilter(object client.Object, resourceFilter v1alpha1.ResourceFilter) bool {
+	hasNamespaces := len(resourceFilter.Namespaces) > 0
+	hasLabels := selectsLabels(resourceFilter)
 
-	if resourceFilter.Selector != nil && len(resourceFilter.Selector.MatchLabels) > 0 {
-		// Get the objects that match the labels
-		items := []client.Object{}
-		if err := listItemsAsObjects(r, ctx, &items, makeList(), client.MatchingLabels(resourceFilter.Selector.MatchLabels)); err != nil {
-			return nil, err
-		} else {
-			for _, object := range items {
-				if !utils.Contains(extractObjectNames(matchingByLabels), object.GetName()) {
-					matchingByLabels = append(matchingByLabels, object)
-				}
-			}
-		}
+	if !hasNamespaces && !hasLabels {
+		return false
 	}
-
-	// If no namespaces are specified, add all the objects that match the labels
-	if len(resourceFilter.Namespaces) == 0 {
-		for _, object := range matchingByLabels {
-			if !utils.Contains(extractObjectNames(matchingObjects), object.GetName()) {
-				matchingObjects = append(matchingObjects, object)
-			}
-		}
+	if hasNamespaces && !utils.Contains(resourceFilter.Namespaces, object.GetNamespace()) {
+		return false
 	}
-
-	// If no labels are specified, add all the objects that match the namespaces
-	if resourceFilter.Selector == nil || len(resourceFilter.Selector.MatchLabels) == 0 {
-		for _, object := range matchingByNamespace {
-			if !utils.Contains(extractObjectNames(matchingObjects), object.GetName()) {
-				matchingObjects = append(matchingObjects, object)
-			}
-		}
+	if hasLabels && !labels.SelectorFromSet(resourceFilter.Selector.MatchLabels).Matches(labels.Set(object.GetLabels())) {
+		return false
 	}
 
-	// If both namespaces and labels are specified, add the objects that match both (logical AND between namespaces and labels)
-	for _, objectByNamespace := range matchingByNamespace {
-		for _, objectByLabels := range matchingByLabels {
-			if objectByNamespace.GetName() == objectByLabels.GetName() {
-				if !utils.Contains(extractObjectNames(matchingObjects), objectByNamespace.GetName()) {
-					matchingObjects = append(matchingObjects, objectByNamespace)
-				}
-			}
-		}
-	}
+	return true
+}
 
-	return matchingObjects, nil
+// selectsLabels returns true if the resource filter selects objects by their labels.
+func selectsLabels(resourceFilter v1alpha1.ResourceFilter) bool {
+	return resourceFilter.Selector != nil && len(resourceFilter.Selector.MatchLabels) > 0
 }
 
 // filterObjectsWithoutDeletionTimestamp only keeps objects that have no deletion timestamp set.
@@ -230,6 +260,39 @@ func filterObjectsWithoutDeletionTimestamp[T any](objects map[client.Object]T) m
 	return filteredObjects
 }
 
+// filterObjectsNotManagedByKoney only keeps objects that were not created by Koney itself (e.g., network honeypots).
+func filterObjectsNotManagedByKoney[T any](objects map[client.Object]T) map[client.Object]T {
+	filteredObjects := map[client.Object]T{}
+	for object, value := range objects {
+		if _, ok := object.GetLabels()[constants.LabelKeyDeceptionPolicyRef]; !ok {
+			filteredObjects[object] = value
+		}
+	}
+	return filteredObjects
+}
+
+// filterObjectsNotInExcludedNamespaces only keeps objects that are not in an excluded namespace.
+// The excluded namespaces where objects were filtered out are returned as well (sorted).
+func filterObjectsNotInExcludedNamespaces[T any](objects map[client.Object]T, excludedNamespaces []string) (map[client.Object]T, []string) {
+	filteredObjects := map[client.Object]T{}
+	skippedNamespaces := []string{}
+	for object, value := range objects {
+		if !isExcludedNamespace(object.GetNamespace(), excludedNamespaces) {
+			filteredObjects[object] = value
+		} else if !utils.Contains(skippedNamespaces, object.GetNamespace()) {
+			skippedNamespaces = append(skippedNamespaces, object.GetNamespace())
+		}
+	}
+	slices.Sort(skippedNamespaces)
+	return filteredObjects, skippedNamespaces
+}
+
+// isExcludedNamespace returns true if no traps must be deployed to the namespace.
+// Traps are never deployed to the namespace of Koney itself, even if it is not excluded explicitly.
+func isExcludedNamespace(namespace string, excludedNamespaces []string) bool {
+	return namespace == constants.KoneyNamespace || utils.Contains(excludedNamespaces, namespace)
+}
+
 // filterObjectsCreatedAfterTimestamp only keeps objects that were created at or after the given timestamp.
 func filterObjectsCreatedAfterTimestamp[T any](objects map[client.Object]T, policyCreatedAt metav1.Time) map[client.Object]T {
 	filteredObjects := map[client.Object]T{}
@@ -315,12 +378,18 @@ func selectContainers(resource client.Object, containerSelector string) ([]strin
 
 	if ContainerSelectorSelectsAll(containerSelector) {
 		for _, container := range containers {
-			selectedContainers = append(selectedContainers, container.Name)
+			if !isSidecarCaptor(container.Name) {
+				selectedContainers = append(selectedContainers, container.Name)
+			}
 		}
 		return selectedContainers, nil
 	}
 
 	for _, container := range containers {
+		if isSidecarCaptor(container.Name) {
+			continue
+		}
+
 		matched, err := filepath.Match(containerSelector, container.Name)
 		if err != nil {
 			return nil, err
@@ -332,6 +401,11 @@ func selectContainers(resource client.Object, containerSelector string) ([]strin
 	return selectedContainers, nil
 }
 
+// isSidecarCaptor checks if a container is a sidecar captor that was injected by Koney.
+func isSidecarCaptor(containerName string) bool {
+	return strings.HasPrefix(containerName, constants.SidecarCaptorNamePrefix)
+}
+
 func listItemsAsObjects(r client.Reader, ctx context.Context, items *[]client.Object, list client.ObjectList, opts ...client.ListOption) error {
 	if err := r.List(ctx, list, opts...); err != nil {
 		return err
@@ -29,6 +29,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 
 	"github.com/dynatrace-oss/koney/api/v1alpha1"
+	"github.com/dynatrace-oss/koney/internal/controller/constants"
 	"github.com/dynatrace-oss/koney/internal/controller/utils"
 )
 
@@ -390,7 +391,7 @@ var _ = Describe("GetDeployableObjectsWithContainers", func() {
 
 			fakeClient = fake.NewClientBuilder().WithLists(&podList).Build()
 
-			matchResult, err := GetDeployableObjectsWithContainers(fakeClient, ctx, testTrapForPods, nil)
+			matchResult, err := GetDeployableObjectsWithContainers(fakeClient, ctx, testTrapForPods, nil, nil)
 			Expect(err).ToNot(HaveOccurred())
 
 			Expect(matchResult.DeployableObjects).To(BeEmpty())
@@ -410,7 +411,7 @@ var _ = Describe("GetDeployableObjectsWithContainers", func() {
 
 			fakeClient = fake.NewClientBuilder().WithLists(&podList).Build()
 
-			matchResult, err := GetDeployableObjectsWithContainers(fakeClient, ctx, testTrapForPods, nil)
+			matchResult, err := GetDeployableObjectsWithContainers(fakeClient, ctx, testTrapForPods, nil, nil)
 			Expect(err).ToNot(HaveOccurred())
 
 			Expect(matchResult.DeployableObjects).To(BeEmpty())
@@ -420,6 +421,26 @@ var _ = Describe("GetDeployableObjectsWithContainers", func() {
 
 	})
 
+	Context("With one matching, and ready pod in an excluded namespace", func() {
+		It("should match no pod", func() {
+			podList := corev1.PodList{
+				Items: []corev1.Pod{
+					podOk_Old_Run_CtrsReady_Ctr1RunAndReady,
+				},
+			}
+
+			fakeClient = fake.NewClientBuilder().WithLists(&podList).Build()
+
+			matchResult, err := GetDeployableObjectsWithContainers(fakeClient, ctx, testTrapForPods, nil, []string{KoneyNamespace})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(matchResult.DeployableObjects).To(BeEmpty())
+			Expect(matchResult.AtLeastOneObjectWasMatched).To(BeFalse())
+			Expect(matchResult.SkippedNamespaces).To(Equal([]string{KoneyNamespace}))
+		})
+
+	})
+
 	Context("With one matching, and ready pod", func() {
 		It("should match the only pod", func() {
 			podList := corev1.PodList{
@@ -430,7 +451,7 @@ var _ = Describe("GetDeployableObjectsWithContainers", func() {
 
 			fakeClient = fake.NewClientBuilder().WithLists(&podList).Build()
 
-			matchResult, err := GetDeployableObjectsWithContainers(fakeClient, ctx, testTrapForPods, nil)
+			matchResult, err := GetDeployableObjectsWithContainers(fakeClient, ctx, testTrapForPods, nil, nil)
 			Expect(err).ToNot(HaveOccurred())
 
 			Expect(matchResult.DeployableObjects).To(HaveLen(1))
@@ -458,7 +479,7 @@ var _ = Describe("GetDeployableObjectsWithContainers", func() {
 			fakeClient = fake.NewClientBuilder().WithLists(&podList).WithInterceptorFuncs(interceptCreationTimestamp(allTestPods)).Build()
 			deceptionPolicyCreatedAt := metav1.Now()
 
-			matchResult, err := GetDeployableObjectsWithContainers(fakeClient, ctx, testTrapForPods, &deceptionPolicyCreatedAt)
+			matchResult, err := GetDeployableObjectsWithContainers(fakeClient, ctx, testTrapForPods, &deceptionPolicyCreatedAt, nil)
 			Expect(err).ToNot(HaveOccurred())
 
 			Expect(matchResult.DeployableObjects).To(HaveLen(1))
@@ -483,7 +504,7 @@ var _ = Describe("GetDeployableObjectsWithContainers", func() {
 			fakeClient = fake.NewClientBuilder().WithLists(&podList).WithInterceptorFuncs(interceptCreationTimestamp(allTestPods)).Build()
 			deceptionPolicyCreatedAt := metav1.NewTime(time.Now().Add(-6 * time.Hour))
 
-			matchResult, err := GetDeployableObjectsWithContainers(fakeClient, ctx, testTrapForPods, &deceptionPolicyCreatedAt)
+			matchResult, err := GetDeployableObjectsWithContainers(fakeClient, ctx, testTrapForPods, &deceptionPolicyCreatedAt, nil)
 			Expect(err).ToNot(HaveOccurred())
 
 			Expect(matchResult.DeployableObjects).To(HaveLen(2))
@@ -519,7 +540,7 @@ var _ = Describe("GetDeployableObjectsWithContainers", func() {
 
 			fakeClient = fake.NewClientBuilder().WithLists(&podList).Build()
 
-			matchResult, err := GetDeployableObjectsWithContainers(fakeClient, ctx, testTrapForPods, nil)
+			matchResult, err := GetDeployableObjectsWithContainers(fakeClient, ctx, testTrapForPods, nil, nil)
 			Expect(err).ToNot(HaveOccurred())
 
 			Expect(matchResult.DeployableObjects).To(HaveLen(1))
@@ -548,7 +569,7 @@ var _ = Describe("GetDeployableObjectsWithContainers", func() {
 
 			fakeClient = fake.NewClientBuilder().WithLists(&podList).Build()
 
-			matchResult, err := GetDeployableObjectsWithContainers(fakeClient, ctx, testTrapForPods, nil)
+			matchResult, err := GetDeployableObjectsWithContainers(fakeClient, ctx, testTrapForPods, nil, nil)
 			Expect(err).ToNot(HaveOccurred())
 
 			Expect(matchResult.DeployableObjects).To(HaveLen(2))
@@ -581,7 +602,7 @@ var _ = Describe("GetDeployableObjectsWithContainers", func() {
 
 			fakeClient = fake.NewClientBuilder().WithLists(&deploymentList).Build()
 
-			matchResult, err := GetDeployableObjectsWithContainers(fakeClient, ctx, testTrapForDeployments, nil)
+			matchResult, err := GetDeployableObjectsWithContainers(fakeClient, ctx, testTrapForDeployments, nil, nil)
 			Expect(err).ToNot(HaveOccurred())
 
 			Expect(matchResult.DeployableObjects).To(BeEmpty())
@@ -601,7 +622,7 @@ var _ = Describe("GetDeployableObjectsWithContainers", func() {
 
 			fakeClient = fake.NewClientBuilder().WithLists(&deploymentList).Build()
 
-			matchResult, err := GetDeployableObjectsWithContainers(fakeClient, ctx, testTrapForDeployments, nil)
+			matchResult, err := GetDeployableObjectsWithContainers(fakeClient, ctx, testTrapForDeployments, nil, nil)
 			Expect(err).ToNot(HaveOccurred())
 
 			Expect(matchResult.DeployableObjects).To(HaveLen(1))
@@ -628,7 +649,7 @@ var _ = Describe("GetDeployableObjectsWithContainers", func() {
 
 			fakeClient = fake.NewClientBuilder().WithLists(&deploymentList).Build()
 
-			matchResult, err := GetDeployableObjectsWithContainers(fakeClient, ctx, testTrapForDeployments, nil)
+			matchResult, err := GetDeployableObjectsWithContainers(fakeClient, ctx, testTrapForDeployments, nil, nil)
 			Expect(err).ToNot(HaveOccurred())
 
 			Expect(matchResult.DeployableObjects).To(HaveLen(1))
@@ -1227,4 +1248,93 @@ var _ = Describe("selectContainers", func() {
 			Expect(selection).To(ConsistOf("bar", "baz"))
 		})
 	})
+
+	Context("With a pod that has a sidecar captor", func() {
+		BeforeEach(func() {
+			pod = corev1.Pod{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Name: "foo"},
+						{Name: "koney-captor-75170fc230cd88f32e475ff4087f81d9"},
+					},
+				},
+			}
+		})
+
+		It("should never select the sidecar captor", func() {
+			for _, containerSelector := range []string{"*", "koney-*", "koney-captor-75170fc230cd88f32e475ff4087f81d9"} {
+				selection, err := selectContainers(&pod, containerSelector)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(selection).NotTo(ContainElement(HavePrefix("koney-captor-")))
+			}
+		})
+	})
+})
+
+var _ = Describe("getMatchingObjectsWithContainers", func() {
+	ctx := context.Background()
+
+	It("should list the objects only once, regardless of the number of filters", func() {
+		pods := []client.Object{
+			&corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "nginx", Namespace: "first", Labels: map[string]string{"app": "nginx"}},
+				Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "nginx"}}},
+			},
+			&corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "nginx", Namespace: "second", Labels: map[string]string{"app": "nginx"}},
+				Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "nginx"}}},
+			},
+			&corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "httpd", Namespace: "third", Labels: map[string]string{"app": "httpd"}},
+				Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "httpd"}}},
+			},
+		}
+
+		numListCalls := 0
+		fakeClient := fake.NewClientBuilder().WithObjects(pods...).WithInterceptorFuncs(interceptor.Funcs{
+			List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+				numListCalls++
+				return c.List(ctx, list, opts...)
+			},
+		}).Build()
+
+		match := v1alpha1.MatchResources{
+			Any: []v1alpha1.ResourceFilter{
+				{ResourceDescription: v1alpha1.ResourceDescription{Namespaces: []string{"first", "second"}}},
+				{ResourceDescription: v1alpha1.ResourceDescription{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "nginx"}}}},
+				{ResourceDescription: v1alpha1.ResourceDescription{Namespaces: []string{"third"}, Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "nginx"}}}},
+			},
+		}
+
+		matchingPodsWithContainers, err := getMatchingPodsWithContainers(fakeClient, ctx, match)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(numListCalls).To(Equal(1))
+
+		// Pods with the same name in different namespaces are different objects
+		Expect(matchingPodsWithContainers).To(HaveLen(2))
+		for pod, containers := range matchingPodsWithContainers {
+			Expect(pod.GetName()).To(Equal("nginx"))
+			Expect(containers).To(Equal([]string{"nginx"}))
+		}
+
+		namespaces, skippedNamespaces, err := GetMatchingNamespaces(fakeClient, ctx, match, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(namespaces).To(ConsistOf("first", "second"))
+		Expect(skippedNamespaces).To(BeEmpty())
+		Expect(numListCalls).To(Equal(2))
+
+		namespaces, skippedNamespaces, err = GetMatchingNamespaces(fakeClient, ctx, match, []string{"second"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(namespaces).To(ConsistOf("first"))
+		Expect(skippedNamespaces).To(Equal([]string{"second"}))
+	})
+})
+
+var _ = Describe("isExcludedNamespace", func() {
+	It("should always exclude the namespace of Koney", func() {
+		Expect(isExcludedNamespace(constants.KoneyNamespace, nil)).To(BeTrue())
+		Expect(isExcludedNamespace("kube-system", nil)).To(BeFalse())
+		Expect(isExcludedNamespace("kube-system", SystemNamespaces)).To(BeTrue())
+		Expect(isExcludedNamespace("default", SystemNamespaces)).To(BeFalse())
+	})
 })
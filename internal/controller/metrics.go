@@ -0,0 +1,72 @@
+// Copyright (c) 2025 Dynatrace LLC
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	trapsapi "github.com/dynatrace-oss/koney/internal/controller/traps/api"
+)
+
+const (
+	outcomeDeployed = "deployed"
+	outcomeSkipped  = "skipped"
+	outcomeFailed   = "failed"
+)
+
+var (
+	// decoyObjectOutcomes counts the outcomes of deploying decoys to individual objects.
+	decoyObjectOutcomes = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "koney_decoy_object_outcomes_total",
+			Help: "Number of decoy deployments to individual objects, by trap type and outcome (deployed, skipped, failed).",
+		},
+		[]string{"trap_type", "outcome"},
+	)
+
+	// decoysTampered counts the decoys that were changed after they were deployed (and were deployed again).
+	decoysTampered = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "koney_decoys_tampered_total",
+			Help: "Number of decoys that were found changed in a container after they were deployed, by trap type.",
+		},
+		[]string{"trap_type"},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(decoyObjectOutcomes, decoysTampered)
+}
+
+// recordDecoyOutcomes adds the outcomes of a decoy deployment to the metrics.
+func recordDecoyOutcomes(trapType string, outcomes []trapsapi.ObjectOutcome) {
+	for _, outcome := range outcomes {
+		decoyObjectOutcomes.WithLabelValues(trapType, outcomeLabel(outcome)).Inc()
+		if len(outcome.Tampered) > 0 {
+			decoysTampered.WithLabelValues(trapType).Add(float64(len(outcome.Tampered)))
+		}
+	}
+}
+
+func outcomeLabel(outcome trapsapi.ObjectOutcome) string {
+	if outcome.Error != nil {
+		return outcomeFailed
+	} else if outcome.SkippedReason != "" {
+		return outcomeSkipped
+	}
+	return outcomeDeployed
+}
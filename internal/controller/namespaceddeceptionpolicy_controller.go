@@ -0,0 +1,288 @@
+// Copyright (c) 2025 Dynatrace LLC
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/dynatrace-oss/koney/api/v1alpha1"
+	"github.com/dynatrace-oss/koney/internal/controller/audit"
+	"github.com/dynatrace-oss/koney/internal/controller/constants"
+	"github.com/dynatrace-oss/koney/internal/controller/utils"
+)
+
+// maxProjectedPolicyNameLength limits the names of DeceptionPolicies that are managed for NamespacedDeceptionPolicies,
+// because the names of DeceptionPolicies are also used as label values, which must not be longer than 63 characters.
+const maxProjectedPolicyNameLength = 63
+
+// NamespacedDeceptionPolicyReconciler reconciles a NamespacedDeceptionPolicy object.
+// Each NamespacedDeceptionPolicy is projected onto a DeceptionPolicy that Koney manages,
+// whose traps only match resources in the namespace of the NamespacedDeceptionPolicy.
+type NamespacedDeceptionPolicyReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+
+	// Audit records the mutations that Koney performs (see DeceptionPolicyReconciler.Audit).
+	Audit *audit.Log
+}
+
+// +kubebuilder:rbac:groups=research.dynatrace.com,resources=namespaceddeceptionpolicies,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=research.dynatrace.com,resources=namespaceddeceptionpolicies/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=research.dynatrace.com,resources=namespaceddeceptionpolicies/finalizers,verbs=update
+
+// Reconcile creates or updates the DeceptionPolicy of a NamespacedDeceptionPolicy,
+// and mirrors the status of that DeceptionPolicy back to the NamespacedDeceptionPolicy.
+func (r *NamespacedDeceptionPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+	log.Info("Reconciling NamespacedDeceptionPolicy ...", "NamespacedDeceptionPolicy", req.NamespacedName)
+
+	// Mutations of the cluster are written to the audit ConfigMap once the reconciliation is done
+	defer func() {
+		if err := r.Audit.Flush(ctx); err != nil {
+			log.Error(err, "Audit log cannot be written to its ConfigMap", "ConfigMap", constants.AuditConfigMapName)
+		}
+	}()
+
+	var namespacedPolicy v1alpha1.NamespacedDeceptionPolicy
+	if err := r.Get(ctx, req.NamespacedName, &namespacedPolicy); err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			log.Info("NamespacedDeceptionPolicy already deleted - stopping reconciliation", "NamespacedDeceptionPolicy", req.NamespacedName)
+			return ctrl.Result{}, nil
+		}
+
+		log.Error(err, "NamespacedDeceptionPolicy cannot be fetched - stopping reconciliation", "NamespacedDeceptionPolicy", req.NamespacedName)
+		return ctrl.Result{}, err
+	}
+
+	// Remove the DeceptionPolicy first, whose own finalizer cleans up the deployed traps
+	if namespacedPolicy.GetDeletionTimestamp() != nil {
+		return r.runFinalizer(ctx, &namespacedPolicy)
+	}
+
+	if !controllerutil.ContainsFinalizer(&namespacedPolicy, constants.FinalizerName) {
+		err := utils.PatchResource(r.Client, ctx, &namespacedPolicy, func() error {
+			controllerutil.AddFinalizer(&namespacedPolicy, constants.FinalizerName)
+			return nil
+		})
+		if err != nil {
+			log.Error(err, "Finalizer cannot be added", "NamespacedDeceptionPolicy", req.NamespacedName)
+			return ctrl.Result{}, err
+		}
+	}
+
+	projectedPolicy, err := ProjectNamespacedDeceptionPolicy(&namespacedPolicy)
+	if err != nil {
+		log.Info("NamespacedDeceptionPolicy reaches beyond its namespace - stopping reconciliation", "NamespacedDeceptionPolicy", req.NamespacedName, "reason", err.Error())
+		return ctrl.Result{}, r.putInvalidCondition(ctx, &namespacedPolicy, PolicyValidReason_NotNamespaced, err.Error())
+	}
+
+	deceptionPolicy := &v1alpha1.DeceptionPolicy{}
+	err = r.Get(ctx, client.ObjectKeyFromObject(projectedPolicy), deceptionPolicy)
+	switch {
+	case apierrors.IsNotFound(err):
+		if err := r.Create(ctx, projectedPolicy, client.FieldOwner(constants.FieldManager)); err != nil {
+			log.Error(err, "DeceptionPolicy cannot be created", "NamespacedDeceptionPolicy", req.NamespacedName, "DeceptionPolicy", projectedPolicy.Name)
+			return ctrl.Result{}, err
+		}
+		deceptionPolicy = projectedPolicy
+		log.Info("DeceptionPolicy created", "NamespacedDeceptionPolicy", req.NamespacedName, "DeceptionPolicy", projectedPolicy.Name)
+	case err != nil:
+		log.Error(err, "DeceptionPolicy cannot be fetched", "NamespacedDeceptionPolicy", req.NamespacedName, "DeceptionPolicy", projectedPolicy.Name)
+		return ctrl.Result{}, err
+	case !isProjectedFrom(deceptionPolicy, &namespacedPolicy):
+		// Never take over a DeceptionPolicy that someone else created with the same name
+		message := fmt.Sprintf("DeceptionPolicy %s already exists and is not managed for this policy", deceptionPolicy.Name)
+		log.Info(message+" - stopping reconciliation", "NamespacedDeceptionPolicy", req.NamespacedName)
+		return ctrl.Result{}, r.putInvalidCondition(ctx, &namespacedPolicy, PolicyValidReason_NameConflict, message)
+	default:
+		err := utils.PatchResource(r.Client, ctx, deceptionPolicy, func() error {
+			deceptionPolicy.Spec = projectedPolicy.Spec
+			return nil
+		})
+		if err != nil {
+			log.Error(err, "DeceptionPolicy cannot be updated", "NamespacedDeceptionPolicy", req.NamespacedName, "DeceptionPolicy", deceptionPolicy.Name)
+			return ctrl.Result{}, err
+		}
+	}
+
+	// The DeceptionPolicy is watched, so its status is mirrored again whenever it changes
+	return ctrl.Result{}, utils.PatchResourceStatus(r.Client, ctx, &namespacedPolicy, func() error {
+		namespacedPolicy.Status = *deceptionPolicy.Status.DeepCopy()
+		return nil
+	})
+}
+
+// runFinalizer deletes the DeceptionPolicy of a NamespacedDeceptionPolicy and removes the finalizer once it is gone.
+// The DeceptionPolicy only disappears after its own finalizer cleaned up the traps, so this is checked again shortly.
+func (r *NamespacedDeceptionPolicyReconciler) runFinalizer(ctx context.Context, namespacedPolicy *v1alpha1.NamespacedDeceptionPolicy) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+
+	if !controllerutil.ContainsFinalizer(namespacedPolicy, constants.FinalizerName) {
+		return ctrl.Result{}, nil
+	}
+
+	deceptionPolicy := &v1alpha1.DeceptionPolicy{}
+	err := r.Get(ctx, types.NamespacedName{Name: ProjectedPolicyName(namespacedPolicy.Namespace, namespacedPolicy.Name)}, deceptionPolicy)
+	if client.IgnoreNotFound(err) != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err == nil && isProjectedFrom(deceptionPolicy, namespacedPolicy) {
+		if deceptionPolicy.GetDeletionTimestamp() == nil {
+			if err := r.Delete(ctx, deceptionPolicy); client.IgnoreNotFound(err) != nil {
+				log.Error(err, "DeceptionPolicy cannot be deleted", "DeceptionPolicy", deceptionPolicy.Name)
+				return ctrl.Result{}, err
+			}
+		}
+
+		log.Info("Waiting for the DeceptionPolicy to clean up its traps", "DeceptionPolicy", deceptionPolicy.Name)
+		return ctrl.Result{RequeueAfter: constants.ShortStatusCheckInterval}, nil
+	}
+
+	return ctrl.Result{}, utils.PatchResource(r.Client, ctx, namespacedPolicy, func() error {
+		controllerutil.RemoveFinalizer(namespacedPolicy, constants.FinalizerName)
+		return nil
+	})
+}
+
+// putInvalidCondition marks a NamespacedDeceptionPolicy as invalid, which leaves its DeceptionPolicy untouched.
+func (r *NamespacedDeceptionPolicyReconciler) putInvalidCondition(ctx context.Context, namespacedPolicy *v1alpha1.NamespacedDeceptionPolicy, reason, message string) error {
+	return utils.PatchResourceStatus(r.Client, ctx, namespacedPolicy, func() error {
+		namespacedPolicy.Status.PutCondition(ResourceFoundType, metav1.ConditionTrue, ResourceFoundReason_Found, ResourceFoundMessage_Found)
+		namespacedPolicy.Status.PutCondition(PolicyValidType, metav1.ConditionFalse, reason, message)
+		return nil
+	})
+}
+
+// ProjectedPolicyName returns the name of the DeceptionPolicy that Koney manages for a NamespacedDeceptionPolicy.
+// Names that would be too long are shortened and made unique with a hash.
+func ProjectedPolicyName(namespace, name string) string {
+	projectedName := namespace + "." + name
+	if len(projectedName) <= maxProjectedPolicyNameLength {
+		return projectedName
+	}
+
+	hash := utils.Hash(projectedName)[:8]
+	prefix := strings.TrimRight(projectedName[:maxProjectedPolicyNameLength-len(hash)-1], ".-")
+	return prefix + "-" + hash
+}
+
+// ProjectNamespacedDeceptionPolicy returns the DeceptionPolicy that Koney manages for a NamespacedDeceptionPolicy.
+// Resource filters without namespaces are restricted to the namespace of the policy, and traps without resource filters
+// match all resources in that namespace. An error is returned if the policy reaches beyond its namespace,
+// i.e., if it matches other namespaces or uses features that Koney resolves with its own permissions.
+func ProjectNamespacedDeceptionPolicy(namespacedPolicy *v1alpha1.NamespacedDeceptionPolicy) (*v1alpha1.DeceptionPolicy, error) {
+	namespace := namespacedPolicy.Namespace
+	spec := namespacedPolicy.Spec.DeepCopy()
+
+	var errs []error
+	for i := range spec.Traps {
+		trap := &spec.Traps[i]
+
+		// Generated contents are kept in the namespace of Koney as well, but they do not reveal anything that is stored there
+		if source := trap.FilesystemHoneytoken.FileContentFrom; source != nil && source.Generate == nil {
+			errs = append(errs, fmt.Errorf("trap %d: fileContentFrom is not supported in namespaced policies, except for generate", i))
+		}
+
+		if len(trap.MatchResources.Any) == 0 {
+			trap.MatchResources.Any = []v1alpha1.ResourceFilter{{ResourceDescription: v1alpha1.ResourceDescription{
+				Namespaces:        []string{namespace},
+				ContainerSelector: "*",
+			}}}
+			continue
+		}
+
+		for j := range trap.MatchResources.Any {
+			description := &trap.MatchResources.Any[j].ResourceDescription
+			if len(description.Namespaces) == 0 {
+				description.Namespaces = []string{namespace}
+			} else if slices.ContainsFunc(description.Namespaces, func(n string) bool { return n != namespace }) {
+				errs = append(errs, fmt.Errorf("trap %d: namespaces must be empty or equal to %q", i, namespace))
+			}
+		}
+	}
+
+	if spec.Alerting != nil {
+		for _, webhook := range spec.Alerting.Webhooks {
+			if webhook.SecretRef != nil {
+				errs = append(errs, fmt.Errorf("webhook %q: secretRef is not supported in namespaced policies", webhook.Name))
+			}
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	return &v1alpha1.DeceptionPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: ProjectedPolicyName(namespace, namespacedPolicy.Name),
+			Annotations: map[string]string{
+				constants.AnnotationKeyNamespacedPolicyRef: namespace + "/" + namespacedPolicy.Name,
+			},
+		},
+		Spec: *spec,
+	}, nil
+}
+
+// isProjectedFrom returns true if Koney manages the DeceptionPolicy for the NamespacedDeceptionPolicy.
+func isProjectedFrom(deceptionPolicy *v1alpha1.DeceptionPolicy, namespacedPolicy *v1alpha1.NamespacedDeceptionPolicy) bool {
+	return deceptionPolicy.GetAnnotations()[constants.AnnotationKeyNamespacedPolicyRef] == namespacedPolicy.Namespace+"/"+namespacedPolicy.Name
+}
+
+// HandleProjectedPolicyWatchEvent reconciles the NamespacedDeceptionPolicy that a DeceptionPolicy is managed for,
+// so that status changes of the DeceptionPolicy are mirrored, and changes by someone else are reverted.
+func HandleProjectedPolicyWatchEvent(ctx context.Context, obj client.Object) []reconcile.Request {
+	ref, ok := obj.GetAnnotations()[constants.AnnotationKeyNamespacedPolicyRef]
+	if !ok {
+		// Not a DeceptionPolicy managed for a NamespacedDeceptionPolicy
+		return []reconcile.Request{}
+	}
+
+	namespace, name, found := strings.Cut(ref, "/")
+	if !found || namespace == "" || name == "" {
+		log.FromContext(ctx).Info(fmt.Sprintf("DeceptionPolicy %s references an invalid NamespacedDeceptionPolicy %q", obj.GetName(), ref))
+		return []reconcile.Request{}
+	}
+
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: namespace, Name: name}}}
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *NamespacedDeceptionPolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		// Only consider spec changes, status changes are made by this controller itself
+		For(&v1alpha1.NamespacedDeceptionPolicy{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&v1alpha1.DeceptionPolicy{}, handler.EnqueueRequestsFromMapFunc(HandleProjectedPolicyWatchEvent)).
+		Complete(r)
+}
@@ -0,0 +1,258 @@
+// Copyright (c) 2025 Dynatrace LLC
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"context"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/dynatrace-oss/koney/api/v1alpha1"
+	"github.com/dynatrace-oss/koney/internal/controller/constants"
+)
+
+var _ = Describe("NamespacedDeceptionPolicy", func() {
+	ctx := context.Background()
+
+	newNamespacedPolicy := func(filters ...v1alpha1.ResourceFilter) *v1alpha1.NamespacedDeceptionPolicy {
+		return &v1alpha1.NamespacedDeceptionPolicy{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "servicetoken"},
+			Spec: v1alpha1.DeceptionPolicySpec{
+				Traps: []v1alpha1.Trap{{
+					FilesystemHoneytoken: v1alpha1.FilesystemHoneytoken{FilePath: "/run/secrets/koney/service_token"},
+					MatchResources:       v1alpha1.MatchResources{Any: filters},
+				}},
+			},
+		}
+	}
+
+	Context("when projecting onto a DeceptionPolicy", func() {
+		It("should restrict resource filters without namespaces to the namespace of the policy", func() {
+			namespacedPolicy := newNamespacedPolicy(v1alpha1.ResourceFilter{ResourceDescription: v1alpha1.ResourceDescription{
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+			}})
+
+			deceptionPolicy, err := ProjectNamespacedDeceptionPolicy(namespacedPolicy)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deceptionPolicy.Name).To(Equal("team-a.servicetoken"))
+			Expect(deceptionPolicy.Annotations).To(HaveKeyWithValue(constants.AnnotationKeyNamespacedPolicyRef, "team-a/servicetoken"))
+			Expect(deceptionPolicy.Spec.Traps[0].MatchResources.Any[0].Namespaces).To(Equal([]string{"team-a"}))
+			Expect(deceptionPolicy.Spec.Traps[0].MatchResources.Any[0].Selector.MatchLabels).To(HaveKeyWithValue("app", "web"))
+
+			// The NamespacedDeceptionPolicy itself must not be modified
+			Expect(namespacedPolicy.Spec.Traps[0].MatchResources.Any[0].Namespaces).To(BeEmpty())
+		})
+
+		It("should match all resources in the namespace if a trap has no resource filters", func() {
+			deceptionPolicy, err := ProjectNamespacedDeceptionPolicy(newNamespacedPolicy())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deceptionPolicy.Spec.Traps[0].MatchResources.Any).To(HaveLen(1))
+			Expect(deceptionPolicy.Spec.Traps[0].MatchResources.Any[0].Namespaces).To(Equal([]string{"team-a"}))
+			Expect(deceptionPolicy.Spec.Traps[0].IsValid()).To(Succeed())
+		})
+
+		It("should accept the namespace of the policy, but reject other namespaces", func() {
+			_, err := ProjectNamespacedDeceptionPolicy(newNamespacedPolicy(v1alpha1.ResourceFilter{ResourceDescription: v1alpha1.ResourceDescription{
+				Namespaces: []string{"team-a"},
+			}}))
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = ProjectNamespacedDeceptionPolicy(newNamespacedPolicy(v1alpha1.ResourceFilter{ResourceDescription: v1alpha1.ResourceDescription{
+				Namespaces: []string{"team-a", "kube-system"},
+			}}))
+			Expect(err).To(MatchError(ContainSubstring(`namespaces must be empty or equal to "team-a"`)))
+		})
+
+		It("should reject features that are resolved with the permissions of Koney", func() {
+			namespacedPolicy := newNamespacedPolicy()
+			namespacedPolicy.Spec.Traps[0].FilesystemHoneytoken.FileContentFrom = &v1alpha1.FileContentSource{}
+			namespacedPolicy.Spec.Alerting = &v1alpha1.Alerting{Webhooks: []v1alpha1.AlertWebhook{{
+				Name:      "team-a",
+				URL:       "https://alerts.example.com",
+				SecretRef: &v1alpha1.WebhookSecretRef{Name: "koney-webhook-auth"},
+			}}}
+
+			_, err := ProjectNamespacedDeceptionPolicy(namespacedPolicy)
+			Expect(err).To(MatchError(ContainSubstring("fileContentFrom is not supported")))
+			Expect(err).To(MatchError(ContainSubstring("secretRef is not supported")))
+		})
+
+		It("should allow generated contents", func() {
+			namespacedPolicy := newNamespacedPolicy()
+			namespacedPolicy.Spec.Traps[0].FilesystemHoneytoken.FileContentFrom = &v1alpha1.FileContentSource{Generate: &v1alpha1.GeneratedContent{}}
+
+			_, err := ProjectNamespacedDeceptionPolicy(namespacedPolicy)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should shorten long names with a hash", func() {
+			name := ProjectedPolicyName("team-a", strings.Repeat("x", 100))
+			Expect(len(name)).To(BeNumerically("<=", 63))
+			Expect(name).To(HavePrefix("team-a.xxx"))
+			Expect(name).NotTo(Equal(ProjectedPolicyName("team-a", strings.Repeat("x", 101))))
+
+			Expect(ProjectedPolicyName("team-a", strings.Repeat("x", 45)+".-"+strings.Repeat("y", 20))).To(MatchRegexp(`^team-a\.x+-[0-9a-f]{8}$`))
+		})
+	})
+
+	Context("when reconciling", func() {
+		var (
+			fakeClient       client.Client
+			reconciler       *NamespacedDeceptionPolicyReconciler
+			namespacedPolicy *v1alpha1.NamespacedDeceptionPolicy
+			request          ctrl.Request
+		)
+
+		setup := func(objects ...client.Object) {
+			scheme := runtime.NewScheme()
+			Expect(v1alpha1.AddToScheme(scheme)).To(Succeed())
+
+			fakeClient = fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(objects...).
+				WithStatusSubresource(&v1alpha1.NamespacedDeceptionPolicy{}, &v1alpha1.DeceptionPolicy{}).
+				Build()
+			reconciler = &NamespacedDeceptionPolicyReconciler{Client: fakeClient, Scheme: scheme}
+		}
+
+		BeforeEach(func() {
+			namespacedPolicy = newNamespacedPolicy()
+			request = ctrl.Request{NamespacedName: client.ObjectKeyFromObject(namespacedPolicy)}
+		})
+
+		getNamespacedPolicy := func() *v1alpha1.NamespacedDeceptionPolicy {
+			stored := &v1alpha1.NamespacedDeceptionPolicy{}
+			Expect(fakeClient.Get(ctx, request.NamespacedName, stored)).To(Succeed())
+			return stored
+		}
+
+		getDeceptionPolicy := func() (*v1alpha1.DeceptionPolicy, error) {
+			stored := &v1alpha1.DeceptionPolicy{}
+			err := fakeClient.Get(ctx, types.NamespacedName{Name: "team-a.servicetoken"}, stored)
+			return stored, err
+		}
+
+		It("should create the DeceptionPolicy and mirror its status", func() {
+			setup(namespacedPolicy)
+
+			_, err := reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(getNamespacedPolicy().Finalizers).To(ContainElement(constants.FinalizerName))
+
+			deceptionPolicy, err := getDeceptionPolicy()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deceptionPolicy.Spec.Traps[0].MatchResources.Any[0].Namespaces).To(Equal([]string{"team-a"}))
+
+			deceptionPolicy.Status.PutCondition(DecoysDeployedType, metav1.ConditionTrue, DecoysDeployedReason_Success, "1/1 decoys deployed")
+			Expect(fakeClient.Status().Update(ctx, deceptionPolicy)).To(Succeed())
+
+			_, err = reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			condition := getNamespacedPolicy().Status.GetCondition(DecoysDeployedType)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Message).To(Equal("1/1 decoys deployed"))
+		})
+
+		It("should revert changes to the DeceptionPolicy", func() {
+			setup(namespacedPolicy)
+
+			_, err := reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+
+			deceptionPolicy, err := getDeceptionPolicy()
+			Expect(err).NotTo(HaveOccurred())
+			deceptionPolicy.Spec.Traps[0].MatchResources.Any[0].Namespaces = []string{"kube-system"}
+			Expect(fakeClient.Update(ctx, deceptionPolicy)).To(Succeed())
+
+			_, err = reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			deceptionPolicy, err = getDeceptionPolicy()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deceptionPolicy.Spec.Traps[0].MatchResources.Any[0].Namespaces).To(Equal([]string{"team-a"}))
+		})
+
+		It("should mark policies that reach beyond their namespace as invalid", func() {
+			namespacedPolicy.Spec.Traps[0].MatchResources.Any = []v1alpha1.ResourceFilter{{ResourceDescription: v1alpha1.ResourceDescription{
+				Namespaces: []string{"kube-system"},
+			}}}
+			setup(namespacedPolicy)
+
+			_, err := reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+
+			condition := getNamespacedPolicy().Status.GetCondition(PolicyValidType)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Reason).To(Equal(PolicyValidReason_NotNamespaced))
+
+			_, err = getDeceptionPolicy()
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("should not take over a DeceptionPolicy that it does not manage", func() {
+			foreignPolicy := &v1alpha1.DeceptionPolicy{ObjectMeta: metav1.ObjectMeta{Name: "team-a.servicetoken"}}
+			setup(namespacedPolicy, foreignPolicy)
+
+			_, err := reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(getNamespacedPolicy().Status.GetCondition(PolicyValidType).Reason).To(Equal(PolicyValidReason_NameConflict))
+
+			deceptionPolicy, err := getDeceptionPolicy()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deceptionPolicy.Spec.Traps).To(BeEmpty())
+		})
+
+		It("should delete the DeceptionPolicy before removing the finalizer", func() {
+			setup(namespacedPolicy)
+
+			_, err := reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeClient.Delete(ctx, getNamespacedPolicy())).To(Succeed())
+
+			result, err := reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(constants.ShortStatusCheckInterval))
+			_, err = getDeceptionPolicy()
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+			_, err = reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			err = fakeClient.Get(ctx, request.NamespacedName, &v1alpha1.NamespacedDeceptionPolicy{})
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		})
+	})
+
+	It("should map DeceptionPolicies to the NamespacedDeceptionPolicy they are managed for", func() {
+		deceptionPolicy := &v1alpha1.DeceptionPolicy{ObjectMeta: metav1.ObjectMeta{
+			Name:        "team-a.servicetoken",
+			Annotations: map[string]string{constants.AnnotationKeyNamespacedPolicyRef: "team-a/servicetoken"},
+		}}
+		Expect(HandleProjectedPolicyWatchEvent(ctx, deceptionPolicy)).To(ConsistOf(
+			ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "team-a", Name: "servicetoken"}},
+		))
+
+		Expect(HandleProjectedPolicyWatchEvent(ctx, &v1alpha1.DeceptionPolicy{})).To(BeEmpty())
+	})
+})
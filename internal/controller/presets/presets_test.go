@@ -0,0 +1,107 @@
+// Copyright (c) 2025 Dynatrace LLC
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package presets
+
+import (
+	"maps"
+	"slices"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/dynatrace-oss/koney/api/v1alpha1"
+)
+
+var _ = Describe("presets", func() {
+	It("should define every preset of the API", func() {
+		Expect(slices.Sorted(maps.Keys(presets))).To(ConsistOf(v1alpha1.TrapPresets))
+	})
+})
+
+var _ = Describe("ExpandPresets", func() {
+	newPolicy := func(uid string, traps ...v1alpha1.Trap) *v1alpha1.DeceptionPolicy {
+		return &v1alpha1.DeceptionPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "deceptionpolicy-presets", UID: types.UID("uid-" + uid)},
+			Spec:       v1alpha1.DeceptionPolicySpec{Traps: traps},
+		}
+	}
+	match := v1alpha1.MatchResources{
+		Any: []v1alpha1.ResourceFilter{{ResourceDescription: v1alpha1.ResourceDescription{Namespaces: []string{"koney"}}}},
+	}
+
+	It("should expand every preset into a valid filesystem honeytoken", func() {
+		for _, name := range v1alpha1.TrapPresets {
+			policy := newPolicy("1", v1alpha1.Trap{Preset: name, MatchResources: match})
+
+			trap := ExpandPresets(policy).Spec.Traps[0]
+			Expect(trap.TrapType()).To(Equal(v1alpha1.FilesystemHoneytokenTrap), name)
+			Expect(trap.FilesystemHoneytoken.FilePath).To(Equal(presets[name].filePath), name)
+			Expect(trap.FilesystemHoneytoken.FileContent).NotTo(BeEmpty(), name)
+			Expect(trap.FilesystemHoneytoken.ReadOnly).To(BeTrue(), name)
+			Expect(trap.IsValid()).To(Succeed(), name)
+		}
+	})
+
+	It("should not change the DeceptionPolicy itself", func() {
+		policy := newPolicy("1", v1alpha1.Trap{Preset: "aws-credentials", MatchResources: match})
+		ExpandPresets(policy)
+		Expect(policy.Spec.Traps[0].TrapType()).To(Equal(v1alpha1.UnknownTrap))
+	})
+
+	It("should generate the same content for the same DeceptionPolicy only", func() {
+		trap := v1alpha1.Trap{Preset: "aws-credentials", MatchResources: match}
+
+		first := ExpandPresets(newPolicy("1", trap)).Spec.Traps[0].FilesystemHoneytoken.FileContent
+		again := ExpandPresets(newPolicy("1", trap)).Spec.Traps[0].FilesystemHoneytoken.FileContent
+		other := ExpandPresets(newPolicy("2", trap)).Spec.Traps[0].FilesystemHoneytoken.FileContent
+
+		Expect(first).To(MatchRegexp(`^\[default\]\naws_access_key_id = AKIA[A-Z2-7]{16}\naws_secret_access_key = [A-Za-z0-9+/]{40}\n`))
+		Expect(again).To(Equal(first))
+		Expect(other).NotTo(Equal(first))
+	})
+
+	It("should keep the fields that the trap sets itself", func() {
+		policy := newPolicy("1", v1alpha1.Trap{
+			Preset: "git-credentials",
+			FilesystemHoneytoken: v1alpha1.FilesystemHoneytoken{
+				FilePath:        "/home/app/.git-credentials",
+				FileContentFrom: &v1alpha1.FileContentSource{Generate: &v1alpha1.GeneratedContent{}},
+			},
+			MatchResources: match,
+		})
+
+		honeytoken := ExpandPresets(policy).Spec.Traps[0].FilesystemHoneytoken
+		Expect(honeytoken.FilePath).To(Equal("/home/app/.git-credentials"))
+		Expect(honeytoken.FileContent).To(BeEmpty())
+		Expect(honeytoken.FileContentFrom).NotTo(BeNil())
+		Expect(honeytoken.ReadOnly).To(BeFalse())
+	})
+
+	It("should leave traps of other types alone", func() {
+		envVarTrap := v1alpha1.Trap{
+			Preset:           "aws-credentials",
+			EnvVarHoneytoken: v1alpha1.EnvVarHoneytoken{Name: "AWS_SECRET_ACCESS_KEY", Value: "secret"},
+			MatchResources:   match,
+		}
+
+		trap := ExpandPresets(newPolicy("1", envVarTrap)).Spec.Traps[0]
+		Expect(trap.TrapType()).To(Equal(v1alpha1.EnvVarHoneytokenTrap))
+		Expect(trap.FilesystemHoneytoken).To(Equal(v1alpha1.FilesystemHoneytoken{}))
+		Expect(trap.IsValid()).To(MatchError(ContainSubstring("presets can only be used for FilesystemHoneytoken traps")))
+	})
+})
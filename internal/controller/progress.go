@@ -0,0 +1,215 @@
+// Copyright (c) 2025 Dynatrace LLC
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/dynatrace-oss/koney/api/v1alpha1"
+	trapsapi "github.com/dynatrace-oss/koney/internal/controller/traps/api"
+	"github.com/dynatrace-oss/koney/internal/controller/utils"
+)
+
+// maxProgressTargets limits how many targets are stored in the status of a DeceptionPolicy,
+// so that the status does not grow too large on clusters with many matched objects.
+const maxProgressTargets = 1000
+
+// decoyProgress tracks the deployment of decoys in the status of a DeceptionPolicy (see v1alpha1.DeploymentProgress).
+// The progress is persisted before and after the decoy of each trap is deployed.
+type decoyProgress struct {
+	client   client.Client
+	key      client.ObjectKey
+	progress v1alpha1.DeploymentProgress
+}
+
+// loadDecoyProgress continues the progress from the status of the DeceptionPolicy,
+// or starts over if the progress refers to another generation of the DeceptionPolicy.
+func (r *DeceptionPolicyReconciler) loadDecoyProgress(deceptionPolicy *v1alpha1.DeceptionPolicy) *decoyProgress {
+	p := &decoyProgress{
+		client:   r.Client,
+		key:      client.ObjectKeyFromObject(deceptionPolicy),
+		progress: v1alpha1.DeploymentProgress{ObservedGeneration: deceptionPolicy.Generation},
+	}
+
+	if previous := deceptionPolicy.Status.DecoyProgress; previous != nil && previous.ObservedGeneration == deceptionPolicy.Generation {
+		p.progress.Targets = slices.Clone(previous.Targets)
+	}
+
+	return p
+}
+
+// orderTraps returns the traps in the order in which their decoys should be deployed:
+// traps that were interrupted come first, followed by traps with pending or failed targets, and finally all other traps.
+func (p *decoyProgress) orderTraps(deceptionPolicy *v1alpha1.DeceptionPolicy, traps []v1alpha1.Trap) []v1alpha1.Trap {
+	priority := func(trap v1alpha1.Trap) int {
+		states := p.statesOf(trapIndex(deceptionPolicy, trap))
+		switch {
+		case slices.Contains(states, v1alpha1.DeploymentTargetInProgress):
+			return 0
+		case slices.Contains(states, v1alpha1.DeploymentTargetPending), slices.Contains(states, v1alpha1.DeploymentTargetFailed):
+			return 1
+		default:
+			return 2
+		}
+	}
+
+	ordered := slices.Clone(traps)
+	slices.SortStableFunc(ordered, func(a, b v1alpha1.Trap) int {
+		return priority(a) - priority(b)
+	})
+	return ordered
+}
+
+// start marks the targets of a trap that are not deployed yet as in progress, and persists the progress.
+// Targets that the decoy cannot be deployed to are left as they are.
+func (p *decoyProgress) start(ctx context.Context, trapIndex int) {
+	for i := range p.progress.Targets {
+		target := &p.progress.Targets[i]
+		if int(target.Trap) == trapIndex && target.State != v1alpha1.DeploymentTargetDeployed && target.State != v1alpha1.DeploymentTargetUnsupported {
+			target.State = v1alpha1.DeploymentTargetInProgress
+		}
+	}
+
+	p.persist(ctx)
+}
+
+// finish replaces the targets of a trap with the outcomes of its decoy deployment, and persists the progress.
+func (p *decoyProgress) finish(ctx context.Context, trapIndex int, outcomes []trapsapi.ObjectOutcome) {
+	p.progress.Targets = slices.DeleteFunc(p.progress.Targets, func(target v1alpha1.DeploymentTarget) bool {
+		return int(target.Trap) == trapIndex
+	})
+
+	for _, outcome := range outcomes {
+		target := v1alpha1.DeploymentTarget{
+			Trap:      int32(trapIndex),
+			Kind:      outcome.Object.Kind,
+			Namespace: outcome.Object.Namespace,
+			Name:      outcome.Object.Name,
+		}
+
+		switch {
+		case outcome.Error != nil:
+			target.State = v1alpha1.DeploymentTargetFailed
+		case outcome.SkippedReason == trapsapi.SkippedReasonUnsupported:
+			target.State = v1alpha1.DeploymentTargetUnsupported
+		case outcome.SkippedReason != "":
+			target.State = v1alpha1.DeploymentTargetPending
+		default:
+			target.State = v1alpha1.DeploymentTargetDeployed
+		}
+
+		p.progress.Targets = append(p.progress.Targets, target)
+	}
+
+	p.persist(ctx)
+}
+
+// persist writes the progress into the status of the DeceptionPolicy.
+// The progress is only informational, so errors are logged but do not fail the reconciliation.
+func (p *decoyProgress) persist(ctx context.Context) {
+	log := log.FromContext(ctx)
+
+	progress := summarizeProgress(p.progress)
+
+	deceptionPolicy := &v1alpha1.DeceptionPolicy{}
+	err := p.client.Get(ctx, p.key, deceptionPolicy)
+	if err == nil {
+		err = utils.PatchResourceStatus(p.client, ctx, deceptionPolicy, func() error {
+			deceptionPolicy.Status.DecoyProgress = &progress
+			return nil
+		})
+	}
+	if err != nil {
+		log.Error(err, "Decoy deployment progress cannot be stored", "DeceptionPolicy", p.key)
+	}
+}
+
+// statesOf returns the states of all targets of a trap.
+func (p *decoyProgress) statesOf(trapIndex int) []v1alpha1.DeploymentTargetState {
+	var states []v1alpha1.DeploymentTargetState
+	for _, target := range p.progress.Targets {
+		if int(target.Trap) == trapIndex {
+			states = append(states, target.State)
+		}
+	}
+	return states
+}
+
+// summarizeProgress counts the targets in each state and writes the summary.
+// If there are too many targets, the targets that are already deployed are omitted first.
+func summarizeProgress(progress v1alpha1.DeploymentProgress) v1alpha1.DeploymentProgress {
+	summarized := v1alpha1.DeploymentProgress{ObservedGeneration: progress.ObservedGeneration}
+
+	for _, target := range progress.Targets {
+		switch target.State {
+		case v1alpha1.DeploymentTargetPending:
+			summarized.Pending++
+		case v1alpha1.DeploymentTargetInProgress:
+			summarized.InProgress++
+		case v1alpha1.DeploymentTargetDeployed:
+			summarized.Deployed++
+		case v1alpha1.DeploymentTargetFailed:
+			summarized.Failed++
+		case v1alpha1.DeploymentTargetUnsupported:
+			summarized.Unsupported++
+		}
+	}
+
+	summary := fmt.Sprintf("%d/%d deployed", summarized.Deployed, len(progress.Targets))
+	for _, count := range []struct {
+		number int32
+		label  string
+	}{{summarized.InProgress, "in progress"}, {summarized.Failed, "failed"}, {summarized.Unsupported, "unsupported"}, {summarized.Pending, "pending"}} {
+		if count.number > 0 {
+			summary += fmt.Sprintf(", %d %s", count.number, count.label)
+		}
+	}
+	summarized.Summary = summary
+
+	summarized.Targets = slices.Clone(progress.Targets)
+	slices.SortStableFunc(summarized.Targets, func(a, b v1alpha1.DeploymentTarget) int {
+		// Keep the targets that are not deployed yet when truncating the list
+		if (a.State == v1alpha1.DeploymentTargetDeployed) != (b.State == v1alpha1.DeploymentTargetDeployed) {
+			if a.State == v1alpha1.DeploymentTargetDeployed {
+				return 1
+			}
+			return -1
+		}
+		if a.Trap != b.Trap {
+			return int(a.Trap - b.Trap)
+		}
+		return strings.Compare(a.Namespace+"/"+a.Name, b.Namespace+"/"+b.Name)
+	})
+	if len(summarized.Targets) > maxProgressTargets {
+		summarized.Targets = summarized.Targets[:maxProgressTargets]
+	}
+
+	return summarized
+}
+
+// trapIndex returns the index of a trap in the spec of the DeceptionPolicy, or -1 if it is not part of the spec.
+func trapIndex(deceptionPolicy *v1alpha1.DeceptionPolicy, trap v1alpha1.Trap) int {
+	return slices.IndexFunc(deceptionPolicy.Spec.Traps, func(other v1alpha1.Trap) bool {
+		return reflect.DeepEqual(other, trap)
+	})
+}
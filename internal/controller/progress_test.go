@@ -0,0 +1,166 @@
+// Copyright (c) 2025 Dynatrace LLC
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"context"
+	goerrors "errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/dynatrace-oss/koney/api/v1alpha1"
+	trapsapi "github.com/dynatrace-oss/koney/internal/controller/traps/api"
+)
+
+var _ = Describe("Decoy deployment progress", func() {
+	ctx := context.Background()
+
+	var (
+		fakeClient      client.Client
+		deceptionPolicy *v1alpha1.DeceptionPolicy
+	)
+
+	newOutcome := func(name string) trapsapi.ObjectOutcome {
+		return trapsapi.ObjectOutcome{Object: corev1.ObjectReference{Kind: "Pod", Namespace: "default", Name: name}}
+	}
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(v1alpha1.AddToScheme(scheme)).To(Succeed())
+
+		deceptionPolicy = &v1alpha1.DeceptionPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-deception-policy", Generation: 2},
+			Spec: v1alpha1.DeceptionPolicySpec{
+				Traps: []v1alpha1.Trap{
+					{FilesystemHoneytoken: v1alpha1.FilesystemHoneytoken{FilePath: "/run/secrets/first"}},
+					{FilesystemHoneytoken: v1alpha1.FilesystemHoneytoken{FilePath: "/run/secrets/second"}},
+				},
+			},
+		}
+
+		fakeClient = fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(deceptionPolicy).
+			WithStatusSubresource(deceptionPolicy).
+			Build()
+	})
+
+	storedProgress := func() *v1alpha1.DeploymentProgress {
+		stored := &v1alpha1.DeceptionPolicy{}
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(deceptionPolicy), stored)).To(Succeed())
+		return stored.Status.DecoyProgress
+	}
+
+	It("should store the state of every target", func() {
+		r := &DeceptionPolicyReconciler{Client: fakeClient}
+		progress := r.loadDecoyProgress(deceptionPolicy)
+
+		failed := newOutcome("failed")
+		failed.Error = goerrors.New("exec failed")
+		pending := newOutcome("pending")
+		pending.SkippedReason = trapsapi.SkippedReasonNotReady
+
+		progress.start(ctx, 0)
+		progress.finish(ctx, 0, []trapsapi.ObjectOutcome{newOutcome("deployed"), failed, pending})
+
+		stored := storedProgress()
+		Expect(stored).NotTo(BeNil())
+		Expect(stored.ObservedGeneration).To(Equal(int64(2)))
+		Expect(stored.Deployed).To(Equal(int32(1)))
+		Expect(stored.Failed).To(Equal(int32(1)))
+		Expect(stored.Pending).To(Equal(int32(1)))
+		Expect(stored.Summary).To(Equal("1/3 deployed, 1 failed, 1 pending"))
+		Expect(stored.Targets).To(HaveLen(3))
+		Expect(stored.Targets[2].State).To(Equal(v1alpha1.DeploymentTargetDeployed))
+	})
+
+	It("should mark targets as in progress while deploying", func() {
+		r := &DeceptionPolicyReconciler{Client: fakeClient}
+		progress := r.loadDecoyProgress(deceptionPolicy)
+
+		pending := newOutcome("pending")
+		pending.SkippedReason = trapsapi.SkippedReasonNotReady
+		progress.finish(ctx, 1, []trapsapi.ObjectOutcome{newOutcome("deployed"), pending})
+		progress.start(ctx, 1)
+
+		stored := storedProgress()
+		Expect(stored.InProgress).To(Equal(int32(1)))
+		Expect(stored.Deployed).To(Equal(int32(1)))
+		Expect(stored.Summary).To(Equal("1/2 deployed, 1 in progress"))
+	})
+
+	It("should not retry targets that the decoy cannot be deployed to", func() {
+		r := &DeceptionPolicyReconciler{Client: fakeClient}
+		progress := r.loadDecoyProgress(deceptionPolicy)
+
+		unsupported := newOutcome("distroless")
+		unsupported.Unsupported = []string{"app"}
+		unsupported.SkippedReason = trapsapi.SkippedReasonUnsupported
+		progress.finish(ctx, 0, []trapsapi.ObjectOutcome{newOutcome("deployed"), unsupported})
+		progress.start(ctx, 0)
+
+		stored := storedProgress()
+		Expect(stored.Unsupported).To(Equal(int32(1)))
+		Expect(stored.InProgress).To(BeZero())
+		Expect(stored.Summary).To(Equal("1/2 deployed, 1 unsupported"))
+	})
+
+	It("should resume with interrupted traps", func() {
+		deceptionPolicy.Status.DecoyProgress = &v1alpha1.DeploymentProgress{
+			ObservedGeneration: 2,
+			Targets: []v1alpha1.DeploymentTarget{
+				{Trap: 0, Name: "done", State: v1alpha1.DeploymentTargetDeployed},
+				{Trap: 1, Name: "interrupted", State: v1alpha1.DeploymentTargetInProgress},
+			},
+		}
+
+		r := &DeceptionPolicyReconciler{Client: fakeClient}
+		ordered := r.loadDecoyProgress(deceptionPolicy).orderTraps(deceptionPolicy, deceptionPolicy.Spec.Traps)
+		Expect(ordered[0].FilesystemHoneytoken.FilePath).To(Equal("/run/secrets/second"))
+		Expect(ordered[1].FilesystemHoneytoken.FilePath).To(Equal("/run/secrets/first"))
+	})
+
+	It("should start over for a new generation", func() {
+		deceptionPolicy.Status.DecoyProgress = &v1alpha1.DeploymentProgress{
+			ObservedGeneration: 1,
+			Targets:            []v1alpha1.DeploymentTarget{{Trap: 1, Name: "interrupted", State: v1alpha1.DeploymentTargetInProgress}},
+		}
+
+		r := &DeceptionPolicyReconciler{Client: fakeClient}
+		progress := r.loadDecoyProgress(deceptionPolicy)
+		Expect(progress.progress.Targets).To(BeEmpty())
+		Expect(progress.progress.ObservedGeneration).To(Equal(int64(2)))
+	})
+
+	It("should keep targets that are not deployed when truncating", func() {
+		progress := v1alpha1.DeploymentProgress{}
+		for i := 0; i < maxProgressTargets; i++ {
+			progress.Targets = append(progress.Targets, v1alpha1.DeploymentTarget{Name: "deployed", State: v1alpha1.DeploymentTargetDeployed})
+		}
+		progress.Targets = append(progress.Targets, v1alpha1.DeploymentTarget{Name: "failed", State: v1alpha1.DeploymentTargetFailed})
+
+		summarized := summarizeProgress(progress)
+		Expect(summarized.Targets).To(HaveLen(maxProgressTargets))
+		Expect(summarized.Targets[0].Name).To(Equal("failed"))
+		Expect(summarized.Deployed).To(Equal(int32(maxProgressTargets)))
+	})
+})
@@ -18,10 +18,10 @@ package controller
 import (
 	"context"
 
-	"k8s.io/client-go/util/retry"
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	"github.com/dynatrace-oss/koney/api/v1alpha1"
+	"github.com/dynatrace-oss/koney/internal/controller/utils"
 )
 
 const (
@@ -29,6 +29,7 @@ const (
 	PolicyValidType     = "PolicyValid"
 	DecoysDeployedType  = "DecoysDeployed"
 	CaptorsDeployedType = "CaptorsDeployed"
+	PolicyConflictType  = "PolicyConflict"
 
 	ResourceFoundReason_Found = "ResourceFound"
 
@@ -38,11 +39,21 @@ const (
 	PolicyValidReason_Valid   = "TrapsSpecValid"
 	PolicyValidReason_Invalid = "TrapsSpecInvalid"
 
-	DecoysDeployedReason_Pending        = "DecoyDeploymentPending"
-	DecoysDeployedReason_Success        = "DecoyDeploymentSucceeded"
-	DecoysDeployedReason_PartialSuccess = "DecoyDeploymentSucceededPartially"
-	DecoysDeployedReason_GenericError   = "DecoyDeploymentError"
-	DecoysDeployedReason_NoObjects      = "NoObjectsMatched"
+	PolicyValidReason_NotNamespaced = "TrapsNotNamespaced"
+	PolicyValidReason_NameConflict  = "PolicyNameConflict"
+
+	PolicyConflictReason_Pending  = "ConflictCheckPending"
+	PolicyConflictReason_None     = "NoConflicts"
+	PolicyConflictReason_Conflict = "ConflictingTraps"
+
+	DecoysDeployedReason_Pending            = "DecoyDeploymentPending"
+	DecoysDeployedReason_Success            = "DecoyDeploymentSucceeded"
+	DecoysDeployedReason_PartialSuccess     = "DecoyDeploymentSucceededPartially"
+	DecoysDeployedReason_GenericError       = "DecoyDeploymentError"
+	DecoysDeployedReason_NoObjects          = "NoObjectsMatched"
+	DecoysDeployedReason_ContentUnavailable = "TrapContentUnavailable"
+
+	DecoysDeployedMessage_ContentUnavailable = "Contents of some traps cannot be resolved from their sources"
 
 	TrapDeployedMessage_NoObjects = "No objects matching selection criteria"
 
@@ -54,6 +65,15 @@ const (
 	CaptorsDeployedReason_MissingTetragon = "TetragonNotInstalled"
 
 	CaptorsDeployedMessage_MissingTetragon = "Cannot deploy captors without Tetragon"
+
+	EventReason_DecoyDeploymentFailed = "DecoyDeploymentFailed"
+	EventReason_DecoyUnsupported      = "DecoyUnsupported"
+	EventReason_TrapTampered          = "TrapTampered"
+	EventReason_CaptorRestored        = "CaptorRestored"
+	EventReason_PolicyConflict        = "PolicyConflict"
+
+	// maxObjectsInStatusMessage limits how many failed objects are named in a status condition message.
+	maxObjectsInStatusMessage = 3
 )
 
 // TrapDeploymentStatusEnum defines the possible conditions for a trap deployment.
@@ -112,24 +132,18 @@ var CaptorDeployedStatusConditions = TrapDeploymentStatusEnum{
 // updateStatusConditions updates one or more conditions of a DeceptionPolicy resource.
 // If the conditions are already set as desired, no update is performed.
 // When comparing the current and desired conditions, the LastTransitionTime field is ignored.
-// This function retries on conflicts (to resolve parallel update attempts) and returns an error if the update fails.
-func (r *DeceptionPolicyReconciler) updateStatusConditions(ctx context.Context, req ctrl.Request, deceptionPolicy *v1alpha1.DeceptionPolicy, conditions []v1alpha1.DeceptionPolicyCondition) error {
-	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
-		if err := r.Get(ctx, req.NamespacedName, deceptionPolicy); err != nil {
-			return err
-		}
+// The status is patched (not updated), so this function does not fail if the DeceptionPolicy was modified in the meantime.
+func (r *DeceptionPolicyReconciler) updateStatusConditions(ctx context.Context, req ctrl.Request, deceptionPolicy *v1alpha1.DeceptionPolicy, conditions []v1alpha1.DeceptionPolicyCondition, skippedNamespaces []string) error {
+	if err := r.Get(ctx, req.NamespacedName, deceptionPolicy); err != nil {
+		return err
+	}
 
-		anyDirty := false
+	// If all conditions already have their desired values, the patch is empty and not sent
+	return utils.PatchResourceStatus(r.Client, ctx, deceptionPolicy, func() error {
 		for _, condition := range conditions {
-			dirty := deceptionPolicy.Status.PutCondition(condition.Type, condition.Status, condition.Reason, condition.Message)
-			anyDirty = anyDirty || dirty
-		}
-		if !anyDirty {
-			return nil // All conditions already have their desired values
+			deceptionPolicy.Status.PutCondition(condition.Type, condition.Status, condition.Reason, condition.Message)
 		}
-
-		// TODO: Can we use patch instead of update to avoid conflicts?
-		err := r.Client.Status().Update(ctx, deceptionPolicy)
-		return err
+		deceptionPolicy.Status.SkippedNamespaces = skippedNamespaces
+		return nil
 	})
 }
@@ -15,7 +15,21 @@
 
 package api
 
-import "github.com/dynatrace-oss/koney/api/v1alpha1"
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/dynatrace-oss/koney/api/v1alpha1"
+)
+
+const (
+	// SkippedReasonNotReady means that the object (or all of its matched containers) was not ready for the decoy yet.
+	SkippedReasonNotReady = "NotReady"
+	// SkippedReasonUnsupported means that the decoy cannot be deployed to any matched container of the object with the chosen strategy,
+	// e.g., because the containers have no shell for the containerExec strategy. Retrying does not help.
+	SkippedReasonUnsupported = "Unsupported"
+)
 
 type TrapDeploymentResult interface {
 	// Trap referenes the trap that was deployed.
@@ -41,6 +55,11 @@ type DecoyDeploymentResult struct {
 	// If not, the deployment should be retried later. This can happen e.g., if containers are not running yet.
 	// If no resources were matched or if errors occurred, this field should be ignored.
 	AllObjectsWereReady bool
+	// Outcomes lists what happened to every matched object (deployed, skipped, or failed).
+	// Errors that are not related to a single object are only reported in Errors.
+	Outcomes []ObjectOutcome
+	// SkippedNamespaces lists the excluded namespaces where the trap matched, but was not deployed to.
+	SkippedNamespaces []string
 	// Errors may contain one or more errors that happened during the deployment.
 	Errors error
 }
@@ -72,6 +91,8 @@ type CaptorDeploymentResult struct {
 	Errors error
 	// MissingTetragon is set if we saw indications that Tetragon is not available in the cluster.
 	MissingTetragon bool
+	// Restored is set if the captor existed already, but was changed by someone else and had to be restored.
+	Restored bool
 }
 
 func (result CaptorDeploymentResult) GetTrap() *v1alpha1.Trap {
@@ -93,3 +114,60 @@ func (result CaptorDeploymentResult) ImpliesFailure() bool {
 func (result CaptorDeploymentResult) ImpliesRetry() bool {
 	return false
 }
+
+// ObjectOutcome describes the outcome of deploying a decoy to a single object.
+type ObjectOutcome struct {
+	// Object references the object (e.g., a pod or a deployment) that the decoy should be deployed to.
+	Object corev1.ObjectReference
+	// Containers lists the containers that the decoy is deployed to, including containers where it was deployed before.
+	Containers []string
+	// Tampered lists the containers where the decoy was deployed before, but its content was changed in the meantime.
+	// The decoy was deployed again to these containers.
+	Tampered []string
+	// Unsupported lists the containers where the decoy cannot be deployed with the chosen strategy,
+	// e.g., because they have no shell for the containerExec strategy.
+	Unsupported []string
+	// SkippedReason is set if the decoy is not in place on the object (yet), e.g., because the object was not ready.
+	SkippedReason string
+	// Error is set if the deployment to the object failed.
+	Error error
+}
+
+// NewObjectOutcome creates an outcome that references the object by its kind, namespace, and name.
+func NewObjectOutcome(object client.Object) ObjectOutcome {
+	kind := object.GetObjectKind().GroupVersionKind().Kind
+	if kind == "" {
+		// Typed objects from the client usually come without type information
+		switch object.(type) {
+		case *corev1.Pod:
+			kind = "Pod"
+		case *appsv1.Deployment:
+			kind = "Deployment"
+		}
+	}
+
+	return ObjectOutcome{
+		Object: corev1.ObjectReference{
+			Kind:      kind,
+			Namespace: object.GetNamespace(),
+			Name:      object.GetName(),
+			UID:       object.GetUID(),
+		},
+	}
+}
+
+// NotReadyOutcomes creates outcomes for objects that were skipped because they were not ready.
+func NotReadyOutcomes(objects []client.Object) []ObjectOutcome {
+	outcomes := make([]ObjectOutcome, 0, len(objects))
+	for _, object := range objects {
+		outcome := NewObjectOutcome(object)
+		outcome.SkippedReason = SkippedReasonNotReady
+		outcomes = append(outcomes, outcome)
+	}
+	return outcomes
+}
+
+// Succeeded returns true if the decoy is in place on the object and no errors occurred.
+func (outcome ObjectOutcome) Succeeded() bool {
+	return outcome.SkippedReason == "" && outcome.Error == nil
+}
@@ -0,0 +1,269 @@
+// Copyright (c) 2025 Dynatrace LLC
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package envtoken
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/dynatrace-oss/koney/api/v1alpha1"
+	"github.com/dynatrace-oss/koney/internal/controller/annotations"
+	"github.com/dynatrace-oss/koney/internal/controller/constants"
+	"github.com/dynatrace-oss/koney/internal/controller/matching"
+	trapsapi "github.com/dynatrace-oss/koney/internal/controller/traps/api"
+	"github.com/dynatrace-oss/koney/internal/controller/traps/filesystoken"
+	"github.com/dynatrace-oss/koney/internal/controller/utils"
+	"github.com/dynatrace-oss/koney/internal/controller/webhookauth"
+)
+
+type EnvVarHoneytokenReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+
+	// MaxAnnotationSize is the maximum size of the changes annotation, before changes are spilled into a ConfigMap.
+	MaxAnnotationSize int
+
+	// AlertWebhookHost is the host of the alert forwarder that captors send alerts to (see webhookauth.WebhookURL).
+	AlertWebhookHost string
+
+	// ExcludedNamespaces are namespaces where no decoys are deployed, even if the trap matches resources there.
+	ExcludedNamespaces []string
+
+	DeceptionPolicy *v1alpha1.DeceptionPolicy
+}
+
+// DeployDecoy deploys an EnvVarHoneytoken decoy by adding the environment variable to the containers of the matching deployments.
+// The trap is only deployed to the containers where the trap is not already deployed.
+func (r *EnvVarHoneytokenReconciler) DeployDecoy(ctx context.Context, deceptionPolicy *v1alpha1.DeceptionPolicy, trap v1alpha1.Trap) trapsapi.DecoyDeploymentResult {
+	log := log.FromContext(ctx)
+	var joinedErrors error
+
+	// If we aren't allowed to mutate existing resources, we avoid matching resources created before the policy was created
+	var filterCreatedAfter metav1.Time
+	if !*deceptionPolicy.Spec.MutateExisting {
+		filterCreatedAfter = deceptionPolicy.CreationTimestamp
+	}
+
+	// Get matching deployments and the matched containers (the strategy is always volumeMount)
+	matchingResult, err := matching.GetDeployableObjectsWithContainers(r, ctx, trap, &filterCreatedAfter, r.ExcludedNamespaces)
+	if err != nil {
+		log.Error(err, "unable to get matching resources")
+		return trapsapi.DecoyDeploymentResult{Trap: &trap, Errors: errors.Join(err, errors.New("unable to get matching resources"))}
+	} else if len(matchingResult.DeployableObjects) == 0 {
+		return trapsapi.DecoyDeploymentResult{
+			Trap:                        &trap,
+			AtLeastOneObjectsWasMatched: matchingResult.AtLeastOneObjectWasMatched,
+			AllObjectsWereReady:         matchingResult.AllDeployableObjectsWereReady,
+			Outcomes:                    trapsapi.NotReadyOutcomes(matchingResult.NotReadyObjects),
+			SkippedNamespaces:           matchingResult.SkippedNamespaces}
+	}
+
+	outcomes := trapsapi.NotReadyOutcomes(matchingResult.NotReadyObjects)
+	for resource, selectedContainers := range matchingResult.DeployableObjects {
+		deployment, ok := resource.(*appsv1.Deployment)
+		if !ok {
+			continue
+		}
+
+		outcome := trapsapi.NewObjectOutcome(deployment)
+		deployedToContainers, err := r.deployDecoyToDeployment(ctx, deceptionPolicy, trap, deployment, selectedContainers)
+		if err != nil {
+			log.Error(err, "unable to deploy EnvVarHoneytoken trap to deployment", "deployment", deployment.Name)
+			joinedErrors = errors.Join(joinedErrors, err)
+			outcome.Error = err
+		}
+		outcome.Containers = deployedToContainers
+		outcomes = append(outcomes, outcome)
+	}
+
+	return trapsapi.DecoyDeploymentResult{
+		Trap:                        &trap,
+		AtLeastOneObjectsWasMatched: matchingResult.AtLeastOneObjectWasMatched,
+		AllObjectsWereReady:         matchingResult.AllDeployableObjectsWereReady,
+		Outcomes:                    outcomes,
+		SkippedNamespaces:           matchingResult.SkippedNamespaces,
+		Errors:                      joinedErrors}
+}
+
+// DeployCaptor deploys a captor for an environment variable honeytoken trap.
+func (r *EnvVarHoneytokenReconciler) DeployCaptor(ctx context.Context, deceptionPolicy *v1alpha1.DeceptionPolicy, trap v1alpha1.Trap) trapsapi.CaptorDeploymentResult {
+	log := log.FromContext(ctx)
+
+	switch trap.CaptorDeployment.Strategy {
+	case "tetragon":
+		restored, err := r.deployCaptorWithTetragon(ctx, deceptionPolicy, trap)
+		if err != nil {
+			missingTetragon := errors.Is(err, &meta.NoKindMatchError{})
+			if missingTetragon {
+				log.Error(nil, "Tetragon is not installed - cannot deploy captors with Tetragon")
+			}
+			return trapsapi.CaptorDeploymentResult{Trap: &trap, Errors: err, MissingTetragon: missingTetragon}
+		}
+		return trapsapi.CaptorDeploymentResult{Trap: &trap, Restored: restored}
+	default:
+		log.Error(nil, fmt.Sprintf("captor deployment strategy '%s' unknown", trap.CaptorDeployment.Strategy))
+		return trapsapi.CaptorDeploymentResult{Trap: &trap, Errors: errors.New("captor deployment strategy unknown")}
+	}
+
+	return trapsapi.CaptorDeploymentResult{Trap: &trap}
+}
+
+// deployDecoyToDeployment stores the value of the environment variable in a secret and references it
+// from the environment of the selected containers of a deployment. The deployment is annotated in the same update.
+// Containers that already define a variable with the same name are skipped, so that we never break applications.
+// The function returns the containers where the trap is deployed to after the update.
+func (r *EnvVarHoneytokenReconciler) deployDecoyToDeployment(ctx context.Context, deceptionPolicy *v1alpha1.DeceptionPolicy, trap v1alpha1.Trap, deployment *appsv1.Deployment, selectedContainers []string) ([]string, error) {
+	log := log.FromContext(ctx)
+
+	name := trap.EnvVarHoneytoken.Name
+	secretName := generateSecretName(name, utils.Hash(trap.EnvVarHoneytoken.Value))
+
+	data := map[string][]byte{
+		name: []byte(trap.EnvVarHoneytoken.Value),
+	}
+
+	if err := filesystoken.CreateSecret(r.Client, ctx, deployment.Namespace, secretName, data); err != nil {
+		log.Error(err, "unable to create secret", "secret", secretName)
+		return nil, err
+	}
+
+	var conflictErrors error          // Errors for containers that already define the environment variable
+	var deployedToContainers []string // Containers where the trap is deployed after this update
+	if err := r.Client.Get(ctx, client.ObjectKeyFromObject(deployment), deployment); err != nil {
+		log.Error(err, "unable to get deployment", "deployment", deployment.Name)
+		return nil, err
+	}
+
+	// The annotations as stored in the API server, before the spilled changes are loaded into them
+	storedAnnotations := maps.Clone(deployment.GetAnnotations())
+
+	err := utils.PatchResource(r.Client, ctx, deployment, func() error {
+		if err := annotations.LoadSpilledChanges(r.Client, ctx, deployment); err != nil {
+			return err
+		}
+
+		// Check to which containers the trap was already deployed
+		changes, err := annotations.GetAnnotationChange(deployment, deceptionPolicy.Name)
+		if err != nil {
+			return err
+		}
+
+		var alreadyDeployedToContainers []string
+		for _, annotationTrap := range changes.Traps {
+			if annotations.AreTheSameTrap(annotationTrap, trap) {
+				alreadyDeployedToContainers = append(alreadyDeployedToContainers, annotationTrap.Containers...)
+			}
+		}
+
+		modified := false
+		for i, container := range deployment.Spec.Template.Spec.Containers {
+			if !utils.Contains(selectedContainers, container.Name) {
+				continue
+			}
+
+			// Check if the container already defines the environment variable
+			envVarExists := false
+			for _, envVar := range container.Env {
+				if envVar.Name != name {
+					continue
+				}
+
+				envVarExists = true
+				if isDecoyEnvVar(envVar, name, secretName) {
+					deployedToContainers = append(deployedToContainers, container.Name)
+				} else {
+					log.Info("Container already defines the environment variable, skipping it", "container", container.Name, "name", name)
+					conflictErrors = errors.Join(conflictErrors, fmt.Errorf("container '%s' already defines the environment variable '%s'", container.Name, name))
+				}
+				break
+			}
+
+			if !envVarExists {
+				log.Info("Adding environment variable to container", "container", container.Name, "name", name)
+				deployment.Spec.Template.Spec.Containers[i].Env = append(deployment.Spec.Template.Spec.Containers[i].Env, generateEnvVar(name, secretName))
+				deployedToContainers = append(deployedToContainers, container.Name)
+				modified = true
+			}
+		}
+
+		// Nothing to do if the trap was already deployed (and annotated) to exactly these containers
+		annotationUpToDate := len(deployedToContainers) == len(alreadyDeployedToContainers)
+		for _, containerName := range deployedToContainers {
+			annotationUpToDate = annotationUpToDate && utils.Contains(alreadyDeployedToContainers, containerName)
+		}
+		if len(deployedToContainers) == 0 || (!modified && annotationUpToDate) {
+			deployment.SetAnnotations(storedAnnotations)
+			return nil
+		}
+
+		// Annotate the deployment with the trap
+		if err := annotations.AddTrapToAnnotations(deployment, deceptionPolicy.Name, trap, deployedToContainers); err != nil {
+			return err
+		}
+
+		// Avoid exceeding the size limit of annotations
+		return annotations.SpillChanges(r.Client, ctx, deployment, r.MaxAnnotationSize)
+	})
+	if err != nil {
+		log.Error(err, "unable to patch deployment", "deployment", deployment.Name)
+		return nil, errors.Join(conflictErrors, err)
+	}
+
+	log.Info("EnvVarHoneytoken trap deployed to deployment", "deployment", deployment.Name)
+	return deployedToContainers, conflictErrors
+}
+
+// deployCaptorWithTetragon generates a Tetragon tracing policy to trace the access
+// to an environment variable honeytoken trap and applies it to the cluster.
+// If the tracing policy already exists but was changed in the meantime, it is restored (and true is returned).
+func (r *EnvVarHoneytokenReconciler) deployCaptorWithTetragon(ctx context.Context, deceptionPolicy *v1alpha1.DeceptionPolicy, trap v1alpha1.Trap) (bool, error) {
+	log := log.FromContext(ctx)
+
+	tracingPolicyName, err := filesystoken.GenerateTetragonTracingPolicyName(trap)
+	if err != nil {
+		log.Error(err, "unable to generate Tetragon tracing policy name")
+		return false, err
+	}
+
+	webhookURL, err := webhookauth.GetSignedURL(r.Client, ctx, webhookauth.WebhookURL(r.AlertWebhookHost, constants.TetragonWebhookPath), deceptionPolicy.Name)
+	if err != nil {
+		log.Error(err, "unable to sign alert forwarder webhook URL")
+		return false, err
+	}
+
+	tracingPolicy, err := generateTetragonTracingPolicy(deceptionPolicy, trap, tracingPolicyName, webhookURL)
+	if err != nil {
+		log.Error(err, "unable to generate Tetragon tracing policy")
+		return false, err
+	}
+
+	restored, err := filesystoken.ApplyTracingPolicy(r.Client, ctx, tracingPolicy)
+	if err != nil {
+		log.Error(err, "unable to apply Tetragon tracing policy")
+		return false, err
+	}
+
+	return restored, nil
+}
@@ -0,0 +1,136 @@
+// Copyright (c) 2025 Dynatrace LLC
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package envtoken
+
+import (
+	"context"
+	"errors"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/dynatrace-oss/koney/api/v1alpha1"
+	"github.com/dynatrace-oss/koney/internal/controller/annotations"
+	"github.com/dynatrace-oss/koney/internal/controller/utils"
+)
+
+// RemoveDecoy removes an EnvVarHoneytoken decoy from a deployment.
+// The environment variable is removed from all containers listed in the trap annotation, together with the annotation itself.
+func (r *EnvVarHoneytokenReconciler) RemoveDecoy(ctx context.Context, crdName string, trap v1alpha1.TrapAnnotation, resource client.Object) error {
+	log := log.FromContext(ctx)
+
+	deployment, ok := resource.(*appsv1.Deployment)
+	if !ok {
+		log.Error(nil, "EnvVarHoneytoken traps can only be removed from deployments", "resource", resource.GetName())
+		return errors.New("EnvVarHoneytoken traps can only be removed from deployments")
+	}
+
+	name := trap.EnvVarHoneytoken.Name
+	// Decoys deployed by older versions of Koney reference secrets with legacy names
+	secretNames := []string{
+		generateSecretName(name, trap.EnvVarHoneytoken.ValueHash),
+		generateLegacySecretName(name, trap.EnvVarHoneytoken.ValueHash),
+	}
+
+	if err := r.Client.Get(ctx, client.ObjectKeyFromObject(deployment), deployment); err != nil {
+		log.Error(err, "unable to get deployment", "deployment", deployment.Name)
+		return err
+	}
+
+	err := utils.PatchResource(r.Client, ctx, deployment, func() error {
+		if err := annotations.LoadSpilledChanges(r.Client, ctx, deployment); err != nil {
+			return err
+		}
+
+		// Remove the environment variable from the containers
+		for i, container := range deployment.Spec.Template.Spec.Containers {
+			if !utils.Contains(trap.Containers, container.Name) {
+				continue
+			}
+
+			newEnv := []corev1.EnvVar{}
+			for _, envVar := range container.Env {
+				if isDecoyEnvVar(envVar, name, secretNames[0]) || isDecoyEnvVar(envVar, name, secretNames[1]) {
+					log.Info("Removing environment variable from container", "container", container.Name, "name", name)
+				} else {
+					newEnv = append(newEnv, envVar)
+				}
+			}
+			deployment.Spec.Template.Spec.Containers[i].Env = newEnv
+		}
+
+		// Remove the trap from the deployment annotations
+		if err := annotations.RemoveTrapAnnotations(deployment, crdName, trap); err != nil {
+			return err
+		}
+
+		// Avoid exceeding the size limit of annotations
+		return annotations.SpillChanges(r.Client, ctx, deployment, r.MaxAnnotationSize)
+	})
+	if err != nil {
+		log.Error(err, "unable to patch deployment", "deployment", deployment.Name)
+		return err
+	}
+
+	log.Info("EnvVarHoneytoken trap removed from deployment", "deployment", deployment.Name)
+
+	// Pods cannot start if they reference a missing secret, so we only delete unused secrets
+	deployments := &appsv1.DeploymentList{}
+	if err := r.Client.List(ctx, deployments, client.InNamespace(deployment.Namespace)); err != nil {
+		return err
+	}
+
+	var joinedErrors error
+	for _, secretName := range secretNames {
+		if err := r.deleteSecretIfUnused(ctx, deployments, deployment.Namespace, secretName); err != nil {
+			joinedErrors = errors.Join(joinedErrors, err)
+		}
+	}
+
+	return joinedErrors
+}
+
+// deleteSecretIfUnused deletes the secret of an environment variable honeytoken, unless a deployment still references it.
+func (r *EnvVarHoneytokenReconciler) deleteSecretIfUnused(ctx context.Context, deployments *appsv1.DeploymentList, namespace, secretName string) error {
+	for i := range deployments.Items {
+		if isSecretReferenced(&deployments.Items[i], secretName) {
+			log.FromContext(ctx).Info("Secret is still referenced by a deployment, keeping it", "secret", secretName, "deployment", deployments.Items[i].Name)
+			return nil
+		}
+	}
+
+	secret := corev1.Secret{}
+	if err := r.Client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: secretName}, &secret); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	return client.IgnoreNotFound(r.Client.Delete(ctx, &secret))
+}
+
+// isSecretReferenced checks if any container of a deployment references the given secret in its environment.
+func isSecretReferenced(deployment *appsv1.Deployment, secretName string) bool {
+	for _, container := range deployment.Spec.Template.Spec.Containers {
+		for _, envVar := range container.Env {
+			if envVar.ValueFrom != nil && envVar.ValueFrom.SecretKeyRef != nil && envVar.ValueFrom.SecretKeyRef.Name == secretName {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,188 @@
+// Copyright (c) 2025 Dynatrace LLC
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package envtoken
+
+import (
+	slimv1 "github.com/cilium/cilium/pkg/k8s/slim/k8s/apis/meta/v1"
+	ciliumiov1alpha1 "github.com/cilium/tetragon/pkg/k8s/apis/cilium.io/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/dynatrace-oss/koney/api/v1alpha1"
+	"github.com/dynatrace-oss/koney/internal/controller/constants"
+	"github.com/dynatrace-oss/koney/internal/controller/traps/filesystoken"
+	"github.com/dynatrace-oss/koney/internal/controller/utils"
+)
+
+// networkClientBinaries are the (suffixes of) binaries that commonly receive credentials as arguments when opening outbound connections.
+var networkClientBinaries = []string{
+	"/curl",
+	"/wget",
+	"/nc",
+	"/ncat",
+	"/socat",
+	"/aws",
+	"/az",
+	"/gcloud",
+	"/gsutil",
+	"/kubectl",
+}
+
+// generateSecretName generates the name of the secret that holds the value of the environment variable.
+// The name only depends on the name of the variable and the hash of its value, so that it can also be derived from annotations.
+func generateSecretName(name, valueHash string) string {
+	return "koney-secret-" + utils.ShortHash(name+":"+valueHash)
+}
+
+// generateLegacySecretName generates the name of the secret like older versions of Koney did,
+// so that their secrets can still be removed.
+func generateLegacySecretName(name, valueHash string) string {
+	return "koney-secret-" + utils.LegacyHash(name+":"+valueHash)
+}
+
+// generateEnvVar generates the decoy environment variable, which references the value in the given secret.
+func generateEnvVar(name, secretName string) corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: name,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+				Key:                  name,
+			},
+		},
+	}
+}
+
+// isDecoyEnvVar checks if an environment variable is the decoy environment variable that references the given secret.
+func isDecoyEnvVar(envVar corev1.EnvVar, name, secretName string) bool {
+	return envVar.Name == name &&
+		envVar.ValueFrom != nil &&
+		envVar.ValueFrom.SecretKeyRef != nil &&
+		envVar.ValueFrom.SecretKeyRef.Name == secretName
+}
+
+// generateTetragonTracingPolicy generates a Tetragon tracing policy for an environment variable honeytoken trap.
+func generateTetragonTracingPolicy(deceptionPolicy *v1alpha1.DeceptionPolicy, trap v1alpha1.Trap, tracingPolicyName, webhookURL string) (*ciliumiov1alpha1.TracingPolicy, error) {
+	/*
+		Processes can read the environment variables of other processes (and their own) from `/proc/<pid>/environ`.
+		We trace such reads with the `security_file_permission` function, just like for filesystem honeytokens.
+
+		Once an attacker found the value, they will probably use it, e.g., by passing it to a command line tool
+		that sends it to some remote API. Tetragon cannot filter on process arguments in the kernel, so we trace
+		the `tcp_connect` function of well-known network clients and the alert forwarder only raises an alert
+		if the value of the environment variable appears in the arguments of the process.
+		Thus, the name and the value of the environment variable are stored in the annotations of the tracing policy.
+
+		See also:
+		- https://tetragon.io/docs/use-cases/filename-access/#hooks
+		- https://tetragon.io/docs/use-cases/network-observability/
+	*/
+	tracingPolicy := &ciliumiov1alpha1.TracingPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: tracingPolicyName,
+			Labels: map[string]string{
+				constants.LabelKeyDeceptionPolicyRef: deceptionPolicy.Name,
+			},
+			Annotations: map[string]string{
+				constants.AnnotationKeyEnvVarName:  trap.EnvVarHoneytoken.Name,
+				constants.AnnotationKeyEnvVarValue: trap.EnvVarHoneytoken.Value,
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion:         deceptionPolicy.APIVersion,
+					Kind:               deceptionPolicy.Kind,
+					Name:               deceptionPolicy.Name,
+					UID:                deceptionPolicy.UID,
+					BlockOwnerDeletion: &[]bool{true}[0], // A pointer to a bool
+					Controller:         &[]bool{true}[0],
+				},
+			},
+		},
+		Spec: ciliumiov1alpha1.TracingPolicySpec{
+			PodSelector: &slimv1.LabelSelector{
+				MatchLabels: map[string]string{},
+			},
+			ContainerSelector: &slimv1.LabelSelector{},
+			KProbes: []ciliumiov1alpha1.KProbeSpec{
+				{
+					Call:    "security_file_permission", // Reads of /proc/<pid>/environ
+					Syscall: false,
+					Return:  true,
+					Args: []ciliumiov1alpha1.KProbeArg{
+						{
+							Index: 0,
+							Type:  "file",
+						},
+					},
+					ReturnArg: &ciliumiov1alpha1.KProbeArg{
+						Index: 0,
+						Type:  "int",
+					},
+					ReturnArgAction: "Post",
+					Selectors: []ciliumiov1alpha1.KProbeSelector{
+						{
+							MatchArgs: []ciliumiov1alpha1.ArgSelector{
+								{
+									Index:    0,
+									Operator: "Postfix",
+									Values:   []string{"/environ"},
+								},
+							},
+							MatchActions: []ciliumiov1alpha1.ActionSelector{
+								{
+									Action: "GetUrl",
+									ArgUrl: webhookURL,
+								},
+							},
+						},
+					},
+				},
+				{
+					Call:    "tcp_connect", // Outbound connections of network clients
+					Syscall: false,
+					Args: []ciliumiov1alpha1.KProbeArg{
+						{
+							Index: 0,
+							Type:  "sock",
+						},
+					},
+					Selectors: []ciliumiov1alpha1.KProbeSelector{
+						{
+							MatchBinaries: []ciliumiov1alpha1.BinarySelector{
+								{
+									Operator: "Postfix",
+									Values:   networkClientBinaries,
+								},
+							},
+							MatchActions: []ciliumiov1alpha1.ActionSelector{
+								{
+									Action: "GetUrl",
+									ArgUrl: webhookURL,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if err := filesystoken.ApplyTrapSelectorsToTracingPolicy(tracingPolicy, trap); err != nil {
+		return nil, err
+	}
+
+	return tracingPolicy, nil
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2025 Dynatrace LLC
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package envtoken
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/dynatrace-oss/koney/api/v1alpha1"
+	"github.com/dynatrace-oss/koney/internal/controller/constants"
+	"github.com/dynatrace-oss/koney/internal/controller/utils"
+)
+
+var _ = Describe("EnvVarHoneytoken resources", func() {
+	var (
+		deceptionPolicy = v1alpha1.DeceptionPolicy{}
+		trap            = v1alpha1.Trap{
+			EnvVarHoneytoken: v1alpha1.EnvVarHoneytoken{
+				Name:  "AWS_SECRET_ACCESS_KEY",
+				Value: "wJalrXUtnFEMI/K7MDENG/bPxRfiCYEXAMPLEKEY",
+			},
+			DecoyDeployment: v1alpha1.DecoyDeployment{
+				Strategy: "volumeMount",
+			},
+			CaptorDeployment: v1alpha1.CaptorDeployment{
+				Strategy: "tetragon",
+			},
+			MatchResources: v1alpha1.MatchResources{
+				Any: []v1alpha1.ResourceFilter{
+					{
+						ResourceDescription: v1alpha1.ResourceDescription{
+							Selector:          &metav1.LabelSelector{MatchLabels: map[string]string{"app": "nginx"}},
+							ContainerSelector: "nginx",
+						},
+					},
+				},
+			},
+		}
+	)
+
+	BeforeEach(func() {
+		deceptionPolicy.Name = "test-deception-policy"
+	})
+
+	Context("When generating the secret name", func() {
+		It("should be derivable from the trap annotation", func() {
+			secretName := generateSecretName(trap.EnvVarHoneytoken.Name, utils.Hash(trap.EnvVarHoneytoken.Value))
+			Expect(secretName).To(HavePrefix("koney-secret-"))
+
+			otherSecretName := generateSecretName(trap.EnvVarHoneytoken.Name, utils.Hash("some-other-value"))
+			Expect(otherSecretName).ToNot(Equal(secretName))
+		})
+	})
+
+	Context("When generating the environment variable", func() {
+		It("should reference the value in the secret", func() {
+			envVar := generateEnvVar("AWS_SECRET_ACCESS_KEY", "koney-secret-abc")
+			Expect(envVar.Name).To(Equal("AWS_SECRET_ACCESS_KEY"))
+			Expect(envVar.Value).To(BeEmpty())
+			Expect(envVar.ValueFrom.SecretKeyRef.Name).To(Equal("koney-secret-abc"))
+			Expect(envVar.ValueFrom.SecretKeyRef.Key).To(Equal("AWS_SECRET_ACCESS_KEY"))
+
+			Expect(isDecoyEnvVar(envVar, "AWS_SECRET_ACCESS_KEY", "koney-secret-abc")).To(BeTrue())
+			Expect(isDecoyEnvVar(envVar, "AWS_SECRET_ACCESS_KEY", "koney-secret-def")).To(BeFalse())
+
+			applicationEnvVar := corev1.EnvVar{Name: "AWS_SECRET_ACCESS_KEY", Value: "real-secret"}
+			Expect(isDecoyEnvVar(applicationEnvVar, "AWS_SECRET_ACCESS_KEY", "koney-secret-abc")).To(BeFalse())
+		})
+	})
+
+	Context("When generating the Tetragon TracingPolicy", func() {
+		It("should trace environ reads and outbound connections of the matched containers", func() {
+			tracingPolicy, err := generateTetragonTracingPolicy(&deceptionPolicy, trap, "test-tracing-policy", constants.TetragonWebhookUrl)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tracingPolicy.Name).To(Equal("test-tracing-policy"))
+			Expect(tracingPolicy.Labels).To(HaveKeyWithValue(constants.LabelKeyDeceptionPolicyRef, "test-deception-policy"))
+			Expect(tracingPolicy.Annotations).To(HaveKeyWithValue(constants.AnnotationKeyEnvVarName, trap.EnvVarHoneytoken.Name))
+			Expect(tracingPolicy.Annotations).To(HaveKeyWithValue(constants.AnnotationKeyEnvVarValue, trap.EnvVarHoneytoken.Value))
+			Expect(tracingPolicy.Spec.PodSelector.MatchLabels).To(Equal(map[string]string{"app": "nginx"}))
+			Expect(tracingPolicy.Spec.ContainerSelector.MatchExpressions).To(HaveLen(1))
+			Expect(tracingPolicy.Spec.ContainerSelector.MatchExpressions[0].Values).To(Equal([]string{"nginx"}))
+
+			Expect(tracingPolicy.Spec.KProbes).To(HaveLen(2))
+			Expect(tracingPolicy.Spec.KProbes[0].Call).To(Equal("security_file_permission"))
+			Expect(tracingPolicy.Spec.KProbes[0].Selectors[0].MatchArgs[0].Values).To(Equal([]string{"/environ"}))
+			Expect(tracingPolicy.Spec.KProbes[1].Call).To(Equal("tcp_connect"))
+			Expect(tracingPolicy.Spec.KProbes[1].Selectors[0].MatchBinaries[0].Values).To(ContainElement("/curl"))
+		})
+	})
+})
@@ -23,7 +23,6 @@ import (
 	"path/filepath"
 	"strings"
 
-	ciliumiov1alpha1 "github.com/cilium/tetragon/pkg/k8s/apis/cilium.io/v1alpha1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -33,15 +32,18 @@ import (
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/remotecommand"
-	"k8s.io/client-go/util/retry"
+	utilexec "k8s.io/client-go/util/exec"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	"github.com/dynatrace-oss/koney/api/v1alpha1"
 	"github.com/dynatrace-oss/koney/internal/controller/annotations"
+	"github.com/dynatrace-oss/koney/internal/controller/audit"
+	"github.com/dynatrace-oss/koney/internal/controller/constants"
 	"github.com/dynatrace-oss/koney/internal/controller/matching"
 	trapsapi "github.com/dynatrace-oss/koney/internal/controller/traps/api"
 	"github.com/dynatrace-oss/koney/internal/controller/utils"
+	"github.com/dynatrace-oss/koney/internal/controller/webhookauth"
 )
 
 type FilesystemHoneytokenReconciler struct {
@@ -50,6 +52,25 @@ type FilesystemHoneytokenReconciler struct {
 	Clientset kubernetes.Clientset
 	Config    rest.Config
 
+	// MaxAnnotationSize is the maximum size of the changes annotation, before changes are spilled into a ConfigMap.
+	MaxAnnotationSize int
+
+	// FalcoNamespace is the namespace where the Falco rules ConfigMap is placed for the falco captor strategy.
+	FalcoNamespace string
+
+	// AlertWebhookHost is the host of the alert forwarder that captors send alerts to (see webhookauth.WebhookURL).
+	AlertWebhookHost string
+
+	// ExcludedNamespaces are namespaces where no decoys are deployed, even if the trap matches resources there.
+	ExcludedNamespaces []string
+
+	// MaxConcurrentDeployments is the maximum number of resources that decoys are deployed to at the same time.
+	MaxConcurrentDeployments int
+
+	// VerifyDecoys checks if decoys deployed with the containerExec strategy are still in place,
+	// and redeploys them if they were removed or changed.
+	VerifyDecoys bool
+
 	DeceptionPolicy *v1alpha1.DeceptionPolicy
 }
 
@@ -66,45 +87,93 @@ func (r *FilesystemHoneytokenReconciler) DeployDecoy(ctx context.Context, decept
 		filterCreatedAfter = deceptionPolicy.CreationTimestamp
 	}
 
-	// Get matching resources and the matched containers: pods for containerExec, deployments for volumeMount
-	matchingResult, err := matching.GetDeployableObjectsWithContainers(r, ctx, trap, &filterCreatedAfter)
+	// Get matching resources and the matched containers: pods for containerExec, deployments for volumeMount and initContainer
+	matchingResult, err := matching.GetDeployableObjectsWithContainers(r, ctx, trap, &filterCreatedAfter, r.ExcludedNamespaces)
 	if err != nil {
 		log.Error(err, "unable to get matching resources")
 		// wrap error with message "unable to get matching resources"
-		return trapsapi.DecoyDeploymentResult{Errors: errors.Join(err, errors.New("unable to get matching resources"))}
+		return trapsapi.DecoyDeploymentResult{Trap: &trap, Errors: errors.Join(err, errors.New("unable to get matching resources"))}
 	} else if len(matchingResult.DeployableObjects) == 0 {
 		return trapsapi.DecoyDeploymentResult{
+			Trap:                        &trap,
 			AtLeastOneObjectsWasMatched: matchingResult.AtLeastOneObjectWasMatched,
-			AllObjectsWereReady:         matchingResult.AllDeployableObjectsWereReady}
+			AllObjectsWereReady:         matchingResult.AllDeployableObjectsWereReady,
+			Outcomes:                    trapsapi.NotReadyOutcomes(matchingResult.NotReadyObjects),
+			SkippedNamespaces:           matchingResult.SkippedNamespaces}
 	}
 
-	// Deploy the trap to the matching resources
-	for resource, selectedContainers := range matchingResult.DeployableObjects {
-		// Check if the trap was already deployed to the resource (and to which containers)
-		// Get the resource's changes annotation
-		changes, err := annotations.GetAnnotationChange(resource, deceptionPolicy.Name) // Empty if the annotation does not exist
-		if err != nil {
-			log.Error(err, "unable to get annotation changes")
-			joinedErrors = errors.Join(joinedErrors, err)
-			continue
-		}
+	// Deploy the trap to the matching resources, with a bounded number of resources at the same time
+	resources := utils.GetMapKeys(matchingResult.DeployableObjects)
+	resourceOutcomes := utils.ForEachConcurrently(resources, r.MaxConcurrentDeployments, func(resource client.Object) trapsapi.ObjectOutcome {
+		return r.deployDecoyToResource(ctx, deceptionPolicy, trap, resource, matchingResult.DeployableObjects[resource])
+	})
+
+	outcomes := trapsapi.NotReadyOutcomes(matchingResult.NotReadyObjects)
+	for _, outcome := range resourceOutcomes {
+		joinedErrors = errors.Join(joinedErrors, outcome.Error)
+		outcomes = append(outcomes, outcome)
+	}
 
-		var alreadyDeployedToContainers []string // Containers where the trap was already deployed
-		var deployedToContainers []string        // Containers where at the end of the function the trap is deployed to
+	return trapsapi.DecoyDeploymentResult{
+		Trap:                        &trap,
+		AtLeastOneObjectsWasMatched: matchingResult.AtLeastOneObjectWasMatched,
+		AllObjectsWereReady:         matchingResult.AllDeployableObjectsWereReady,
+		Outcomes:                    outcomes,
+		SkippedNamespaces:           matchingResult.SkippedNamespaces,
+		Errors:                      joinedErrors}
+}
 
-		// Cycle through the traps in the annotation
-		for _, annotationTrap := range changes.Traps {
-			// Are areTheSameTrap checks if two traps are the same, ignoring the containers field
-			// since Trap does not have a list of containers, but only a containerSelector
-			if annotations.AreTheSameTrap(annotationTrap, trap) {
-				// The trap was already deployed to the containers in the annotation
-				alreadyDeployedToContainers = append(alreadyDeployedToContainers, annotationTrap.Containers...)
-			}
+// deployDecoyToResource deploys a FilesystemHoneytoken decoy to the selected containers of a single resource (pod or deployment).
+// It is safe to call this function for several resources at the same time.
+func (r *FilesystemHoneytokenReconciler) deployDecoyToResource(ctx context.Context, deceptionPolicy *v1alpha1.DeceptionPolicy, trap v1alpha1.Trap, resource client.Object, selectedContainers []string) trapsapi.ObjectOutcome {
+	log := log.FromContext(ctx)
+
+	outcome := trapsapi.NewObjectOutcome(resource)
+
+	// Check if the trap was already deployed to the resource (and to which containers)
+	// Get the resource's changes annotation (including the changes that were spilled into a ConfigMap)
+	if err := annotations.LoadSpilledChanges(r.Client, ctx, resource); err != nil {
+		log.Error(err, "unable to load spilled annotation changes")
+		outcome.Error = err
+		return outcome
+	}
+	changes, err := annotations.GetAnnotationChange(resource, deceptionPolicy.Name) // Empty if the annotation does not exist
+	if err != nil {
+		log.Error(err, "unable to get annotation changes")
+		outcome.Error = err
+		return outcome
+	}
+
+	var resourceErrors error // Errors that happened while deploying the trap to this resource
+
+	var alreadyDeployedToContainers []string // Containers where the trap was already deployed
+	var deployedToContainers []string        // Containers where at the end of the function the trap is deployed to
+
+	// Cycle through the traps in the annotation
+	for _, annotationTrap := range changes.Traps {
+		// Are areTheSameTrap checks if two traps are the same, ignoring the containers field
+		// since Trap does not have a list of containers, but only a containerSelector
+		if annotations.AreTheSameTrap(annotationTrap, trap) {
+			// The trap was already deployed to the containers in the annotation
+			alreadyDeployedToContainers = append(alreadyDeployedToContainers, annotationTrap.Containers...)
 		}
+	}
 
-		// Deploy the trap to the selected container(s)
-		for _, containerName := range selectedContainers {
-			if utils.Contains(alreadyDeployedToContainers, containerName) {
+	// Deploy the trap to the selected container(s)
+	for _, containerName := range selectedContainers {
+		if utils.Contains(alreadyDeployedToContainers, containerName) {
+			state := decoyIntact
+			if pod, ok := resource.(*corev1.Pod); ok && r.VerifyDecoys && trap.DecoyDeployment.Strategy == "containerExec" {
+				if state, err = r.verifyDecoyWithContainerExec(ctx, trap, *pod, containerName); err != nil {
+					// We cannot tell if the decoy is still there, so we leave it as it is
+					log.Error(err, "unable to verify FilesystemHoneytoken trap in container", "resource", resource.GetName(), "container", containerName)
+					resourceErrors = errors.Join(resourceErrors, err)
+					state = decoyIntact
+				}
+			}
+
+			switch state {
+			case decoyIntact:
 				log.Info("FilesystemHoneytoken trap already deployed to container", "resource", resource.GetName(), "container", containerName)
 
 				// We need to add it here regardless to update the annotation
@@ -112,46 +181,67 @@ func (r *FilesystemHoneytokenReconciler) DeployDecoy(ctx context.Context, decept
 				// this will not add containers where the trap was already deployed but that do not exist anymore
 				deployedToContainers = append(deployedToContainers, containerName)
 				continue
+			case decoyMissing:
+				log.Info("FilesystemHoneytoken trap was removed from container - redeploying", "resource", resource.GetName(), "container", containerName)
+			case decoyTampered:
+				log.Info("FilesystemHoneytoken trap was changed in container - redeploying", "resource", resource.GetName(), "container", containerName)
+				outcome.Tampered = append(outcome.Tampered, containerName)
 			}
+		}
 
-			// Deploy the trap to the container
-			switch trap.DecoyDeployment.Strategy {
-			case "containerExec":
-				// The containerExec strategy deploys the honeytoken directly to containers inside a pod
-				if pod, ok := resource.(*corev1.Pod); ok {
-					if err := r.deployDecoyWithContainerExec(ctx, trap, *pod, containerName); err != nil {
-						log.Error(err, "unable to deploy FilesystemHoneytoken trap to container with containerExec strategy", "container", containerName)
-						joinedErrors = errors.Join(joinedErrors, err)
-					} else {
-						deployedToContainers = append(deployedToContainers, containerName)
-					}
+		// Deploy the trap to the container
+		switch trap.DecoyDeployment.Strategy {
+		case "containerExec":
+			// The containerExec strategy deploys the honeytoken directly to containers inside a pod
+			if pod, ok := resource.(*corev1.Pod); ok {
+				if err := r.deployDecoyWithContainerExec(ctx, trap, *pod, containerName); errors.Is(err, ErrShellNotAvailable) {
+					// Retrying does not help, the container will never have a shell
+					outcome.Unsupported = append(outcome.Unsupported, containerName)
+				} else if err != nil {
+					log.Error(err, "unable to deploy FilesystemHoneytoken trap to container with containerExec strategy", "container", containerName)
+					resourceErrors = errors.Join(resourceErrors, err)
+				} else {
+					deployedToContainers = append(deployedToContainers, containerName)
 				}
+			}
 
-			case "volumeMount":
-				// The volumeMount strategy deploys the honeytoken mounting a volume in the deployment to the containers
-				if deployment, ok := resource.(*appsv1.Deployment); ok {
-					if err := r.deployDecoyWithVolumeMount(ctx, trap, *deployment, containerName); err != nil {
-						log.Error(err, "unable to deploy FilesystemHoneytoken trap to container with volumeMount strategy", "container", containerName)
-						joinedErrors = errors.Join(joinedErrors, err)
-					} else {
-						deployedToContainers = append(deployedToContainers, containerName)
-					}
+		case "volumeMount":
+			// The volumeMount strategy deploys the honeytoken mounting a volume in the deployment to the containers
+			if deployment, ok := resource.(*appsv1.Deployment); ok {
+				if err := r.deployDecoyWithVolumeMount(ctx, trap, *deployment, containerName); err != nil {
+					log.Error(err, "unable to deploy FilesystemHoneytoken trap to container with volumeMount strategy", "container", containerName)
+					resourceErrors = errors.Join(resourceErrors, err)
+				} else {
+					deployedToContainers = append(deployedToContainers, containerName)
 				}
+			}
 
-			case "kyvernoPolicy":
-				log.Info("KyvernoPolicy strategy not implemented yet")
-				joinedErrors = errors.Join(joinedErrors, errors.New("KyvernoPolicy strategy not implemented yet"))
-			default:
-				log.Error(nil, "unknown strategy", "strategy", trap.DecoyDeployment.Strategy)
-				joinedErrors = errors.Join(joinedErrors, errors.New("unknown strategy"))
+		case "initContainer":
+			// The initContainer strategy copies the honeytoken into a volume of the deployment while the pods start
+			if deployment, ok := resource.(*appsv1.Deployment); ok {
+				if err := r.deployDecoyWithInitContainer(ctx, trap, *deployment, containerName); err != nil {
+					log.Error(err, "unable to deploy FilesystemHoneytoken trap to container with initContainer strategy", "container", containerName)
+					resourceErrors = errors.Join(resourceErrors, err)
+				} else {
+					deployedToContainers = append(deployedToContainers, containerName)
+				}
 			}
+
+		case "kyvernoPolicy":
+			log.Info("KyvernoPolicy strategy not implemented yet")
+			resourceErrors = errors.Join(resourceErrors, errors.New("KyvernoPolicy strategy not implemented yet"))
+		default:
+			log.Error(nil, "unknown strategy", "strategy", trap.DecoyDeployment.Strategy)
+			resourceErrors = errors.Join(resourceErrors, errors.New("unknown strategy"))
 		}
+	}
 
-		// Annotate the pod with the trap
-		if len(deployedToContainers) > 0 {
-			// Use RetryOnConflict to elegantly avoid conflicts when updating a resource
-			err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
-				if err := r.Client.Get(ctx, client.ObjectKeyFromObject(resource), resource); err != nil {
+	// Annotate the pod with the trap
+	if len(deployedToContainers) > 0 {
+		err := r.Client.Get(ctx, client.ObjectKeyFromObject(resource), resource)
+		if err == nil {
+			err = utils.PatchResource(r.Client, ctx, resource, func() error {
+				if err := annotations.LoadSpilledChanges(r.Client, ctx, resource); err != nil {
 					return err
 				}
 
@@ -159,23 +249,25 @@ func (r *FilesystemHoneytokenReconciler) DeployDecoy(ctx context.Context, decept
 				err := annotations.AddTrapToAnnotations(resource, deceptionPolicy.Name, trap, deployedToContainers)
 				if err != nil {
 					log.Error(err, "unable to add trap to resource annotations", "resource", resource.GetName())
-					joinedErrors = errors.Join(joinedErrors, err)
+					resourceErrors = errors.Join(resourceErrors, err)
 				}
 
-				// TODO: Can we use patch instead of update to avoid conflicts?
-				return r.Client.Update(ctx, resource)
+				// Avoid exceeding the size limit of annotations
+				return annotations.SpillChanges(r.Client, ctx, resource, r.MaxAnnotationSize)
 			})
-			if err != nil {
-				log.Error(err, "unable to update resource", "resource", resource.GetName())
-				joinedErrors = errors.Join(joinedErrors, err)
-			}
+		}
+		if err != nil {
+			log.Error(err, "unable to update resource", "resource", resource.GetName())
+			resourceErrors = errors.Join(resourceErrors, err)
 		}
 	}
 
-	return trapsapi.DecoyDeploymentResult{
-		AtLeastOneObjectsWasMatched: matchingResult.AtLeastOneObjectWasMatched,
-		AllObjectsWereReady:         matchingResult.AllDeployableObjectsWereReady,
-		Errors:                      joinedErrors}
+	outcome.Containers = deployedToContainers
+	outcome.Error = resourceErrors
+	if len(deployedToContainers) == 0 && len(outcome.Unsupported) > 0 && resourceErrors == nil {
+		outcome.SkippedReason = trapsapi.SkippedReasonUnsupported
+	}
+	return outcome
 }
 
 // DeployCaptor deploys a captor for a filesystem honeytoken trap.
@@ -184,13 +276,21 @@ func (r *FilesystemHoneytokenReconciler) DeployCaptor(ctx context.Context, decep
 
 	switch trap.CaptorDeployment.Strategy {
 	case "tetragon":
-		if err := r.deployCaptorWithTetragon(ctx, deceptionPolicy, trap); err != nil {
+		restored, err := r.deployCaptorWithTetragon(ctx, deceptionPolicy, trap)
+		if err != nil {
 			missingTetragon := errors.Is(err, &meta.NoKindMatchError{})
 			if missingTetragon {
 				log.Error(nil, "Tetragon is not installed - cannot deploy captors with Tetragon")
 			}
 			return trapsapi.CaptorDeploymentResult{Trap: &trap, Errors: err, MissingTetragon: missingTetragon}
 		}
+		return trapsapi.CaptorDeploymentResult{Trap: &trap, Restored: restored}
+	case "falco":
+		if err := r.deployCaptorWithFalco(ctx, deceptionPolicy); err != nil {
+			return trapsapi.CaptorDeploymentResult{Trap: &trap, Errors: err}
+		}
+	case "sidecar":
+		// Sidecar captors are injected together with the decoys, see deployDecoyWithVolumeMount and deployDecoyWithInitContainer
 	default:
 		log.Error(nil, fmt.Sprintf("captor deployment strategy '%s' unknown", trap.CaptorDeployment.Strategy))
 		return trapsapi.CaptorDeploymentResult{Trap: &trap, Errors: errors.New("captor deployment strategy unknown")}
@@ -210,8 +310,13 @@ func (r *FilesystemHoneytokenReconciler) deployDecoyWithContainerExec(ctx contex
 	// Create the directory if it doesn't exist
 	directory := trap.FilesystemHoneytoken.FilePath[:strings.LastIndex(trap.FilesystemHoneytoken.FilePath, "/")]
 	cmd = []string{"mkdir", "-p", directory}
-	_, err := r.executeCommandInContainer(ctx, pod, containerName, cmd)
+	output, err := r.executeCommandInContainer(ctx, pod, containerName, cmd)
 	if err != nil {
+		if isExecutableNotFound(err, output) {
+			// Distroless images come without mkdir and sh, so we cannot write the decoy into the container
+			log.Info("Container has no shell - cannot deploy FilesystemHoneytoken trap with containerExec strategy", "container", containerName)
+			return ErrShellNotAvailable
+		}
 		log.Error(err, "unable to create directory with mkdir in container", "directory", directory, "container", containerName)
 		joinedErrors = errors.Join(joinedErrors, err)
 
@@ -237,8 +342,12 @@ func (r *FilesystemHoneytokenReconciler) deployDecoyWithContainerExec(ctx contex
 	}
 
 	// Use ExecCMDInContainer to execute the command in the container
-	output, err := r.executeCommandInContainer(ctx, pod, containerName, cmd)
+	output, err = r.executeCommandInContainer(ctx, pod, containerName, cmd)
 	if err != nil {
+		if isExecutableNotFound(err, output) {
+			log.Info("Container has no shell - cannot deploy FilesystemHoneytoken trap with containerExec strategy", "container", containerName)
+			return ErrShellNotAvailable
+		}
 		log.Error(err, "unable to deploy FilesystemHoneytoken trap to container", "container", containerName, "stderr", output)
 		// We don't return here to try to deploy the trap to the other containers
 		joinedErrors = errors.Join(joinedErrors, err)
@@ -251,7 +360,7 @@ func (r *FilesystemHoneytokenReconciler) deployDecoyWithContainerExec(ctx contex
 		if err != nil {
 			log.Error(err, "unable to read the content of the file", "container", containerName)
 			joinedErrors = errors.Join(joinedErrors, err)
-		} else if strings.TrimSuffix(output, "\n") != strings.TrimSuffix(trap.FilesystemHoneytoken.FileContent, "\n") { // TrimSuffix removes the trailing newline
+		} else if !decoyContentMatches(output, trap.FilesystemHoneytoken.FileContent) {
 			log.Error(nil, "the content of the file is not the expected content", "container", containerName, "expected", trap.FilesystemHoneytoken.FileContent, "actual", output)
 			joinedErrors = errors.Join(joinedErrors, errors.New("the content of the file is not the expected content"))
 		} else {
@@ -271,6 +380,34 @@ func (r *FilesystemHoneytokenReconciler) deployDecoyWithContainerExec(ctx contex
 	return joinedErrors
 }
 
+// verifyDecoyWithContainerExec checks if a FilesystemHoneytoken trap that was deployed with the containerExec strategy
+// is still in place, i.e., the file exists and has the expected content. The file is read with the Koney fingerprint,
+// so that the verification does not raise alerts.
+func (r *FilesystemHoneytokenReconciler) verifyDecoyWithContainerExec(ctx context.Context, trap v1alpha1.Trap, pod corev1.Pod, containerName string) (decoyState, error) {
+	filePath := trap.FilesystemHoneytoken.FilePath
+	catFingerprint := utils.EncodeFingerprintInCat(utils.KoneyFingerprint)
+
+	cmd := []string{"sh", "-c", fmt.Sprintf("[ -f \"%s\" ] || exit %d; cat %s \"%s\"", filePath, decoyMissingExitCode, catFingerprint, filePath)}
+	output, err := r.executeCommandInContainer(ctx, pod, containerName, cmd)
+	if err != nil {
+		var exitErr utilexec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitStatus() == decoyMissingExitCode {
+			return decoyMissing, nil
+		}
+		log.FromContext(ctx).Error(err, "unable to read the content of the file", "container", containerName, "stderr", output)
+		return decoyIntact, err
+	}
+
+	if !decoyContentMatches(output, trap.FilesystemHoneytoken.FileContent) {
+		// Only log hashes, so that the content of the decoy does not end up in the logs
+		log.FromContext(ctx).Info("the content of the file is not the expected content", "container", containerName,
+			"expectedHash", utils.Hash(trap.FilesystemHoneytoken.FileContent), "actualHash", utils.Hash(output))
+		return decoyTampered, nil
+	}
+
+	return decoyIntact, nil
+}
+
 // deployDecoyWithVolumeMount deploys a FilesystemHoneytoken trap to
 // a list of deployments using the volumeMount strategy.
 // The trap is only deployed to the pods where the trap is not already deployed.
@@ -292,7 +429,7 @@ func (r *FilesystemHoneytokenReconciler) deployDecoyWithVolumeMount(ctx context.
 		fileName: []byte(trap.FilesystemHoneytoken.FileContent),
 	}
 
-	if err := createSecret(r.Client, ctx, deployment.Namespace, secretName, data); err != nil {
+	if err := CreateSecret(r.Client, ctx, deployment.Namespace, secretName, data); err != nil {
 		log.Error(err, "unable to create secret", "secret", secretName)
 		joinedErrors = errors.Join(joinedErrors, err)
 
@@ -304,65 +441,194 @@ func (r *FilesystemHoneytokenReconciler) deployDecoyWithVolumeMount(ctx context.
 	// since there cannot be two volumes mounted to the same path with different content
 	volumeName := generateVolumeName(trap.FilesystemHoneytoken.FilePath)
 
-	// Get the pod
+	// Sidecar captors watch the decoy from within the pod, so they are injected together with the decoy
+	sidecar, err := r.generateSidecarCaptor(ctx, trap)
+	if err != nil {
+		return errors.Join(joinedErrors, err)
+	}
+
+	// Get the deployment
 	if err := r.Client.Get(ctx, client.ObjectKeyFromObject(&deployment), &deployment); err != nil {
 		log.Error(err, "unable to get deployment", "deployment", deployment.Name)
-		joinedErrors = errors.Join(joinedErrors, err)
+		return errors.Join(joinedErrors, err)
 	}
 
-	// Check if the volume is already configured to the deployment
-	volumeAlreadyConfigured := false
-	for _, volume := range deployment.Spec.Template.Spec.Volumes {
-		if volume.Name == volumeName {
-			volumeAlreadyConfigured = true
-			break
+	err = utils.PatchResource(r.Client, ctx, &deployment, func() error {
+		// Check if the volume is already configured to the deployment
+		volumeAlreadyConfigured := false
+		for _, volume := range deployment.Spec.Template.Spec.Volumes {
+			if volume.Name == volumeName {
+				volumeAlreadyConfigured = true
+				break
+			}
 		}
-	}
 
-	if volumeAlreadyConfigured {
-		log.Info("Volume already configured", "volume", volumeName)
-	} else {
-		// Add the volume to the deployment
-		deployment.Spec.Template.Spec.Volumes = append(deployment.Spec.Template.Spec.Volumes, corev1.Volume{
-			Name: volumeName,
-			VolumeSource: corev1.VolumeSource{
-				Secret: &corev1.SecretVolumeSource{
-					SecretName: secretName,
+		if volumeAlreadyConfigured {
+			log.Info("Volume already configured", "volume", volumeName)
+		} else {
+			// Add the volume to the deployment
+			deployment.Spec.Template.Spec.Volumes = append(deployment.Spec.Template.Spec.Volumes, corev1.Volume{
+				Name: volumeName,
+				VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{
+						SecretName: secretName,
+					},
 				},
-			},
-		})
-	}
-
-	// Add the volume mount to the container
-	for i, container := range deployment.Spec.Template.Spec.Containers {
-		if container.Name == containerName {
-			// Check if the volume is already mounted
-			volumeAlreadyMounted := false
-			for _, volumeMount := range deployment.Spec.Template.Spec.Containers[i].VolumeMounts {
-				if volumeMount.Name == volumeName {
-					volumeAlreadyMounted = true
-					break
+			})
+		}
+
+		// Add the volume mount to the container
+		for i, container := range deployment.Spec.Template.Spec.Containers {
+			if container.Name == containerName {
+				// Check if the volume is already mounted
+				volumeAlreadyMounted := false
+				for _, volumeMount := range deployment.Spec.Template.Spec.Containers[i].VolumeMounts {
+					if volumeMount.Name == volumeName {
+						volumeAlreadyMounted = true
+						break
+					}
 				}
-			}
 
-			if !volumeAlreadyMounted {
-				log.Info("Adding volume mount to container", "container", containerName, "volume", volumeName, "mountPath", mountPath)
-				deployment.Spec.Template.Spec.Containers[i].VolumeMounts = append(deployment.Spec.Template.Spec.Containers[i].VolumeMounts, corev1.VolumeMount{
-					Name:      volumeName,
-					MountPath: trap.FilesystemHoneytoken.FilePath,
-					ReadOnly:  trap.FilesystemHoneytoken.ReadOnly,
-					SubPath:   fileName,
-				})
+				if !volumeAlreadyMounted {
+					log.Info("Adding volume mount to container", "container", containerName, "volume", volumeName, "mountPath", mountPath)
+					deployment.Spec.Template.Spec.Containers[i].VolumeMounts = append(deployment.Spec.Template.Spec.Containers[i].VolumeMounts, corev1.VolumeMount{
+						Name:      volumeName,
+						MountPath: trap.FilesystemHoneytoken.FilePath,
+						ReadOnly:  trap.FilesystemHoneytoken.ReadOnly,
+						SubPath:   fileName,
+					})
+				}
 			}
 		}
+
+		injectSidecarCaptor(ctx, &deployment, sidecar)
+
+		return nil
+	})
+	if err != nil {
+		log.Error(err, "unable to patch deployment", "deployment", deployment.Name)
+		joinedErrors = errors.Join(joinedErrors, err)
+	} else {
+		log.Info("FilesystemHoneytoken trap deployed to container", "container", containerName)
+	}
+
+	return joinedErrors
+}
+
+// deployDecoyWithInitContainer deploys a FilesystemHoneytoken trap to a deployment using the initContainer strategy.
+// An init container copies the decoy from a Secret into an emptyDir volume, which is mounted into the container.
+// Unlike containerExec, this works for containers with read-only root filesystems or without a shell.
+func (r *FilesystemHoneytokenReconciler) deployDecoyWithInitContainer(ctx context.Context, trap v1alpha1.Trap, deployment appsv1.Deployment, containerName string) error {
+	log := log.FromContext(ctx)
+
+	var joinedErrors error
+
+	filePath := trap.FilesystemHoneytoken.FilePath
+	_, fileName := filepath.Split(filePath)
+	if fileName == "" {
+		log.Error(nil, "file path must point to a file", "file path", filePath)
+		return errors.New("file path must point to a file")
+	}
+
+	// The init container reads the content of the decoy from a Secret, just like the volumeMount strategy
+	secretName := generateSecretName(trap)
+	data := map[string][]byte{
+		fileName: []byte(trap.FilesystemHoneytoken.FileContent),
 	}
 
-	err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
-		// TODO: Can we use patch instead of update to avoid conflicts?
-		return r.Client.Update(ctx, &deployment)
+	if err := CreateSecret(r.Client, ctx, deployment.Namespace, secretName, data); err != nil {
+		log.Error(err, "unable to create secret", "secret", secretName)
+		return errors.Join(joinedErrors, err)
+	}
+
+	sourceVolumeName := generateSourceVolumeName(filePath)
+	volumeName := generateVolumeName(filePath)
+	initContainer := generateDecoyInitContainer(trap)
+
+	// Sidecar captors watch the emptyDir volume, so they see the decoy just like with the volumeMount strategy
+	sidecar, err := r.generateSidecarCaptor(ctx, trap)
+	if err != nil {
+		return errors.Join(joinedErrors, err)
+	}
+
+	// Get the deployment
+	if err := r.Client.Get(ctx, client.ObjectKeyFromObject(&deployment), &deployment); err != nil {
+		log.Error(err, "unable to get deployment", "deployment", deployment.Name)
+		return errors.Join(joinedErrors, err)
+	}
+
+	err = utils.PatchResource(r.Client, ctx, &deployment, func() error {
+		podSpec := &deployment.Spec.Template.Spec
+
+		// Add the Secret volume that the init container copies from, and the emptyDir volume that it copies to
+		configuredVolumes := map[string]bool{}
+		for _, volume := range podSpec.Volumes {
+			configuredVolumes[volume.Name] = true
+		}
+
+		if !configuredVolumes[sourceVolumeName] {
+			podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+				Name: sourceVolumeName,
+				VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{
+						SecretName: secretName,
+					},
+				},
+			})
+		}
+
+		if !configuredVolumes[volumeName] {
+			podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+				Name: volumeName,
+				VolumeSource: corev1.VolumeSource{
+					EmptyDir: &corev1.EmptyDirVolumeSource{},
+				},
+			})
+		}
+
+		// Add the init container, unless another container of the deployment already needed it
+		initContainerAlreadyInjected := false
+		for _, container := range podSpec.InitContainers {
+			if container.Name == initContainer.Name {
+				initContainerAlreadyInjected = true
+				break
+			}
+		}
+
+		if !initContainerAlreadyInjected {
+			log.Info("Adding init container to deployment", "deployment", deployment.Name, "container", initContainer.Name)
+			podSpec.InitContainers = append(podSpec.InitContainers, initContainer)
+		}
+
+		// Add the volume mount to the container
+		for i, container := range podSpec.Containers {
+			if container.Name == containerName {
+				volumeAlreadyMounted := false
+				for _, volumeMount := range container.VolumeMounts {
+					if volumeMount.Name == volumeName {
+						volumeAlreadyMounted = true
+						break
+					}
+				}
+
+				if !volumeAlreadyMounted {
+					log.Info("Adding volume mount to container", "container", containerName, "volume", volumeName, "mountPath", filePath)
+					podSpec.Containers[i].VolumeMounts = append(podSpec.Containers[i].VolumeMounts, corev1.VolumeMount{
+						Name:      volumeName,
+						MountPath: filePath,
+						ReadOnly:  trap.FilesystemHoneytoken.ReadOnly,
+						SubPath:   fileName,
+					})
+				}
+			}
+		}
+
+		injectSidecarCaptor(ctx, &deployment, sidecar)
+
+		return nil
 	})
 	if err != nil {
-		log.Error(err, "unable to update deployment", "deployment", deployment.Name)
+		log.Error(err, "unable to patch deployment", "deployment", deployment.Name)
 		joinedErrors = errors.Join(joinedErrors, err)
 	} else {
 		log.Info("FilesystemHoneytoken trap deployed to container", "container", containerName)
@@ -371,43 +637,88 @@ func (r *FilesystemHoneytokenReconciler) deployDecoyWithVolumeMount(ctx context.
 	return joinedErrors
 }
 
+// generateSidecarCaptor generates the sidecar captor of a trap, if it is monitored with the sidecar strategy.
+// If the trap is monitored differently, no sidecar captor is returned.
+func (r *FilesystemHoneytokenReconciler) generateSidecarCaptor(ctx context.Context, trap v1alpha1.Trap) (*corev1.Container, error) {
+	log := log.FromContext(ctx)
+
+	if trap.CaptorDeployment.Strategy != "sidecar" {
+		return nil, nil
+	}
+
+	webhookURL, err := webhookauth.GetSignedURL(r.Client, ctx, webhookauth.WebhookURL(r.AlertWebhookHost, constants.SidecarWebhookPath), r.DeceptionPolicy.Name)
+	if err != nil {
+		log.Error(err, "unable to sign alert forwarder webhook URL")
+		return nil, err
+	}
+
+	sidecar, err := generateSidecarCaptorContainer(r.DeceptionPolicy.Name, trap, webhookURL)
+	if err != nil {
+		log.Error(err, "unable to generate sidecar captor")
+		return nil, err
+	}
+
+	return &sidecar, nil
+}
+
+// injectSidecarCaptor adds a sidecar captor to a deployment, unless it was already injected (or there is none).
+func injectSidecarCaptor(ctx context.Context, deployment *appsv1.Deployment, sidecar *corev1.Container) {
+	if sidecar == nil {
+		return
+	}
+
+	for _, container := range deployment.Spec.Template.Spec.Containers {
+		if container.Name == sidecar.Name {
+			return
+		}
+	}
+
+	log.FromContext(ctx).Info("Adding sidecar captor to deployment", "deployment", deployment.Name, "container", sidecar.Name)
+	deployment.Spec.Template.Spec.Containers = append(deployment.Spec.Template.Spec.Containers, *sidecar)
+}
+
 // deployCaptorWithTetragon generates a Tetragon tracing policy
 // to trace the filesystem access of a filesystem honeytoken trap and applies it to the cluster.
-func (r *FilesystemHoneytokenReconciler) deployCaptorWithTetragon(ctx context.Context, deceptionPolicy *v1alpha1.DeceptionPolicy, trap v1alpha1.Trap) error {
+// If the tracing policy already exists but was changed in the meantime, it is restored (and true is returned).
+func (r *FilesystemHoneytokenReconciler) deployCaptorWithTetragon(ctx context.Context, deceptionPolicy *v1alpha1.DeceptionPolicy, trap v1alpha1.Trap) (bool, error) {
 	log := log.FromContext(ctx)
 
 	tracingPolicyName, err := GenerateTetragonTracingPolicyName(trap)
 	if err != nil {
 		log.Error(err, "unable to generate Tetragon tracing policy name")
-		return err
+		return false, err
 	}
 
-	// Get the Tetragon tracing policy if it already exists
-	// If the tracing policy already exists, we don't need to do anything
-	// since the name is unique for each unique trap
-	existingTracingPolicy := &ciliumiov1alpha1.TracingPolicy{}
-	err = r.Client.Get(ctx, client.ObjectKey{Name: tracingPolicyName}, existingTracingPolicy)
+	webhookURL, err := webhookauth.GetSignedURL(r.Client, ctx, webhookauth.WebhookURL(r.AlertWebhookHost, constants.TetragonWebhookPath), deceptionPolicy.Name)
+	if err != nil {
+		log.Error(err, "unable to sign alert forwarder webhook URL")
+		return false, err
+	}
 
-	// If the policy does not exist, err is not nil and is a NotFound error
+	tracingPolicy, err := generateTetragonTracingPolicy(deceptionPolicy, trap, tracingPolicyName, webhookURL)
 	if err != nil {
-		// If the policy does not exist, we create it
-		if client.IgnoreNotFound(err) != nil {
-			log.Error(err, "unable to get Tetragon tracing policy")
-			return err
-		}
+		log.Error(err, "unable to generate Tetragon tracing policy")
+		return false, err
+	}
 
-		tracingPolicy, err := generateTetragonTracingPolicy(deceptionPolicy, trap, tracingPolicyName)
-		if err != nil {
-			log.Error(err, "unable to generate Tetragon tracing policy")
-			return err
-		}
+	// The name is unique for each unique trap, so an existing tracing policy only needs to be restored if it was changed
+	restored, err := ApplyTracingPolicy(r.Client, ctx, tracingPolicy)
+	if err != nil {
+		log.Error(err, "unable to apply Tetragon tracing policy")
+		return false, err
+	}
 
-		if err := r.Client.Create(ctx, tracingPolicy); err != nil {
-			log.Error(err, "unable to create Tetragon tracing policy")
-			return err
-		}
+	return restored, nil
+}
 
-		log.Info("Tetragon tracing policy created", "policy", tracingPolicy)
+// deployCaptorWithFalco renders the Falco rules of all filesystem honeytoken traps
+// of the deception policy and writes them into the Falco rules ConfigMap.
+func (r *FilesystemHoneytokenReconciler) deployCaptorWithFalco(ctx context.Context, deceptionPolicy *v1alpha1.DeceptionPolicy) error {
+	log := log.FromContext(ctx)
+
+	if err := SyncFalcoRules(r.Client, ctx, r.FalcoNamespace, deceptionPolicy); err != nil {
+		log.Error(err, "unable to write Falco rules", "namespace", r.FalcoNamespace, "configMap", constants.FalcoRulesConfigMapName)
+		return err
 	}
 
 	return nil
@@ -433,6 +744,7 @@ func (r *FilesystemHoneytokenReconciler) executeCommandInContainer(ctx context.C
 
 	exec, err := remotecommand.NewSPDYExecutor(&r.Config, "POST", req.URL())
 	if err != nil {
+		audit.RecordExec(r.Client, ctx, pod, containerName, cmd, err)
 		return "", err
 	}
 
@@ -444,6 +756,7 @@ func (r *FilesystemHoneytokenReconciler) executeCommandInContainer(ctx context.C
 		Stdout: &stdout,
 		Stderr: &stderr,
 	})
+	audit.RecordExec(r.Client, ctx, pod, containerName, cmd, err)
 	if err != nil {
 		return stderr.String(), err
 	}
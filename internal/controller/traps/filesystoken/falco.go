@@ -0,0 +1,225 @@
+// Copyright (c) 2025 Dynatrace LLC
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package filesystoken
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/dynatrace-oss/koney/api/v1alpha1"
+	"github.com/dynatrace-oss/koney/internal/controller/constants"
+	"github.com/dynatrace-oss/koney/internal/controller/matching"
+	"github.com/dynatrace-oss/koney/internal/controller/utils"
+)
+
+// falcoFileOpenCondition matches successful file-open system calls.
+// Unlike the open_read macro of the default Falco rules, it also matches files opened for writing.
+const falcoFileOpenCondition = "evt.type in (open, openat, openat2) and evt.dir = < and fd.num >= 0"
+
+// falcoRuleOutput is the output of Falco rules. The alert forwarder reads the referenced fields from the JSON output.
+const falcoRuleOutput = "Access to Koney honeytoken detected (file=%fd.name k8s_ns=%k8s.ns.name k8s_pod=%k8s.pod.name " +
+	"container=%container.name container_id=%container.id exepath=%proc.exepath cmdline=%proc.cmdline " +
+	"pid=%proc.pid uid=%user.uid cwd=%proc.cwd)"
+
+// falcoRule is a single rule of a Falco rules file.
+type falcoRule struct {
+	Rule      string   `json:"rule"`
+	Desc      string   `json:"desc"`
+	Condition string   `json:"condition"`
+	Output    string   `json:"output"`
+	Priority  string   `json:"priority"`
+	Tags      []string `json:"tags"`
+}
+
+// GenerateFalcoRulesKey generates the key in the Falco rules ConfigMap that holds the rules of a deception policy.
+func GenerateFalcoRulesKey(deceptionPolicyName string) string {
+	return deceptionPolicyName + ".yaml"
+}
+
+// GenerateFalcoRules renders a Falco rules file with one rule for each valid filesystem honeytoken trap
+// of a deception policy that is monitored with the falco strategy.
+// If no trap is monitored with Falco, an empty string is returned.
+func GenerateFalcoRules(deceptionPolicy *v1alpha1.DeceptionPolicy) (string, error) {
+	rules := []falcoRule{}
+	for _, trap := range deceptionPolicy.Spec.Traps {
+		if trap.CaptorDeployment.Strategy != "falco" || trap.TrapType() != v1alpha1.FilesystemHoneytokenTrap || trap.IsValid() != nil {
+			continue
+		}
+
+		rule, err := generateFalcoRule(deceptionPolicy.Name, trap)
+		if err != nil {
+			return "", err
+		}
+		rules = append(rules, rule)
+	}
+
+	if len(rules) == 0 {
+		return "", nil
+	}
+
+	rulesYAML, err := yaml.Marshal(rules)
+	if err != nil {
+		return "", err
+	}
+
+	return string(rulesYAML), nil
+}
+
+// SyncFalcoRules writes the Falco rules of a deception policy into the Falco rules ConfigMap.
+// Rules of traps that were removed from the deception policy are dropped in the process.
+func SyncFalcoRules(c client.Client, ctx context.Context, namespace string, deceptionPolicy *v1alpha1.DeceptionPolicy) error {
+	rules, err := GenerateFalcoRules(deceptionPolicy)
+	if err != nil {
+		return err
+	}
+
+	return writeFalcoRules(c, ctx, namespace, GenerateFalcoRulesKey(deceptionPolicy.Name), rules)
+}
+
+// PruneFalcoRules removes the Falco rules of a deception policy from the Falco rules ConfigMap,
+// but only if none of its traps is monitored with Falco anymore.
+func PruneFalcoRules(c client.Client, ctx context.Context, namespace string, deceptionPolicy *v1alpha1.DeceptionPolicy) error {
+	rules, err := GenerateFalcoRules(deceptionPolicy)
+	if err != nil || rules != "" {
+		return err
+	}
+
+	return RemoveFalcoRules(c, ctx, namespace, deceptionPolicy.Name)
+}
+
+// RemoveFalcoRules removes the Falco rules of a deception policy from the Falco rules ConfigMap.
+func RemoveFalcoRules(c client.Client, ctx context.Context, namespace, deceptionPolicyName string) error {
+	return writeFalcoRules(c, ctx, namespace, GenerateFalcoRulesKey(deceptionPolicyName), "")
+}
+
+// writeFalcoRules sets a key in the Falco rules ConfigMap, or removes the key if the rules are empty.
+// The ConfigMap is created if it does not exist yet, but it is never deleted, since Falco mounts it.
+func writeFalcoRules(c client.Client, ctx context.Context, namespace, key, rules string) error {
+	configMap := &corev1.ConfigMap{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: constants.FalcoRulesConfigMapName}, configMap); err != nil {
+		if client.IgnoreNotFound(err) != nil || rules == "" {
+			return client.IgnoreNotFound(err)
+		}
+
+		configMap = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      constants.FalcoRulesConfigMapName,
+				Namespace: namespace,
+			},
+			Data: map[string]string{key: rules},
+		}
+
+		return c.Create(ctx, configMap)
+	}
+
+	// Nothing to do if the rules are already up-to-date (or already removed)
+	if configMap.Data[key] == rules {
+		return nil
+	}
+
+	// The patch only touches the key of this deception policy, so policies do not conflict with each other
+	return utils.PatchResource(c, ctx, configMap, func() error {
+		if rules == "" {
+			delete(configMap.Data, key)
+		} else {
+			if configMap.Data == nil {
+				configMap.Data = map[string]string{}
+			}
+			configMap.Data[key] = rules
+		}
+		return nil
+	})
+}
+
+// generateFalcoRule generates a Falco rule that detects when the file of a filesystem honeytoken trap is opened.
+func generateFalcoRule(deceptionPolicyName string, trap v1alpha1.Trap) (falcoRule, error) {
+	trapJSON, err := captorTrapJSON(trap)
+	if err != nil {
+		return falcoRule{}, err
+	}
+
+	return falcoRule{
+		// Rule names must be unique across all rules files loaded by Falco
+		Rule:      fmt.Sprintf("Koney filesystem honeytoken %s/%s", deceptionPolicyName, utils.ShortHash(string(trapJSON))),
+		Desc:      fmt.Sprintf("Detects access to the honeytoken %s of the deception policy %s", trap.FilesystemHoneytoken.FilePath, deceptionPolicyName),
+		Condition: generateFalcoCondition(trap),
+		Output:    falcoRuleOutput,
+		Priority:  "WARNING",
+		Tags:      []string{"koney", constants.FalcoTagDeceptionPolicyRef + deceptionPolicyName},
+	}, nil
+}
+
+// generateFalcoCondition generates the condition of a Falco rule for a filesystem honeytoken trap.
+// Each resource filter of the trap is translated into a sub-condition on namespaces, pod labels, and container names.
+func generateFalcoCondition(trap v1alpha1.Trap) string {
+	condition := falcoFileOpenCondition + " and fd.name = " + quoteFalcoString(trap.FilesystemHoneytoken.FilePath)
+
+	filters := []string{}
+	for _, resourceFilter := range trap.MatchResources.Any {
+		parts := []string{}
+
+		if len(resourceFilter.Namespaces) > 0 {
+			namespaces := make([]string, len(resourceFilter.Namespaces))
+			for i, namespace := range resourceFilter.Namespaces {
+				namespaces[i] = quoteFalcoString(namespace)
+			}
+			parts = append(parts, "k8s.ns.name in ("+strings.Join(namespaces, ", ")+")")
+		}
+
+		if resourceFilter.Selector != nil {
+			// Sort the labels, so that the rules do not change between reconciliations
+			keys := make([]string, 0, len(resourceFilter.Selector.MatchLabels))
+			for key := range resourceFilter.Selector.MatchLabels {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+
+			for _, key := range keys {
+				parts = append(parts, fmt.Sprintf("k8s.pod.label[%s] = %s", key, quoteFalcoString(resourceFilter.Selector.MatchLabels[key])))
+			}
+		}
+
+		// Falco supports the same wildcards in globs as we do in container selectors
+		if !matching.ContainerSelectorSelectsAll(resourceFilter.ContainerSelector) {
+			parts = append(parts, "container.name glob "+quoteFalcoString(resourceFilter.ContainerSelector))
+		}
+
+		// A resource filter without restrictions matches all containers
+		if len(parts) == 0 {
+			return condition
+		}
+
+		filters = append(filters, "("+strings.Join(parts, " and ")+")")
+	}
+
+	if len(filters) > 0 {
+		condition += " and (" + strings.Join(filters, " or ") + ")"
+	}
+
+	return condition
+}
+
+// quoteFalcoString quotes a string for use in the condition of a Falco rule.
+func quoteFalcoString(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value) + `"`
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2025 Dynatrace LLC
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package filesystoken
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/dynatrace-oss/koney/api/v1alpha1"
+	"github.com/dynatrace-oss/koney/internal/controller/constants"
+)
+
+var _ = Describe("generateFalcoCondition", func() {
+	falcoTrap := func(filters ...v1alpha1.ResourceFilter) v1alpha1.Trap {
+		return v1alpha1.Trap{
+			FilesystemHoneytoken: v1alpha1.FilesystemHoneytoken{
+				FilePath:    "/run/secrets/koney/service_token",
+				FileContent: "someverysecrettoken",
+			},
+			CaptorDeployment: v1alpha1.CaptorDeployment{Strategy: "falco"},
+			MatchResources:   v1alpha1.MatchResources{Any: filters},
+		}
+	}
+
+	Context("With a trap that matches namespaces, labels, and containers", func() {
+		It("should restrict the condition to the matched containers", func() {
+			trap := falcoTrap(v1alpha1.ResourceFilter{
+				ResourceDescription: v1alpha1.ResourceDescription{
+					Namespaces:        []string{"koney", "demo"},
+					Selector:          &metav1.LabelSelector{MatchLabels: map[string]string{"key2": "value2", "key1": "value1"}},
+					ContainerSelector: "web-*",
+				},
+			})
+
+			Expect(generateFalcoCondition(trap)).To(Equal(falcoFileOpenCondition +
+				` and fd.name = "/run/secrets/koney/service_token"` +
+				` and ((k8s.ns.name in ("koney", "demo") and k8s.pod.label[key1] = "value1" and k8s.pod.label[key2] = "value2" and container.name glob "web-*"))`))
+		})
+	})
+
+	Context("With a trap that has multiple resource filters", func() {
+		It("should combine the resource filters with or", func() {
+			trap := falcoTrap(
+				v1alpha1.ResourceFilter{ResourceDescription: v1alpha1.ResourceDescription{Namespaces: []string{"koney"}, ContainerSelector: "*"}},
+				v1alpha1.ResourceFilter{ResourceDescription: v1alpha1.ResourceDescription{Namespaces: []string{"demo"}, ContainerSelector: "app"}},
+			)
+
+			Expect(generateFalcoCondition(trap)).To(HaveSuffix(
+				` and ((k8s.ns.name in ("koney")) or (k8s.ns.name in ("demo") and container.name glob "app"))`))
+		})
+	})
+
+	Context("With a file path that contains quotes", func() {
+		It("should escape the file path", func() {
+			trap := falcoTrap(v1alpha1.ResourceFilter{ResourceDescription: v1alpha1.ResourceDescription{Namespaces: []string{"koney"}}})
+			trap.FilesystemHoneytoken.FilePath = `/tmp/"quoted"\token`
+
+			Expect(generateFalcoCondition(trap)).To(ContainSubstring(`fd.name = "/tmp/\"quoted\"\\token"`))
+		})
+	})
+})
+
+var _ = Describe("GenerateFalcoRules", func() {
+	matchResources := v1alpha1.MatchResources{
+		Any: []v1alpha1.ResourceFilter{{ResourceDescription: v1alpha1.ResourceDescription{Namespaces: []string{"koney"}}}},
+	}
+
+	Context("With a deception policy that monitors some traps with Falco", func() {
+		It("should render one rule for each trap monitored with Falco", func() {
+			deceptionPolicy := &v1alpha1.DeceptionPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "deceptionpolicy-falco"},
+				Spec: v1alpha1.DeceptionPolicySpec{
+					Traps: []v1alpha1.Trap{
+						{
+							FilesystemHoneytoken: v1alpha1.FilesystemHoneytoken{FilePath: "/run/secrets/koney/falco", FileContent: "token"},
+							CaptorDeployment:     v1alpha1.CaptorDeployment{Strategy: "falco"},
+							MatchResources:       matchResources,
+						},
+						{
+							FilesystemHoneytoken: v1alpha1.FilesystemHoneytoken{FilePath: "/run/secrets/koney/tetragon", FileContent: "token"},
+							CaptorDeployment:     v1alpha1.CaptorDeployment{Strategy: "tetragon"},
+							MatchResources:       matchResources,
+						},
+					},
+				},
+			}
+
+			rulesYAML, err := GenerateFalcoRules(deceptionPolicy)
+			Expect(err).NotTo(HaveOccurred())
+
+			rules := []falcoRule{}
+			Expect(yaml.Unmarshal([]byte(rulesYAML), &rules)).To(Succeed())
+			Expect(rules).To(HaveLen(1))
+			Expect(rules[0].Rule).To(HavePrefix("Koney filesystem honeytoken deceptionpolicy-falco/"))
+			Expect(rules[0].Condition).To(ContainSubstring(`fd.name = "/run/secrets/koney/falco"`))
+			Expect(rules[0].Tags).To(ContainElement(constants.FalcoTagDeceptionPolicyRef + "deceptionpolicy-falco"))
+		})
+	})
+
+	Context("With a deception policy that monitors no traps with Falco", func() {
+		It("should render no rules", func() {
+			deceptionPolicy := &v1alpha1.DeceptionPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "deceptionpolicy-tetragon"},
+				Spec: v1alpha1.DeceptionPolicySpec{
+					Traps: []v1alpha1.Trap{
+						{
+							FilesystemHoneytoken: v1alpha1.FilesystemHoneytoken{FilePath: "/run/secrets/koney/tetragon", FileContent: "token"},
+							CaptorDeployment:     v1alpha1.CaptorDeployment{Strategy: "tetragon"},
+							MatchResources:       matchResources,
+						},
+					},
+				},
+			}
+
+			Expect(GenerateFalcoRules(deceptionPolicy)).To(BeEmpty())
+		})
+	})
+})
@@ -22,7 +22,6 @@ import (
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
@@ -51,7 +50,7 @@ func (r *FilesystemHoneytokenReconciler) RemoveDecoy(ctx context.Context, crdNam
 				removedFromContainers = append(removedFromContainers, containerName)
 			}
 
-		case "volumeMount":
+		case "volumeMount", "initContainer":
 			deployment := resource.(*appsv1.Deployment)
 			if err := r.removeDecoyWithVolumeMount(ctx, trap, *deployment, containerName); err != nil {
 				log.Error(err, "unable to remove FilesystemHoneytoken trap from container", "container", containerName)
@@ -73,25 +72,26 @@ func (r *FilesystemHoneytokenReconciler) RemoveDecoy(ctx context.Context, crdNam
 
 	// If the file was removed from all containers, remove the trap from the pod annotations
 	if len(removedFromContainers) == len(trap.Containers) {
-		// Use RetryOnConflict to elegantly avoid conflicts when updating a resource
-		// as explained in https://github.com/kubernetes-sigs/controller-runtime/issues/1748
-		err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
-			if err := r.Client.Get(ctx, client.ObjectKeyFromObject(resource), resource); err != nil {
-				return err
-			}
+		err := r.Client.Get(ctx, client.ObjectKeyFromObject(resource), resource)
+		if err == nil {
+			err = utils.PatchResource(r.Client, ctx, resource, func() error {
+				if err := annotations.LoadSpilledChanges(r.Client, ctx, resource); err != nil {
+					return err
+				}
 
-			// Remove the trap from the pod annotations
-			err := annotations.RemoveTrapAnnotations(resource, crdName, trap)
-			if err != nil {
-				log.Error(err, "unable to remove trap from resource annotations", "resource", resource.GetName())
-				joinedErrors = errors.Join(joinedErrors, err)
-			}
+				// Remove the trap from the pod annotations
+				err := annotations.RemoveTrapAnnotations(resource, crdName, trap)
+				if err != nil {
+					log.Error(err, "unable to remove trap from resource annotations", "resource", resource.GetName())
+					joinedErrors = errors.Join(joinedErrors, err)
+				}
 
-			// TODO: Can we use patch instead of update to avoid conflicts?
-			return r.Client.Update(ctx, resource)
-		})
+				// Avoid exceeding the size limit of annotations
+				return annotations.SpillChanges(r.Client, ctx, resource, r.MaxAnnotationSize)
+			})
+		}
 		if err != nil {
-			log.Error(err, "unable to update resource", "resource", resource.GetName())
+			log.Error(err, "unable to patch resource", "resource", resource.GetName())
 			joinedErrors = errors.Join(joinedErrors, err)
 		}
 	} else {
@@ -103,24 +103,26 @@ func (r *FilesystemHoneytokenReconciler) RemoveDecoy(ctx context.Context, crdNam
 			}
 		}
 
-		// Use RetryOnConflict to elegantly avoid conflicts when updating a resource
-		err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
-			if err := r.Client.Get(ctx, client.ObjectKeyFromObject(resource), resource); err != nil {
-				return err
-			}
+		err := r.Client.Get(ctx, client.ObjectKeyFromObject(resource), resource)
+		if err == nil {
+			err = utils.PatchResource(r.Client, ctx, resource, func() error {
+				if err := annotations.LoadSpilledChanges(r.Client, ctx, resource); err != nil {
+					return err
+				}
 
-			// Update the trap in the pod annotations
-			err := annotations.UpdateContainersInAnnotations(resource, crdName, trap, containersWithTrap)
-			if err != nil {
-				log.Error(err, "unable to update trap in resource annotations", "resource", resource.GetName())
-				joinedErrors = errors.Join(joinedErrors, err)
-			}
+				// Update the trap in the pod annotations
+				err := annotations.UpdateContainersInAnnotations(resource, crdName, trap, containersWithTrap)
+				if err != nil {
+					log.Error(err, "unable to update trap in resource annotations", "resource", resource.GetName())
+					joinedErrors = errors.Join(joinedErrors, err)
+				}
 
-			// TODO: Can we use patch instead of update to avoid conflicts?
-			return r.Client.Update(ctx, resource)
-		})
+				// Avoid exceeding the size limit of annotations
+				return annotations.SpillChanges(r.Client, ctx, resource, r.MaxAnnotationSize)
+			})
+		}
 		if err != nil {
-			log.Error(err, "unable to update resource", "resource", resource.GetName())
+			log.Error(err, "unable to patch resource", "resource", resource.GetName())
 			joinedErrors = errors.Join(joinedErrors, err)
 		}
 	}
@@ -161,58 +163,91 @@ func (r *FilesystemHoneytokenReconciler) removeDecoyWithContainerExec(ctx contex
 }
 
 // removeDecoyWithVolumeMount removes a FilesystemHoneytoken trap a deployment using the volumeMount strategy.
+// It also removes traps deployed with the initContainer strategy, together with their init containers.
 func (r *FilesystemHoneytokenReconciler) removeDecoyWithVolumeMount(ctx context.Context, trap v1alpha1.TrapAnnotation, deployment appsv1.Deployment, containerName string) error {
 	log := log.FromContext(ctx)
 
 	var joinedErrors error
 
-	volumeName := generateVolumeName(trap.FilesystemHoneytoken.FilePath)
-	secretName := ""
+	// Decoys deployed by older versions of Koney use volumes and sidecar captors with legacy names
+	filePath := trap.FilesystemHoneytoken.FilePath
+	volumeNames := []string{generateVolumeName(filePath), generateLegacyVolumeName(filePath), generateSourceVolumeName(filePath)}
+	initContainerName := generateDecoyInitContainerName(filePath)
+	sidecarNames := []string{generateSidecarCaptorName(filePath), generateLegacySidecarCaptorName(filePath)}
+	secretNames := []string{}
+
+	// Get the latest version of the deployment, without the changes that were loaded from its companion ConfigMap
+	if err := r.Client.Get(ctx, client.ObjectKeyFromObject(&deployment), &deployment); err != nil {
+		log.Error(err, "unable to get deployment", "deployment", deployment.Name)
+		return errors.Join(joinedErrors, err)
+	}
 
-	// Remove the volume mount from the container
-	for i, container := range deployment.Spec.Template.Spec.Containers {
-		if container.Name == containerName {
-			newVolumeMounts := []corev1.VolumeMount{}
+	err := utils.PatchResource(r.Client, ctx, &deployment, func() error {
+		// Remove the volume mount from the container
+		for i, container := range deployment.Spec.Template.Spec.Containers {
+			if container.Name == containerName {
+				newVolumeMounts := []corev1.VolumeMount{}
 
-			// Remove the volume mount from the container
-			for j, volumeMount := range deployment.Spec.Template.Spec.Containers[i].VolumeMounts {
-				if volumeMount.Name != volumeName {
-					newVolumeMounts = append(newVolumeMounts, deployment.Spec.Template.Spec.Containers[i].VolumeMounts[j])
-				} else {
-					log.Info("Removing volume mount from container", "volume", volumeName, "container", containerName)
+				// Remove the volume mount from the container
+				for j, volumeMount := range deployment.Spec.Template.Spec.Containers[i].VolumeMounts {
+					if !utils.Contains(volumeNames, volumeMount.Name) {
+						newVolumeMounts = append(newVolumeMounts, deployment.Spec.Template.Spec.Containers[i].VolumeMounts[j])
+					} else {
+						log.Info("Removing volume mount from container", "volume", volumeMount.Name, "container", containerName)
+					}
 				}
+
+				deployment.Spec.Template.Spec.Containers[i].VolumeMounts = newVolumeMounts
 			}
+		}
 
-			deployment.Spec.Template.Spec.Containers[i].VolumeMounts = newVolumeMounts
+		// Remove the sidecar captor that watches the volume, if there is one
+		newContainers := []corev1.Container{}
+		for i, container := range deployment.Spec.Template.Spec.Containers {
+			if !utils.Contains(sidecarNames, container.Name) {
+				newContainers = append(newContainers, deployment.Spec.Template.Spec.Containers[i])
+			} else {
+				log.Info("Removing sidecar captor from deployment", "container", container.Name)
+			}
 		}
-	}
+		deployment.Spec.Template.Spec.Containers = newContainers
 
-	// Remove the volume from the deployment
-	newVolumes := []corev1.Volume{}
-	for i, volume := range deployment.Spec.Template.Spec.Volumes {
-		if volume.Name != volumeName {
-			newVolumes = append(newVolumes, deployment.Spec.Template.Spec.Volumes[i])
-		} else {
-			secretName = volume.VolumeSource.Secret.SecretName
-			log.Info("Removing volume from deployment", "volume", volumeName)
+		// Remove the init container that copies the decoy, if there is one
+		newInitContainers := []corev1.Container{}
+		for i, container := range deployment.Spec.Template.Spec.InitContainers {
+			if container.Name != initContainerName {
+				newInitContainers = append(newInitContainers, deployment.Spec.Template.Spec.InitContainers[i])
+			} else {
+				log.Info("Removing init container from deployment", "container", container.Name)
+			}
 		}
-	}
-	deployment.Spec.Template.Spec.Volumes = newVolumes
+		deployment.Spec.Template.Spec.InitContainers = newInitContainers
+
+		// Remove the volume from the deployment
+		newVolumes := []corev1.Volume{}
+		for i, volume := range deployment.Spec.Template.Spec.Volumes {
+			if !utils.Contains(volumeNames, volume.Name) {
+				newVolumes = append(newVolumes, deployment.Spec.Template.Spec.Volumes[i])
+			} else {
+				if volume.VolumeSource.Secret != nil {
+					secretNames = append(secretNames, volume.VolumeSource.Secret.SecretName)
+				}
+				log.Info("Removing volume from deployment", "volume", volume.Name)
+			}
+		}
+		deployment.Spec.Template.Spec.Volumes = newVolumes
 
-	// Use RetryOnConflict to elegantly avoid conflicts when updating a resource
-	err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
-		// TODO: Can we use patch instead of update to avoid conflicts?
-		return r.Client.Update(ctx, &deployment)
+		return nil
 	})
 	if err != nil {
-		log.Error(err, "unable to update pod", "pod", deployment.Name)
+		log.Error(err, "unable to patch deployment", "deployment", deployment.Name)
 		joinedErrors = errors.Join(joinedErrors, err)
 	} else {
 		log.Info("FilesystemHoneytoken trap removed from container", "container", containerName)
 	}
 
-	// Delete the secret, if it was created by the trap
-	if secretName != "" {
+	// Delete the secrets, if they were created by the trap
+	for _, secretName := range secretNames {
 		secret := corev1.Secret{}
 		err = r.Client.Get(ctx, client.ObjectKey{Namespace: deployment.Namespace, Name: secretName}, &secret)
 		if err != nil {
@@ -18,14 +18,21 @@ package filesystoken
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"path"
+	"path/filepath"
 	"regexp"
+	"strings"
 
 	slimv1 "github.com/cilium/cilium/pkg/k8s/slim/k8s/apis/meta/v1"
 	ciliumiov1alpha1 "github.com/cilium/tetragon/pkg/k8s/apis/cilium.io/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	"github.com/dynatrace-oss/koney/api/v1alpha1"
 	"github.com/dynatrace-oss/koney/internal/controller/constants"
@@ -33,19 +40,59 @@ import (
 	"github.com/dynatrace-oss/koney/internal/controller/utils"
 )
 
+// decoyState describes whether a deployed decoy is still in place.
+type decoyState int
+
+const (
+	decoyIntact   decoyState = iota // The decoy exists and has the expected content
+	decoyMissing                    // The decoy does not exist anymore
+	decoyTampered                   // The decoy exists, but its content was changed
+)
+
+// decoyMissingExitCode is the exit code of the verification command if the decoy does not exist.
+const decoyMissingExitCode = 3
+
+// ErrShellNotAvailable is returned if a decoy cannot be deployed with the containerExec strategy,
+// because the container has no shell (or other basic tools), e.g., because it runs a distroless image.
+var ErrShellNotAvailable = errors.New("container has no shell - the containerExec strategy is not supported")
+
+// isExecutableNotFound checks if a command failed because its executable does not exist in the container.
+// The container runtime only reports this in the error message of the exec request, e.g.,
+// `exec: "sh": executable file not found in $PATH`.
+func isExecutableNotFound(err error, stderr string) bool {
+	return strings.Contains(err.Error(), "executable file not found") || strings.Contains(stderr, "executable file not found")
+}
+
+// decoyContentMatches checks if the content read from a decoy is the expected content, ignoring a trailing newline.
+func decoyContentMatches(actual, expected string) bool {
+	return strings.TrimSuffix(actual, "\n") == strings.TrimSuffix(expected, "\n")
+}
+
 // GenerateTetragonTracingPolicyName generates the name of a Tetragon tracing policy based on the trap.
 func GenerateTetragonTracingPolicyName(trap v1alpha1.Trap) (string, error) {
-	trapJSON, err := json.Marshal(trap)
+	trapJSON, err := captorTrapJSON(trap)
 	if err != nil {
 		return "", err
 	}
 
-	return "koney-tracing-policy-" + utils.Hash(string(trapJSON)), nil
+	return "koney-tracing-policy-" + utils.ShortHash(string(trapJSON)), nil
+}
+
+// captorTrapJSON marshals the trap for deriving the names of its captors.
+// Captors of filesystem honeytokens only watch the file path, so the content is left out,
+// which keeps the captors as they are when the content changes (e.g., when it is rotated).
+func captorTrapJSON(trap v1alpha1.Trap) ([]byte, error) {
+	if trap.TrapType() == v1alpha1.FilesystemHoneytokenTrap {
+		trap.FilesystemHoneytoken.FileContent = ""
+		trap.FilesystemHoneytoken.FileContentFrom = nil
+	}
+
+	return json.Marshal(trap)
 }
 
-// createSecret creates a secret in the same namespace as the resource with the given name and data.
+// CreateSecret creates a secret in the same namespace as the resource with the given name and data.
 // The function does nothing if the secret already exists.
-func createSecret(c client.Client, ctx context.Context, namespace, secretName string, data map[string][]byte) error {
+func CreateSecret(c client.Client, ctx context.Context, namespace, secretName string, data map[string][]byte) error {
 	// Check if the secret already exists
 	secret := corev1.Secret{}
 	if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: secretName}, &secret); err != nil {
@@ -64,9 +111,10 @@ func createSecret(c client.Client, ctx context.Context, namespace, secretName st
 			Data: data,
 		}
 
-		return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
-			return c.Create(ctx, &secret)
-		})
+		// Decoys are deployed to several resources at the same time, which might share the secret
+		if err := c.Create(ctx, &secret); !apierrors.IsAlreadyExists(err) {
+			return err
+		}
 	}
 
 	return nil
@@ -79,7 +127,7 @@ func generateSecretName(trap v1alpha1.Trap) string {
 	switch trap.TrapType() {
 	case v1alpha1.FilesystemHoneytokenTrap:
 		// The hash is calculated over the trap's filePath and fileContent
-		suffix = utils.Hash(trap.FilesystemHoneytoken.FilePath + ":" + trap.FilesystemHoneytoken.FileContent)
+		suffix = utils.ShortHash(trap.FilesystemHoneytoken.FilePath + ":" + trap.FilesystemHoneytoken.FileContent)
 	case v1alpha1.HttpEndpointTrap:
 		suffix = "" // TODO: Implement.
 	case v1alpha1.HttpPayloadTrap:
@@ -93,11 +141,151 @@ func generateSecretName(trap v1alpha1.Trap) string {
 
 // generateVolumeName generates the name of a volume based on the filePath.
 func generateVolumeName(filePath string) string {
-	return "koney-volume-" + utils.Hash(filePath)
+	return "koney-volume-" + utils.ShortHash(filePath)
+}
+
+// generateLegacyVolumeName generates the name of a volume like older versions of Koney did,
+// so that their volumes can still be removed.
+func generateLegacyVolumeName(filePath string) string {
+	return "koney-volume-" + utils.LegacyHash(filePath)
+}
+
+// sidecarCaptorMountPath is the path where sidecar captors mount the volume of the decoy.
+const sidecarCaptorMountPath = "/koney/decoy"
+
+// sidecarCaptorScript watches the decoy with inotifyd and reports every time it is opened to the alert forwarder.
+// The trap is passed as JSON object in KONEY_TRAP, the pod is resolved with the downward API.
+const sidecarCaptorScript = `inotifyd - "$KONEY_WATCH_PATH:r" | while read -r event file; do
+  wget -q -O /dev/null --header "Content-Type: application/json" \
+    --post-data "{\"trap\":$KONEY_TRAP,\"pod\":\"$POD_NAME\",\"namespace\":\"$POD_NAMESPACE\",\"node\":\"$NODE_NAME\"}" \
+    "$KONEY_WEBHOOK_URL" || true
+done`
+
+// generateSidecarCaptorName generates the name of a sidecar captor container based on the filePath.
+func generateSidecarCaptorName(filePath string) string {
+	return constants.SidecarCaptorNamePrefix + utils.ShortHash(filePath)
+}
+
+// generateLegacySidecarCaptorName generates the name of a sidecar captor container like older versions of Koney did,
+// so that their sidecar captors can still be removed.
+func generateLegacySidecarCaptorName(filePath string) string {
+	return constants.SidecarCaptorNamePrefix + utils.LegacyHash(filePath)
+}
+
+// generateSidecarCaptorContainer generates a sidecar container that watches the decoy of a filesystem honeytoken trap.
+// The container mounts the same volume as the decoy, so it sees every access to the file from other containers of the pod.
+func generateSidecarCaptorContainer(deceptionPolicyName string, trap v1alpha1.Trap, webhookURL string) (corev1.Container, error) {
+	_, fileName := filepath.Split(trap.FilesystemHoneytoken.FilePath)
+
+	trapJSON, err := json.Marshal(map[string]string{
+		"deception_policy_name": deceptionPolicyName,
+		"file_path":             trap.FilesystemHoneytoken.FilePath,
+	})
+	if err != nil {
+		return corev1.Container{}, err
+	}
+
+	fieldRef := func(fieldPath string) *corev1.EnvVarSource {
+		return &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: fieldPath}}
+	}
+
+	return corev1.Container{
+		Name:    generateSidecarCaptorName(trap.FilesystemHoneytoken.FilePath),
+		Image:   constants.SidecarCaptorImage,
+		Command: []string{"sh", "-c", sidecarCaptorScript},
+		Env: []corev1.EnvVar{
+			{Name: "KONEY_WATCH_PATH", Value: path.Join(sidecarCaptorMountPath, fileName)},
+			{Name: "KONEY_TRAP", Value: string(trapJSON)},
+			{Name: "KONEY_WEBHOOK_URL", Value: webhookURL},
+			{Name: "POD_NAME", ValueFrom: fieldRef("metadata.name")},
+			{Name: "POD_NAMESPACE", ValueFrom: fieldRef("metadata.namespace")},
+			{Name: "NODE_NAME", ValueFrom: fieldRef("spec.nodeName")},
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      generateVolumeName(trap.FilesystemHoneytoken.FilePath),
+				MountPath: sidecarCaptorMountPath,
+				ReadOnly:  true,
+			},
+		},
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("5m"),
+				corev1.ResourceMemory: resource.MustParse("8Mi"),
+			},
+			Limits: corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("32Mi"),
+			},
+		},
+		SecurityContext: &corev1.SecurityContext{
+			RunAsNonRoot:             &[]bool{true}[0],
+			RunAsUser:                &[]int64{65534}[0], // nobody
+			ReadOnlyRootFilesystem:   &[]bool{true}[0],
+			AllowPrivilegeEscalation: &[]bool{false}[0],
+			Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+		},
+	}, nil
+}
+
+// Paths where init containers mount the Secret with the content of the decoy and the emptyDir volume of the decoy.
+const (
+	decoyInitContainerSourcePath = "/koney/source"
+	decoyInitContainerTargetPath = "/koney/decoy"
+)
+
+// generateSourceVolumeName generates the name of the Secret volume that init containers copy the decoy from.
+func generateSourceVolumeName(filePath string) string {
+	return "koney-source-" + utils.ShortHash(filePath)
+}
+
+// generateDecoyInitContainerName generates the name of an init container that copies a decoy based on the filePath.
+func generateDecoyInitContainerName(filePath string) string {
+	return constants.DecoyInitContainerNamePrefix + utils.ShortHash(filePath)
+}
+
+// generateDecoyInitContainer generates an init container that copies the decoy of a filesystem honeytoken trap
+// from its Secret into the emptyDir volume of the decoy, which is then mounted into the selected containers.
+// This way, the decoy is in place before the containers start, without writing to their filesystems.
+func generateDecoyInitContainer(trap v1alpha1.Trap) corev1.Container {
+	filePath := trap.FilesystemHoneytoken.FilePath
+	_, fileName := filepath.Split(filePath)
+
+	return corev1.Container{
+		Name:    generateDecoyInitContainerName(filePath),
+		Image:   constants.DecoyInitContainerImage,
+		Command: []string{"cp", path.Join(decoyInitContainerSourcePath, fileName), path.Join(decoyInitContainerTargetPath, fileName)},
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      generateSourceVolumeName(filePath),
+				MountPath: decoyInitContainerSourcePath,
+				ReadOnly:  true,
+			},
+			{
+				Name:      generateVolumeName(filePath),
+				MountPath: decoyInitContainerTargetPath,
+			},
+		},
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("5m"),
+				corev1.ResourceMemory: resource.MustParse("8Mi"),
+			},
+			Limits: corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("32Mi"),
+			},
+		},
+		SecurityContext: &corev1.SecurityContext{
+			RunAsNonRoot:             &[]bool{true}[0],
+			RunAsUser:                &[]int64{65534}[0], // nobody
+			ReadOnlyRootFilesystem:   &[]bool{true}[0],
+			AllowPrivilegeEscalation: &[]bool{false}[0],
+			Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+		},
+	}
 }
 
 // generateTetragonTracingPolicy generates a Tetragon tracing policy for a filesystem honeytoken trap.
-func generateTetragonTracingPolicy(deceptionPolicy *v1alpha1.DeceptionPolicy, trap v1alpha1.Trap, tracingPolicyName string) (*ciliumiov1alpha1.TracingPolicy, error) {
+func generateTetragonTracingPolicy(deceptionPolicy *v1alpha1.DeceptionPolicy, trap v1alpha1.Trap, tracingPolicyName, webhookURL string) (*ciliumiov1alpha1.TracingPolicy, error) {
 	/*
 		The `security_file_permission` function is a common execution point for the execution of
 		system calls related to filesystem access, such as read, write, etc.
@@ -172,7 +360,7 @@ func generateTetragonTracingPolicy(deceptionPolicy *v1alpha1.DeceptionPolicy, tr
 							MatchActions: []ciliumiov1alpha1.ActionSelector{
 								{
 									Action: "GetUrl",
-									ArgUrl: constants.TetragonWebhookUrl,
+									ArgUrl: webhookURL,
 								},
 							},
 						},
@@ -207,7 +395,7 @@ func generateTetragonTracingPolicy(deceptionPolicy *v1alpha1.DeceptionPolicy, tr
 							MatchActions: []ciliumiov1alpha1.ActionSelector{
 								{
 									Action: "GetUrl",
-									ArgUrl: constants.TetragonWebhookUrl,
+									ArgUrl: webhookURL,
 								},
 							},
 						},
@@ -217,6 +405,70 @@ func generateTetragonTracingPolicy(deceptionPolicy *v1alpha1.DeceptionPolicy, tr
 		},
 	}
 
+	if err := ApplyTrapSelectorsToTracingPolicy(tracingPolicy, trap); err != nil {
+		return nil, err
+	}
+
+	return tracingPolicy, nil
+}
+
+// ApplyTracingPolicy creates a Tetragon tracing policy, or restores its spec and labels if it already exists
+// but was changed by someone else in the meantime. The boolean return value indicates if an existing policy was restored.
+// Tracing policies of another deception policy (that has an identical trap) are left as they are.
+func ApplyTracingPolicy(c client.Client, ctx context.Context, tracingPolicy *ciliumiov1alpha1.TracingPolicy) (bool, error) {
+	log := log.FromContext(ctx)
+
+	existingTracingPolicy := &ciliumiov1alpha1.TracingPolicy{}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(tracingPolicy), existingTracingPolicy); err != nil {
+		if client.IgnoreNotFound(err) != nil {
+			return false, err
+		}
+
+		if err := c.Create(ctx, tracingPolicy); err != nil {
+			return false, err
+		}
+
+		log.Info("Tetragon tracing policy created", "policy", tracingPolicy)
+		return false, nil
+	}
+
+	deceptionPolicyName := tracingPolicy.Labels[constants.LabelKeyDeceptionPolicyRef]
+	if ref, ok := existingTracingPolicy.Labels[constants.LabelKeyDeceptionPolicyRef]; ok && ref != deceptionPolicyName {
+		log.Info("Tetragon tracing policy belongs to another deception policy - leaving it as it is", "policy", tracingPolicy.Name, "owner", ref)
+		return false, nil
+	}
+
+	if equality.Semantic.DeepEqual(existingTracingPolicy.Spec, tracingPolicy.Spec) &&
+		existingTracingPolicy.Labels[constants.LabelKeyDeceptionPolicyRef] == deceptionPolicyName {
+		return false, nil
+	}
+
+	err := utils.PatchResource(c, ctx, existingTracingPolicy, func() error {
+		existingTracingPolicy.Spec = tracingPolicy.Spec
+		if existingTracingPolicy.Labels == nil {
+			existingTracingPolicy.Labels = map[string]string{}
+		}
+		existingTracingPolicy.Labels[constants.LabelKeyDeceptionPolicyRef] = deceptionPolicyName
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	log.Info("Tetragon tracing policy was changed by someone else - restored it", "policy", tracingPolicy.Name)
+	return true, nil
+}
+
+// ApplyTrapSelectorsToTracingPolicy restricts a Tetragon tracing policy to the pods and containers matched by a trap.
+// The pod selector is derived from the label selectors, and the container selector from the container selectors of the trap.
+func ApplyTrapSelectorsToTracingPolicy(tracingPolicy *ciliumiov1alpha1.TracingPolicy, trap v1alpha1.Trap) error {
+	if tracingPolicy.Spec.PodSelector == nil {
+		tracingPolicy.Spec.PodSelector = &slimv1.LabelSelector{MatchLabels: map[string]string{}}
+	}
+	if tracingPolicy.Spec.ContainerSelector == nil {
+		tracingPolicy.Spec.ContainerSelector = &slimv1.LabelSelector{}
+	}
+
 	// Add the labels from the trap's MatchResources to the PodSelector
 	for _, resourceFilter := range trap.MatchResources.Any {
 		for key, value := range resourceFilter.Selector.MatchLabels {
@@ -227,7 +479,7 @@ func generateTetragonTracingPolicy(deceptionPolicy *v1alpha1.DeceptionPolicy, tr
 	// A compiled regex to check if the containerSelector contains filepath.Match wildcards
 	compiledRegex, err := regexp.Compile(constants.WildcardContainerSelectorRegex)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	for _, resourceFilter := range trap.MatchResources.Any {
@@ -262,5 +514,5 @@ func generateTetragonTracingPolicy(deceptionPolicy *v1alpha1.DeceptionPolicy, tr
 		}
 	}
 
-	return tracingPolicy, nil
+	return nil
 }
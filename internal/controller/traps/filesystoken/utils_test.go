@@ -16,12 +16,19 @@
 package filesystoken
 
 import (
+	"context"
+	"errors"
+	"path/filepath"
 	"regexp"
 
 	slimv1 "github.com/cilium/cilium/pkg/k8s/slim/k8s/apis/meta/v1"
+	ciliumiov1alpha1 "github.com/cilium/tetragon/pkg/k8s/apis/cilium.io/v1alpha1"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	"github.com/dynatrace-oss/koney/api/v1alpha1"
 	"github.com/dynatrace-oss/koney/internal/controller/constants"
@@ -83,7 +90,7 @@ var _ = Describe("generateTetragonTracingPolicy", func() {
 						Traps: []v1alpha1.Trap{trap},
 					},
 				}
-				tracingPolicy, err := generateTetragonTracingPolicy(&deceptionPolicy, trap, "test-tracing-policy")
+				tracingPolicy, err := generateTetragonTracingPolicy(&deceptionPolicy, trap, "test-tracing-policy", constants.TetragonWebhookUrl)
 				Expect(err).ToNot(HaveOccurred())
 				Expect(tracingPolicy.Name).To(Equal("test-tracing-policy"))
 
@@ -113,3 +120,144 @@ var _ = Describe("generateTetragonTracingPolicy", func() {
 	})
 
 })
+
+var _ = Describe("generateSidecarCaptorContainer", func() {
+	Context("With a filesystem honeytoken trap", func() {
+		It("should watch the decoy through the volume of the decoy", func() {
+			trap := helpersTraps[0]
+			trap.CaptorDeployment.Strategy = "sidecar"
+
+			sidecar, err := generateSidecarCaptorContainer("deceptionpolicy-sidecar", trap, constants.SidecarWebhookUrl)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(sidecar.Name).To(HavePrefix(constants.SidecarCaptorNamePrefix))
+			Expect(len(sidecar.Name)).To(BeNumerically("<=", 63))
+			Expect(sidecar.VolumeMounts).To(HaveLen(1))
+			Expect(sidecar.VolumeMounts[0].Name).To(Equal(generateVolumeName(trap.FilesystemHoneytoken.FilePath)))
+			Expect(sidecar.VolumeMounts[0].ReadOnly).To(BeTrue())
+
+			env := map[string]string{}
+			for _, envVar := range sidecar.Env {
+				env[envVar.Name] = envVar.Value
+			}
+			Expect(env).To(HaveKeyWithValue("KONEY_WATCH_PATH", sidecarCaptorMountPath+"/file"))
+			Expect(env).To(HaveKeyWithValue("KONEY_TRAP", `{"deception_policy_name":"deceptionpolicy-sidecar","file_path":"/path/to/file"}`))
+			Expect(env).To(HaveKeyWithValue("KONEY_WEBHOOK_URL", constants.SidecarWebhookUrl))
+		})
+	})
+})
+
+var _ = Describe("generateDecoyInitContainer", func() {
+	Context("With a filesystem honeytoken trap", func() {
+		It("should copy the decoy from its Secret into the volume of the decoy", func() {
+			trap := helpersTraps[0]
+			trap.DecoyDeployment.Strategy = "initContainer"
+			_, fileName := filepath.Split(trap.FilesystemHoneytoken.FilePath)
+
+			initContainer := generateDecoyInitContainer(trap)
+
+			Expect(initContainer.Name).To(HavePrefix(constants.DecoyInitContainerNamePrefix))
+			Expect(len(initContainer.Name)).To(BeNumerically("<=", 63))
+			Expect(initContainer.Command).To(Equal([]string{"cp", "/koney/source/" + fileName, "/koney/decoy/" + fileName}))
+			Expect(initContainer.VolumeMounts).To(HaveLen(2))
+			Expect(initContainer.VolumeMounts[0].Name).To(Equal(generateSourceVolumeName(trap.FilesystemHoneytoken.FilePath)))
+			Expect(initContainer.VolumeMounts[0].ReadOnly).To(BeTrue())
+			Expect(initContainer.VolumeMounts[1].Name).To(Equal(generateVolumeName(trap.FilesystemHoneytoken.FilePath)))
+			Expect(*initContainer.SecurityContext.RunAsNonRoot).To(BeTrue())
+		})
+	})
+})
+
+var _ = Describe("isExecutableNotFound", func() {
+	It("should recognize containers without a shell", func() {
+		err := errors.New(`command terminated with exit code 126`)
+		Expect(isExecutableNotFound(err, `OCI runtime exec failed: exec failed: unable to start container process: exec: "sh": executable file not found in $PATH: unknown`)).To(BeTrue())
+
+		err = errors.New(`OCI runtime exec failed: exec failed: unable to start container process: exec: "mkdir": executable file not found in $PATH: unknown`)
+		Expect(isExecutableNotFound(err, "")).To(BeTrue())
+
+		err = errors.New("command terminated with exit code 1")
+		Expect(isExecutableNotFound(err, "mkdir: can't create directory '/run/secrets': Read-only file system")).To(BeFalse())
+	})
+})
+
+var _ = Describe("decoyContentMatches", func() {
+	Context("When verifying decoys", func() {
+		It("should only accept the expected content", func() {
+			Expect(decoyContentMatches("someverysecrettoken\n", "someverysecrettoken")).To(BeTrue())
+			Expect(decoyContentMatches("someverysecrettoken", "someverysecrettoken\n")).To(BeTrue())
+			Expect(decoyContentMatches("", "")).To(BeTrue())
+
+			Expect(decoyContentMatches("someverysecrettoken-changed", "someverysecrettoken")).To(BeFalse())
+			Expect(decoyContentMatches("", "someverysecrettoken")).To(BeFalse())
+		})
+	})
+})
+
+var _ = Describe("ApplyTracingPolicy", func() {
+	ctx := context.Background()
+
+	var (
+		fakeClient    client.Client
+		tracingPolicy *ciliumiov1alpha1.TracingPolicy
+	)
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(ciliumiov1alpha1.AddToScheme(scheme)).To(Succeed())
+		fakeClient = fake.NewClientBuilder().WithScheme(scheme).Build()
+
+		deceptionPolicy := &v1alpha1.DeceptionPolicy{ObjectMeta: metav1.ObjectMeta{Name: "deceptionpolicy-drift"}}
+		var err error
+		tracingPolicy, err = generateTetragonTracingPolicy(deceptionPolicy, helpersTraps[0], "koney-tracing-policy-drift", constants.TetragonWebhookUrl)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should create missing tracing policies", func() {
+		restored, err := ApplyTracingPolicy(fakeClient, ctx, tracingPolicy.DeepCopy())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(restored).To(BeFalse())
+
+		existing := &ciliumiov1alpha1.TracingPolicy{}
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(tracingPolicy), existing)).To(Succeed())
+		Expect(existing.Spec).To(Equal(tracingPolicy.Spec))
+
+		// Applying the same tracing policy again does not change anything
+		restored, err = ApplyTracingPolicy(fakeClient, ctx, tracingPolicy.DeepCopy())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(restored).To(BeFalse())
+	})
+
+	It("should restore tracing policies that were changed", func() {
+		Expect(fakeClient.Create(ctx, tracingPolicy.DeepCopy())).To(Succeed())
+
+		existing := &ciliumiov1alpha1.TracingPolicy{}
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(tracingPolicy), existing)).To(Succeed())
+		existing.Spec.KProbes = existing.Spec.KProbes[:1]
+		delete(existing.Labels, constants.LabelKeyDeceptionPolicyRef)
+		Expect(fakeClient.Update(ctx, existing)).To(Succeed())
+
+		restored, err := ApplyTracingPolicy(fakeClient, ctx, tracingPolicy.DeepCopy())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(restored).To(BeTrue())
+
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(tracingPolicy), existing)).To(Succeed())
+		Expect(existing.Spec).To(Equal(tracingPolicy.Spec))
+		Expect(existing.Labels).To(HaveKeyWithValue(constants.LabelKeyDeceptionPolicyRef, "deceptionpolicy-drift"))
+	})
+
+	It("should leave tracing policies of other deception policies alone", func() {
+		other := tracingPolicy.DeepCopy()
+		other.Labels[constants.LabelKeyDeceptionPolicyRef] = "deceptionpolicy-other"
+		other.Spec.KProbes = other.Spec.KProbes[:1]
+		Expect(fakeClient.Create(ctx, other)).To(Succeed())
+
+		restored, err := ApplyTracingPolicy(fakeClient, ctx, tracingPolicy.DeepCopy())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(restored).To(BeFalse())
+
+		existing := &ciliumiov1alpha1.TracingPolicy{}
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(tracingPolicy), existing)).To(Succeed())
+		Expect(existing.Spec.KProbes).To(HaveLen(1))
+	})
+})
@@ -0,0 +1,195 @@
+// Copyright (c) 2025 Dynatrace LLC
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package nethoneypot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/dynatrace-oss/koney/api/v1alpha1"
+	"github.com/dynatrace-oss/koney/internal/controller/constants"
+	"github.com/dynatrace-oss/koney/internal/controller/matching"
+	trapsapi "github.com/dynatrace-oss/koney/internal/controller/traps/api"
+	"github.com/dynatrace-oss/koney/internal/controller/traps/filesystoken"
+	"github.com/dynatrace-oss/koney/internal/controller/utils"
+	"github.com/dynatrace-oss/koney/internal/controller/webhookauth"
+)
+
+type NetworkHoneypotReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+
+	// AlertWebhookHost is the host of the alert forwarder that captors send alerts to (see webhookauth.WebhookURL).
+	AlertWebhookHost string
+
+	// ExcludedNamespaces are namespaces where no decoys are deployed, even if the trap matches resources there.
+	ExcludedNamespaces []string
+
+	DeceptionPolicy *v1alpha1.DeceptionPolicy
+}
+
+// DeployDecoy deploys a NetworkHoneypot decoy, i.e., a listener Deployment and a Service in every matched namespace.
+// Honeypots in namespaces that are no longer matched are removed.
+func (r *NetworkHoneypotReconciler) DeployDecoy(ctx context.Context, deceptionPolicy *v1alpha1.DeceptionPolicy, trap v1alpha1.Trap) trapsapi.DecoyDeploymentResult {
+	log := log.FromContext(ctx)
+	var joinedErrors error
+
+	namespaces, skippedNamespaces, err := matching.GetMatchingNamespaces(r, ctx, trap.MatchResources, r.ExcludedNamespaces)
+	if err != nil {
+		log.Error(err, "unable to get matching namespaces")
+		return trapsapi.DecoyDeploymentResult{Trap: &trap, Errors: errors.Join(err, errors.New("unable to get matching namespaces"))}
+	}
+
+	honeypotID, err := GenerateNetworkHoneypotID(trap)
+	if err != nil {
+		return trapsapi.DecoyDeploymentResult{Trap: &trap, Errors: err}
+	}
+
+	allHoneypotsReady := true
+	outcomes := make([]trapsapi.ObjectOutcome, 0, len(namespaces))
+	for _, namespace := range namespaces {
+		// The listener Deployment is the object that represents the honeypot in the namespace
+		outcome := trapsapi.ObjectOutcome{Object: corev1.ObjectReference{Kind: "Deployment", Namespace: namespace, Name: trap.NetworkHoneypot.ServiceName}}
+
+		ready, err := r.deployHoneypotToNamespace(ctx, deceptionPolicy, trap, honeypotID, namespace)
+		if err != nil {
+			log.Error(err, "unable to deploy NetworkHoneypot trap to namespace", "namespace", namespace)
+			joinedErrors = errors.Join(joinedErrors, err)
+			outcome.Error = err
+		} else if !ready {
+			allHoneypotsReady = false
+			outcome.SkippedReason = trapsapi.SkippedReasonNotReady
+		} else {
+			log.Info("NetworkHoneypot trap deployed to namespace", "namespace", namespace, "service", trap.NetworkHoneypot.ServiceName)
+		}
+		outcomes = append(outcomes, outcome)
+	}
+
+	// Remove honeypots from namespaces that are no longer matched
+	if err := r.removeHoneypots(ctx, deceptionPolicy, func(id, namespace string) bool {
+		return id == honeypotID && !utils.Contains(namespaces, namespace)
+	}); err != nil {
+		joinedErrors = errors.Join(joinedErrors, err)
+	}
+
+	return trapsapi.DecoyDeploymentResult{
+		Trap:                        &trap,
+		AtLeastOneObjectsWasMatched: len(namespaces) > 0,
+		AllObjectsWereReady:         len(namespaces) > 0 && allHoneypotsReady,
+		Outcomes:                    outcomes,
+		SkippedNamespaces:           skippedNamespaces,
+		Errors:                      joinedErrors}
+}
+
+// DeployCaptor deploys a captor for a network honeypot trap.
+func (r *NetworkHoneypotReconciler) DeployCaptor(ctx context.Context, deceptionPolicy *v1alpha1.DeceptionPolicy, trap v1alpha1.Trap) trapsapi.CaptorDeploymentResult {
+	log := log.FromContext(ctx)
+
+	switch trap.CaptorDeployment.Strategy {
+	case "tetragon":
+		restored, err := r.deployCaptorWithTetragon(ctx, deceptionPolicy, trap)
+		if err != nil {
+			missingTetragon := errors.Is(err, &meta.NoKindMatchError{})
+			if missingTetragon {
+				log.Error(nil, "Tetragon is not installed - cannot deploy captors with Tetragon")
+			}
+			return trapsapi.CaptorDeploymentResult{Trap: &trap, Errors: err, MissingTetragon: missingTetragon}
+		}
+		return trapsapi.CaptorDeploymentResult{Trap: &trap, Restored: restored}
+	default:
+		log.Error(nil, fmt.Sprintf("captor deployment strategy '%s' unknown", trap.CaptorDeployment.Strategy))
+		return trapsapi.CaptorDeploymentResult{Trap: &trap, Errors: errors.New("captor deployment strategy unknown")}
+	}
+
+	return trapsapi.CaptorDeploymentResult{Trap: &trap}
+}
+
+// deployHoneypotToNamespace creates the listener Deployment and the Service of a network honeypot in a namespace.
+// The function does nothing if the resources already exist. The boolean return value indicates if the listener is available.
+func (r *NetworkHoneypotReconciler) deployHoneypotToNamespace(ctx context.Context, deceptionPolicy *v1alpha1.DeceptionPolicy, trap v1alpha1.Trap, honeypotID, namespace string) (bool, error) {
+	log := log.FromContext(ctx)
+
+	deployment := appsv1.Deployment{}
+	if err := r.Client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: trap.NetworkHoneypot.ServiceName}, &deployment); err != nil {
+		if client.IgnoreNotFound(err) != nil {
+			return false, err
+		}
+
+		log.Info("Creating NetworkHoneypot listener", "namespace", namespace, "deployment", trap.NetworkHoneypot.ServiceName)
+		if err := r.Client.Create(ctx, generateListenerDeployment(deceptionPolicy, trap, honeypotID, namespace)); err != nil {
+			return false, err
+		}
+	} else if deployment.Labels[constants.LabelKeyNetworkHoneypotRef] != honeypotID {
+		return false, fmt.Errorf("deployment '%s/%s' already exists and is not managed by this trap", namespace, deployment.Name)
+	}
+
+	service := corev1.Service{}
+	if err := r.Client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: trap.NetworkHoneypot.ServiceName}, &service); err != nil {
+		if client.IgnoreNotFound(err) != nil {
+			return false, err
+		}
+
+		log.Info("Creating NetworkHoneypot service", "namespace", namespace, "service", trap.NetworkHoneypot.ServiceName)
+		if err := r.Client.Create(ctx, generateService(deceptionPolicy, trap, honeypotID, namespace)); err != nil {
+			return false, err
+		}
+	} else if service.Labels[constants.LabelKeyNetworkHoneypotRef] != honeypotID {
+		return false, fmt.Errorf("service '%s/%s' already exists and is not managed by this trap", namespace, service.Name)
+	}
+
+	return utils.GetDeploymentCondition(&deployment.Status.Conditions, appsv1.DeploymentAvailable) == corev1.ConditionTrue, nil
+}
+
+// deployCaptorWithTetragon generates a Tetragon tracing policy
+// to trace incoming connections to a network honeypot and applies it to the cluster.
+// If the tracing policy already exists but was changed in the meantime, it is restored (and true is returned).
+func (r *NetworkHoneypotReconciler) deployCaptorWithTetragon(ctx context.Context, deceptionPolicy *v1alpha1.DeceptionPolicy, trap v1alpha1.Trap) (bool, error) {
+	log := log.FromContext(ctx)
+
+	tracingPolicyName, err := filesystoken.GenerateTetragonTracingPolicyName(trap)
+	if err != nil {
+		log.Error(err, "unable to generate Tetragon tracing policy name")
+		return false, err
+	}
+
+	honeypotID, err := GenerateNetworkHoneypotID(trap)
+	if err != nil {
+		return false, err
+	}
+
+	webhookURL, err := webhookauth.GetSignedURL(r.Client, ctx, webhookauth.WebhookURL(r.AlertWebhookHost, constants.TetragonWebhookPath), deceptionPolicy.Name)
+	if err != nil {
+		log.Error(err, "unable to sign alert forwarder webhook URL")
+		return false, err
+	}
+
+	tracingPolicy := generateTetragonTracingPolicy(deceptionPolicy, tracingPolicyName, honeypotID, webhookURL)
+	restored, err := filesystoken.ApplyTracingPolicy(r.Client, ctx, tracingPolicy)
+	if err != nil {
+		log.Error(err, "unable to apply Tetragon tracing policy")
+		return false, err
+	}
+
+	return restored, nil
+}
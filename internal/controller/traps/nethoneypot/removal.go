@@ -0,0 +1,92 @@
+// Copyright (c) 2025 Dynatrace LLC
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package nethoneypot
+
+import (
+	"context"
+	"errors"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/dynatrace-oss/koney/api/v1alpha1"
+	"github.com/dynatrace-oss/koney/internal/controller/constants"
+	"github.com/dynatrace-oss/koney/internal/controller/utils"
+)
+
+// RemoveDecoys removes all network honeypots of a DeceptionPolicy, except the ones of the given traps.
+func (r *NetworkHoneypotReconciler) RemoveDecoys(ctx context.Context, deceptionPolicy *v1alpha1.DeceptionPolicy, keepTraps []v1alpha1.Trap) error {
+	keepHoneypotIDs := []string{}
+	for _, trap := range keepTraps {
+		if trap.TrapType() != v1alpha1.NetworkHoneypotTrap {
+			continue
+		}
+
+		honeypotID, err := GenerateNetworkHoneypotID(trap)
+		if err != nil {
+			return err
+		}
+		keepHoneypotIDs = append(keepHoneypotIDs, honeypotID)
+	}
+
+	return r.removeHoneypots(ctx, deceptionPolicy, func(id, namespace string) bool {
+		return !utils.Contains(keepHoneypotIDs, id)
+	})
+}
+
+// removeHoneypots deletes the listener Deployments and Services of a DeceptionPolicy's network honeypots,
+// for which shouldRemove (given the honeypot identifier and the namespace) returns true.
+func (r *NetworkHoneypotReconciler) removeHoneypots(ctx context.Context, deceptionPolicy *v1alpha1.DeceptionPolicy, shouldRemove func(id, namespace string) bool) error {
+	log := log.FromContext(ctx)
+
+	var joinedErrors error
+	listOptions := []client.ListOption{
+		client.MatchingLabels{constants.LabelKeyDeceptionPolicyRef: deceptionPolicy.Name},
+		client.HasLabels{constants.LabelKeyNetworkHoneypotRef},
+	}
+
+	deployments := &appsv1.DeploymentList{}
+	if err := r.Client.List(ctx, deployments, listOptions...); err != nil {
+		return err
+	}
+	for i := range deployments.Items {
+		deployment := &deployments.Items[i]
+		if shouldRemove(deployment.Labels[constants.LabelKeyNetworkHoneypotRef], deployment.Namespace) {
+			log.Info("Deleting NetworkHoneypot listener", "namespace", deployment.Namespace, "deployment", deployment.Name)
+			if err := r.Client.Delete(ctx, deployment); client.IgnoreNotFound(err) != nil {
+				joinedErrors = errors.Join(joinedErrors, err)
+			}
+		}
+	}
+
+	services := &corev1.ServiceList{}
+	if err := r.Client.List(ctx, services, listOptions...); err != nil {
+		return errors.Join(joinedErrors, err)
+	}
+	for i := range services.Items {
+		service := &services.Items[i]
+		if shouldRemove(service.Labels[constants.LabelKeyNetworkHoneypotRef], service.Namespace) {
+			log.Info("Deleting NetworkHoneypot service", "namespace", service.Namespace, "service", service.Name)
+			if err := r.Client.Delete(ctx, service); client.IgnoreNotFound(err) != nil {
+				joinedErrors = errors.Join(joinedErrors, err)
+			}
+		}
+	}
+
+	return joinedErrors
+}
@@ -0,0 +1,238 @@
+// Copyright (c) 2025 Dynatrace LLC
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package nethoneypot
+
+import (
+	"encoding/json"
+	"fmt"
+
+	slimv1 "github.com/cilium/cilium/pkg/k8s/slim/k8s/apis/meta/v1"
+	ciliumiov1alpha1 "github.com/cilium/tetragon/pkg/k8s/apis/cilium.io/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/dynatrace-oss/koney/api/v1alpha1"
+	"github.com/dynatrace-oss/koney/internal/controller/constants"
+	"github.com/dynatrace-oss/koney/internal/controller/utils"
+)
+
+// GenerateNetworkHoneypotID generates an identifier for a network honeypot trap,
+// which is used to label (and select) all resources that belong to the honeypot.
+func GenerateNetworkHoneypotID(trap v1alpha1.Trap) (string, error) {
+	honeypotJSON, err := json.Marshal(trap.NetworkHoneypot)
+	if err != nil {
+		return "", err
+	}
+
+	return utils.ShortHash(string(honeypotJSON)), nil
+}
+
+// generateGreeting returns the protocol-specific message that the honeypot sends to every client.
+func generateGreeting(protocol string) string {
+	switch protocol {
+	case "redis":
+		return `-NOAUTH Authentication required.\r\n`
+	case "ssh":
+		return `SSH-2.0-OpenSSH_9.6p1 Ubuntu-3ubuntu13.5\r\n`
+	default:
+		return ""
+	}
+}
+
+// generateLabels generates the labels that are placed on all resources of a network honeypot.
+func generateLabels(deceptionPolicy *v1alpha1.DeceptionPolicy, trap v1alpha1.Trap, honeypotID string) map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/name":             trap.NetworkHoneypot.ServiceName,
+		constants.LabelKeyDeceptionPolicyRef: deceptionPolicy.Name,
+		constants.LabelKeyNetworkHoneypotRef: honeypotID,
+	}
+}
+
+// generateOwnerReferences makes the DeceptionPolicy the owner of the resources of a network honeypot.
+func generateOwnerReferences(deceptionPolicy *v1alpha1.DeceptionPolicy) []metav1.OwnerReference {
+	return []metav1.OwnerReference{
+		{
+			APIVersion:         deceptionPolicy.APIVersion,
+			Kind:               deceptionPolicy.Kind,
+			Name:               deceptionPolicy.Name,
+			UID:                deceptionPolicy.UID,
+			BlockOwnerDeletion: &[]bool{true}[0], // A pointer to a bool
+			Controller:         &[]bool{true}[0],
+		},
+	}
+}
+
+// generateListenerDeployment generates the Deployment that runs the listener of a network honeypot.
+// The listener accepts every connection, sends the protocol greeting, and closes the connection.
+func generateListenerDeployment(deceptionPolicy *v1alpha1.DeceptionPolicy, trap v1alpha1.Trap, honeypotID, namespace string) *appsv1.Deployment {
+	labels := generateLabels(deceptionPolicy, trap, honeypotID)
+	port := trap.NetworkHoneypot.GetPort()
+
+	// socat forks a process for every client, which writes the greeting (printf %b interprets the escape sequences)
+	listenAddress := generateListenAddress(trap.NetworkHoneypot.GetIPFamily(), port)
+	greetingCommand := fmt.Sprintf("SYSTEM:printf '%%b' '%s'", generateGreeting(trap.NetworkHoneypot.Protocol))
+
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            trap.NetworkHoneypot.ServiceName,
+			Namespace:       namespace,
+			Labels:          labels,
+			OwnerReferences: generateOwnerReferences(deceptionPolicy),
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &[]int32{1}[0],
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{constants.LabelKeyNetworkHoneypotRef: honeypotID},
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: corev1.PodSpec{
+					AutomountServiceAccountToken: &[]bool{false}[0],
+					Containers: []corev1.Container{
+						{
+							Name:  trap.NetworkHoneypot.Protocol,
+							Image: constants.NetworkHoneypotImage,
+							Args:  []string{listenAddress, greetingCommand},
+							Ports: []corev1.ContainerPort{
+								{
+									Name:          trap.NetworkHoneypot.Protocol,
+									ContainerPort: port,
+									Protocol:      corev1.ProtocolTCP,
+								},
+							},
+							SecurityContext: &corev1.SecurityContext{
+								AllowPrivilegeEscalation: &[]bool{false}[0],
+								ReadOnlyRootFilesystem:   &[]bool{true}[0],
+								Capabilities: &corev1.Capabilities{
+									Drop: []corev1.Capability{"ALL"},
+									Add:  []corev1.Capability{"NET_BIND_SERVICE"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// generateListenAddress generates the socat address of the listener for the IP family of a network honeypot.
+// Dual-stack listeners bind to an IPv6 socket that also accepts IPv4 connections (as IPv4-mapped IPv6 addresses).
+func generateListenAddress(ipFamily string, port int32) string {
+	switch ipFamily {
+	case v1alpha1.NetworkHoneypotIPv6:
+		return fmt.Sprintf("TCP6-LISTEN:%d,fork,reuseaddr,ipv6only=1", port)
+	case v1alpha1.NetworkHoneypotDualStack:
+		return fmt.Sprintf("TCP6-LISTEN:%d,fork,reuseaddr,ipv6only=0", port)
+	default:
+		return fmt.Sprintf("TCP-LISTEN:%d,fork,reuseaddr", port)
+	}
+}
+
+// generateIPFamilies generates the IP family policy and the IP families of the Service of a network honeypot.
+func generateIPFamilies(ipFamily string) (*corev1.IPFamilyPolicy, []corev1.IPFamily) {
+	switch ipFamily {
+	case v1alpha1.NetworkHoneypotIPv6:
+		return &[]corev1.IPFamilyPolicy{corev1.IPFamilyPolicySingleStack}[0], []corev1.IPFamily{corev1.IPv6Protocol}
+	case v1alpha1.NetworkHoneypotDualStack:
+		return &[]corev1.IPFamilyPolicy{corev1.IPFamilyPolicyRequireDualStack}[0], []corev1.IPFamily{corev1.IPv4Protocol, corev1.IPv6Protocol}
+	default:
+		return &[]corev1.IPFamilyPolicy{corev1.IPFamilyPolicySingleStack}[0], []corev1.IPFamily{corev1.IPv4Protocol}
+	}
+}
+
+// generateService generates the Service that exposes the listener of a network honeypot.
+func generateService(deceptionPolicy *v1alpha1.DeceptionPolicy, trap v1alpha1.Trap, honeypotID, namespace string) *corev1.Service {
+	port := trap.NetworkHoneypot.GetPort()
+	ipFamilyPolicy, ipFamilies := generateIPFamilies(trap.NetworkHoneypot.GetIPFamily())
+
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            trap.NetworkHoneypot.ServiceName,
+			Namespace:       namespace,
+			Labels:          generateLabels(deceptionPolicy, trap, honeypotID),
+			OwnerReferences: generateOwnerReferences(deceptionPolicy),
+		},
+		Spec: corev1.ServiceSpec{
+			Selector:       map[string]string{constants.LabelKeyNetworkHoneypotRef: honeypotID},
+			IPFamilyPolicy: ipFamilyPolicy,
+			IPFamilies:     ipFamilies,
+			Ports: []corev1.ServicePort{
+				{
+					Name:       trap.NetworkHoneypot.Protocol,
+					Port:       port,
+					TargetPort: intstr.FromInt32(port),
+					Protocol:   corev1.ProtocolTCP,
+				},
+			},
+		},
+	}
+}
+
+// generateTetragonTracingPolicy generates a Tetragon tracing policy that traces incoming connections to a network honeypot.
+func generateTetragonTracingPolicy(deceptionPolicy *v1alpha1.DeceptionPolicy, tracingPolicyName, honeypotID, webhookURL string) *ciliumiov1alpha1.TracingPolicy {
+	/*
+		The `inet_csk_accept` function is called by the kernel whenever a process accepts a TCP connection.
+		Its return value is the socket of the new connection, which includes both the local address
+		(the honeypot) and the remote address (the client). Since nobody has a reason to connect to the honeypot,
+		every accepted connection is an alert. The alert forwarder resolves the client pod by its IP address.
+
+		See also:
+		- https://tetragon.io/docs/use-cases/network-observability/
+	*/
+	return &ciliumiov1alpha1.TracingPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: tracingPolicyName,
+			Labels: map[string]string{
+				constants.LabelKeyDeceptionPolicyRef: deceptionPolicy.Name,
+			},
+			OwnerReferences: generateOwnerReferences(deceptionPolicy),
+		},
+		Spec: ciliumiov1alpha1.TracingPolicySpec{
+			PodSelector: &slimv1.LabelSelector{
+				MatchLabels: map[string]string{
+					constants.LabelKeyNetworkHoneypotRef: honeypotID,
+				},
+			},
+			KProbes: []ciliumiov1alpha1.KProbeSpec{
+				{
+					Call:    "inet_csk_accept",
+					Syscall: false,
+					Return:  true,
+					ReturnArg: &ciliumiov1alpha1.KProbeArg{
+						Index: 0,
+						Type:  "sock", // The accepted socket includes the addresses of both peers
+					},
+					ReturnArgAction: "Post",
+					Selectors: []ciliumiov1alpha1.KProbeSelector{
+						{
+							MatchActions: []ciliumiov1alpha1.ActionSelector{
+								{
+									Action: "GetUrl",
+									ArgUrl: webhookURL,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
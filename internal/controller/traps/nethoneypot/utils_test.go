@@ -0,0 +1,133 @@
+// Copyright (c) 2025 Dynatrace LLC
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package nethoneypot
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/dynatrace-oss/koney/api/v1alpha1"
+	"github.com/dynatrace-oss/koney/internal/controller/constants"
+)
+
+var _ = Describe("NetworkHoneypot resources", func() {
+	var (
+		deceptionPolicy = v1alpha1.DeceptionPolicy{}
+		trap            = v1alpha1.Trap{
+			NetworkHoneypot: v1alpha1.NetworkHoneypot{
+				ServiceName: "redis-cache",
+				Protocol:    "redis",
+			},
+			CaptorDeployment: v1alpha1.CaptorDeployment{
+				Strategy: "tetragon",
+			},
+			MatchResources: v1alpha1.MatchResources{
+				Any: []v1alpha1.ResourceFilter{
+					{ResourceDescription: v1alpha1.ResourceDescription{Namespaces: []string{"koney"}}},
+				},
+			},
+		}
+	)
+
+	BeforeEach(func() {
+		deceptionPolicy.Name = "test-deception-policy"
+	})
+
+	Context("When generating the honeypot identifier", func() {
+		It("should only depend on the honeypot configuration", func() {
+			id, err := GenerateNetworkHoneypotID(trap)
+			Expect(err).ToNot(HaveOccurred())
+
+			otherTrap := trap
+			otherTrap.MatchResources = v1alpha1.MatchResources{}
+			otherID, err := GenerateNetworkHoneypotID(otherTrap)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(otherID).To(Equal(id))
+
+			otherTrap.NetworkHoneypot.Port = 6380
+			otherID, err = GenerateNetworkHoneypotID(otherTrap)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(otherID).ToNot(Equal(id))
+		})
+	})
+
+	Context("When generating the listener deployment and service", func() {
+		It("should select the listener pods by the honeypot identifier", func() {
+			deployment := generateListenerDeployment(&deceptionPolicy, trap, "some-id", "koney")
+			Expect(deployment.Name).To(Equal("redis-cache"))
+			Expect(deployment.Namespace).To(Equal("koney"))
+			Expect(deployment.Labels).To(HaveKeyWithValue(constants.LabelKeyDeceptionPolicyRef, "test-deception-policy"))
+			Expect(deployment.Spec.Selector.MatchLabels).To(HaveKeyWithValue(constants.LabelKeyNetworkHoneypotRef, "some-id"))
+			Expect(deployment.Spec.Template.Labels).To(HaveKeyWithValue(constants.LabelKeyNetworkHoneypotRef, "some-id"))
+			Expect(deployment.Spec.Template.Spec.Containers).To(HaveLen(1))
+			Expect(deployment.Spec.Template.Spec.Containers[0].Ports[0].ContainerPort).To(BeEquivalentTo(6379))
+			Expect(deployment.Spec.Template.Spec.Containers[0].Args).To(ContainElement("TCP-LISTEN:6379,fork,reuseaddr"))
+
+			service := generateService(&deceptionPolicy, trap, "some-id", "koney")
+			Expect(service.Name).To(Equal("redis-cache"))
+			Expect(service.Spec.Selector).To(HaveKeyWithValue(constants.LabelKeyNetworkHoneypotRef, "some-id"))
+			Expect(service.Spec.Ports).To(HaveLen(1))
+			Expect(service.Spec.Ports[0].Port).To(BeEquivalentTo(6379))
+			Expect(service.Spec.IPFamilies).To(Equal([]corev1.IPFamily{corev1.IPv4Protocol}))
+		})
+
+		It("should listen on IPv6 for IPv6-only honeypots", func() {
+			ipv6Trap := trap
+			ipv6Trap.NetworkHoneypot.IPFamily = v1alpha1.NetworkHoneypotIPv6
+
+			deployment := generateListenerDeployment(&deceptionPolicy, ipv6Trap, "some-id", "koney")
+			Expect(deployment.Spec.Template.Spec.Containers[0].Args).To(ContainElement("TCP6-LISTEN:6379,fork,reuseaddr,ipv6only=1"))
+
+			service := generateService(&deceptionPolicy, ipv6Trap, "some-id", "koney")
+			Expect(*service.Spec.IPFamilyPolicy).To(Equal(corev1.IPFamilyPolicySingleStack))
+			Expect(service.Spec.IPFamilies).To(Equal([]corev1.IPFamily{corev1.IPv6Protocol}))
+		})
+
+		It("should listen on both families for dual-stack honeypots", func() {
+			dualStackTrap := trap
+			dualStackTrap.NetworkHoneypot.IPFamily = v1alpha1.NetworkHoneypotDualStack
+
+			deployment := generateListenerDeployment(&deceptionPolicy, dualStackTrap, "some-id", "koney")
+			Expect(deployment.Spec.Template.Spec.Containers[0].Args).To(ContainElement("TCP6-LISTEN:6379,fork,reuseaddr,ipv6only=0"))
+
+			service := generateService(&deceptionPolicy, dualStackTrap, "some-id", "koney")
+			Expect(*service.Spec.IPFamilyPolicy).To(Equal(corev1.IPFamilyPolicyRequireDualStack))
+			Expect(service.Spec.IPFamilies).To(ConsistOf(corev1.IPv4Protocol, corev1.IPv6Protocol))
+		})
+
+		It("should default to IPv4 and reject unknown IP families", func() {
+			honeypot := trap.NetworkHoneypot
+			Expect(honeypot.GetIPFamily()).To(Equal(v1alpha1.NetworkHoneypotIPv4))
+			Expect(honeypot.IsValid()).To(Succeed())
+
+			honeypot.IPFamily = "IPv5"
+			Expect(honeypot.IsValid()).NotTo(Succeed())
+		})
+	})
+
+	Context("When generating the Tetragon TracingPolicy", func() {
+		It("should only select the listener pods", func() {
+			tracingPolicy := generateTetragonTracingPolicy(&deceptionPolicy, "test-tracing-policy", "some-id", constants.TetragonWebhookUrl)
+			Expect(tracingPolicy.Name).To(Equal("test-tracing-policy"))
+			Expect(tracingPolicy.Labels).To(HaveKeyWithValue(constants.LabelKeyDeceptionPolicyRef, "test-deception-policy"))
+			Expect(tracingPolicy.Spec.PodSelector.MatchLabels).To(Equal(map[string]string{constants.LabelKeyNetworkHoneypotRef: "some-id"}))
+			Expect(tracingPolicy.Spec.KProbes).To(HaveLen(1))
+			Expect(tracingPolicy.Spec.KProbes[0].Call).To(Equal("inet_csk_accept"))
+			Expect(tracingPolicy.Spec.KProbes[0].ReturnArg.Type).To(Equal("sock"))
+		})
+	})
+})
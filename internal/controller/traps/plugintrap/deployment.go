@@ -0,0 +1,160 @@
+// Copyright (c) 2025 Dynatrace LLC
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package plugintrap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/dynatrace-oss/koney/api/v1alpha1"
+	"github.com/dynatrace-oss/koney/internal/controller/constants"
+	"github.com/dynatrace-oss/koney/internal/controller/matching"
+	trapsapi "github.com/dynatrace-oss/koney/internal/controller/traps/api"
+	"github.com/dynatrace-oss/koney/internal/controller/webhookauth"
+	"github.com/dynatrace-oss/koney/pkg/plugin"
+)
+
+// PluginTrapReconciler reconciles traps that are implemented by out-of-tree plugins.
+type PluginTrapReconciler struct {
+	client.Client
+
+	// Plugins discovers the plugins that implement the traps.
+	Plugins *Registry
+
+	// AlertWebhookHost is the host of the alert forwarder that captors send alerts to (see webhookauth.WebhookURL).
+	AlertWebhookHost string
+
+	// ExcludedNamespaces are namespaces where no decoys are deployed, even if the trap matches resources there.
+	ExcludedNamespaces []string
+
+	DeceptionPolicy *v1alpha1.DeceptionPolicy
+}
+
+// DeployDecoy resolves the objects that a plugin trap matches and lets the plugin deploy the decoy to them.
+// Objects that are not ready yet are not passed to the plugin, but reported as skipped.
+func (r *PluginTrapReconciler) DeployDecoy(ctx context.Context, deceptionPolicy *v1alpha1.DeceptionPolicy, trap v1alpha1.Trap) trapsapi.DecoyDeploymentResult {
+	log := log.FromContext(ctx)
+
+	trapPlugin, err := r.Plugins.Get(trap.Plugin.Name)
+	if err != nil {
+		log.Error(err, "unable to find trap plugin", "plugin", trap.Plugin.Name)
+		return trapsapi.DecoyDeploymentResult{Trap: &trap, Errors: err}
+	}
+
+	// If we aren't allowed to mutate existing resources, we avoid matching resources created before the policy was created
+	var filterCreatedAfter metav1.Time
+	if !*deceptionPolicy.Spec.MutateExisting {
+		filterCreatedAfter = deceptionPolicy.CreationTimestamp
+	}
+
+	matchingResult, err := matching.GetDeployableObjectsWithContainers(r, ctx, trap, &filterCreatedAfter, r.ExcludedNamespaces)
+	if err != nil {
+		log.Error(err, "unable to get matching resources")
+		return trapsapi.DecoyDeploymentResult{Trap: &trap, Errors: errors.Join(err, errors.New("unable to get matching resources"))}
+	}
+
+	namespaces, skippedNamespaces, err := matching.GetMatchingNamespaces(r, ctx, trap.MatchResources, r.ExcludedNamespaces)
+	if err != nil {
+		log.Error(err, "unable to get matching namespaces")
+		return trapsapi.DecoyDeploymentResult{Trap: &trap, Errors: errors.Join(err, errors.New("unable to get matching namespaces"))}
+	}
+
+	trapSpec, err := generateTrapSpec(trap)
+	if err != nil {
+		return trapsapi.DecoyDeploymentResult{Trap: &trap, Errors: err}
+	}
+
+	targets := make([]plugin.Target, 0, len(matchingResult.DeployableObjects))
+	for object, containers := range matchingResult.DeployableObjects {
+		targets = append(targets, generateTarget(object, containers))
+	}
+
+	callCtx, cancel := context.WithTimeout(ctx, constants.PluginCallTimeout)
+	defer cancel()
+
+	response, err := trapPlugin.DeployDecoy(callCtx, &plugin.DeployDecoyRequest{
+		Policy:     generatePolicyRef(deceptionPolicy),
+		Trap:       trapSpec,
+		Namespaces: namespaces,
+		Targets:    targets,
+	})
+	if err != nil {
+		log.Error(err, "trap plugin failed to deploy decoy", "plugin", trap.Plugin.Name)
+		return trapsapi.DecoyDeploymentResult{Trap: &trap, Errors: fmt.Errorf("plugin '%s' failed to deploy decoy: %w", trap.Plugin.Name, err)}
+	}
+
+	var joinedErrors error
+	outcomes := append(trapsapi.NotReadyOutcomes(matchingResult.NotReadyObjects), convertOutcomes(targets, response.Outcomes)...)
+	for _, outcome := range outcomes {
+		joinedErrors = errors.Join(joinedErrors, outcome.Error)
+	}
+
+	// Objects and namespaces can both be skipped in the same excluded namespace
+	skippedNamespaces = append(skippedNamespaces, matchingResult.SkippedNamespaces...)
+	slices.Sort(skippedNamespaces)
+
+	return trapsapi.DecoyDeploymentResult{
+		Trap:                        &trap,
+		AtLeastOneObjectsWasMatched: matchingResult.AtLeastOneObjectWasMatched || len(namespaces) > 0,
+		AllObjectsWereReady:         matchingResult.AllDeployableObjectsWereReady,
+		Outcomes:                    outcomes,
+		SkippedNamespaces:           slices.Compact(skippedNamespaces),
+		Errors:                      joinedErrors}
+}
+
+// DeployCaptor lets the plugin deploy the captor of a plugin trap.
+// Plugins that do not implement captors are tolerated, since their decoys might report access themselves.
+func (r *PluginTrapReconciler) DeployCaptor(ctx context.Context, deceptionPolicy *v1alpha1.DeceptionPolicy, trap v1alpha1.Trap) trapsapi.CaptorDeploymentResult {
+	log := log.FromContext(ctx)
+
+	trapPlugin, err := r.Plugins.Get(trap.Plugin.Name)
+	if err != nil {
+		log.Error(err, "unable to find trap plugin", "plugin", trap.Plugin.Name)
+		return trapsapi.CaptorDeploymentResult{Trap: &trap, Errors: err}
+	}
+
+	trapSpec, err := generateTrapSpec(trap)
+	if err != nil {
+		return trapsapi.CaptorDeploymentResult{Trap: &trap, Errors: err}
+	}
+
+	webhookURL, err := webhookauth.GetSignedURL(r.Client, ctx, webhookauth.WebhookURL(r.AlertWebhookHost, constants.PluginWebhookPath), deceptionPolicy.Name)
+	if err != nil {
+		log.Error(err, "unable to sign alert forwarder webhook URL")
+		return trapsapi.CaptorDeploymentResult{Trap: &trap, Errors: err}
+	}
+
+	callCtx, cancel := context.WithTimeout(ctx, constants.PluginCallTimeout)
+	defer cancel()
+
+	_, err = trapPlugin.DeployCaptor(callCtx, &plugin.DeployCaptorRequest{
+		Policy:     generatePolicyRef(deceptionPolicy),
+		Trap:       trapSpec,
+		WebhookURL: webhookURL,
+	})
+	if err != nil && !plugin.IsUnimplemented(err) {
+		log.Error(err, "trap plugin failed to deploy captor", "plugin", trap.Plugin.Name)
+		return trapsapi.CaptorDeploymentResult{Trap: &trap, Errors: fmt.Errorf("plugin '%s' failed to deploy captor: %w", trap.Plugin.Name, err)}
+	}
+
+	return trapsapi.CaptorDeploymentResult{Trap: &trap}
+}
@@ -0,0 +1,160 @@
+// Copyright (c) 2025 Dynatrace LLC
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package plugintrap
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/dynatrace-oss/koney/api/v1alpha1"
+	"github.com/dynatrace-oss/koney/pkg/plugin"
+)
+
+// removalPlugin records the removal requests it receives and implements nothing else.
+type removalPlugin struct {
+	plugin.UnimplementedTrapPlugin
+
+	removeDecoyRequests []*plugin.RemoveRequest
+}
+
+func (p *removalPlugin) RemoveDecoys(_ context.Context, req *plugin.RemoveRequest) (*plugin.RemoveResponse, error) {
+	p.removeDecoyRequests = append(p.removeDecoyRequests, req)
+	return &plugin.RemoveResponse{}, nil
+}
+
+var _ = Describe("Plugin traps", func() {
+	var trap = v1alpha1.Trap{
+		Plugin: v1alpha1.PluginTrap{
+			Name:   "records",
+			Type:   "customer-record",
+			Config: `{"table":"customers"}`,
+		},
+		DecoyDeployment: v1alpha1.DecoyDeployment{Strategy: "volumeMount"},
+		MatchResources: v1alpha1.MatchResources{
+			Any: []v1alpha1.ResourceFilter{
+				{ResourceDescription: v1alpha1.ResourceDescription{Namespaces: []string{"koney"}}},
+			},
+		},
+	}
+
+	Context("When generating the trap identifier", func() {
+		It("should change whenever the trap changes", func() {
+			id, err := GeneratePluginTrapID(trap)
+			Expect(err).ToNot(HaveOccurred())
+
+			otherTrap := trap
+			otherTrap.Plugin.Config = `{"table":"orders"}`
+			otherID, err := GeneratePluginTrapID(otherTrap)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(otherID).ToNot(Equal(id))
+		})
+	})
+
+	Context("When converting the outcomes of a plugin", func() {
+		target := plugin.Target{Kind: "Deployment", Namespace: "shop", Name: "backend", Containers: []string{"app"}}
+
+		It("should assume success for all targets if the plugin reports no outcomes", func() {
+			outcomes := convertOutcomes([]plugin.Target{target}, nil)
+			Expect(outcomes).To(HaveLen(1))
+			Expect(outcomes[0].Succeeded()).To(BeTrue())
+			Expect(outcomes[0].Object.Name).To(Equal("backend"))
+			Expect(outcomes[0].Containers).To(ConsistOf("app"))
+		})
+
+		It("should convert errors of individual targets", func() {
+			outcomes := convertOutcomes([]plugin.Target{target}, []plugin.Outcome{{Target: target, Error: "table is read-only"}})
+			Expect(outcomes).To(HaveLen(1))
+			Expect(outcomes[0].Error).To(MatchError("table is read-only"))
+		})
+	})
+
+	Context("When discovering plugins", func() {
+		var (
+			dir  string
+			impl *removalPlugin
+		)
+
+		BeforeEach(func() {
+			// Unix socket paths are limited in length, so we avoid the long temporary directories of Ginkgo
+			var err error
+			dir, err = os.MkdirTemp("", "koney-plugins")
+			Expect(err).NotTo(HaveOccurred())
+			DeferCleanup(os.RemoveAll, dir)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			DeferCleanup(cancel)
+
+			socketPath := filepath.Join(dir, "records.sock")
+			impl = &removalPlugin{}
+			go func() {
+				defer GinkgoRecover()
+				Expect(plugin.Serve(ctx, socketPath, impl)).To(Succeed())
+			}()
+			Eventually(func() error { _, err := os.Stat(socketPath); return err }).Should(Succeed())
+
+			// Files that are not sockets must be ignored
+			Expect(os.WriteFile(filepath.Join(dir, "README.sock"), []byte{}, 0o600)).To(Succeed())
+		})
+
+		It("should only find plugins that serve on a socket", func() {
+			registry := NewRegistry(dir)
+			Expect(registry.Names()).To(ConsistOf("records"))
+
+			_, err := registry.Get("records")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = registry.Get("missing")
+			Expect(errors.Is(err, ErrPluginNotFound)).To(BeTrue())
+		})
+
+		It("should not find plugins if plugins are disabled", func() {
+			registry := NewRegistry("")
+			Expect(registry.Names()).To(BeEmpty())
+
+			_, err := registry.Get("records")
+			Expect(errors.Is(err, ErrPluginNotFound)).To(BeTrue())
+		})
+
+		It("should let plugins remove all traps except the ones to keep", func() {
+			deceptionPolicy := &v1alpha1.DeceptionPolicy{}
+			deceptionPolicy.Name = "deceptionpolicy-records"
+			r := PluginTrapReconciler{Plugins: NewRegistry(dir), DeceptionPolicy: deceptionPolicy}
+
+			Expect(r.RemoveTraps(context.Background(), deceptionPolicy, []v1alpha1.Trap{trap})).To(Succeed())
+
+			trapID, err := GeneratePluginTrapID(trap)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(impl.removeDecoyRequests).To(HaveLen(1))
+			Expect(impl.removeDecoyRequests[0].Policy.Name).To(Equal("deceptionpolicy-records"))
+			Expect(impl.removeDecoyRequests[0].KeepTrapIDs).To(ConsistOf(trapID))
+		})
+
+		It("should report a missing plugin as failed decoy deployment", func() {
+			otherTrap := trap
+			otherTrap.Plugin.Name = "missing"
+			r := PluginTrapReconciler{Plugins: NewRegistry(dir)}
+
+			result := r.DeployDecoy(context.Background(), &v1alpha1.DeceptionPolicy{}, otherTrap)
+			Expect(result.ImpliesFailure()).To(BeTrue())
+			Expect(errors.Is(result.Errors, ErrPluginNotFound)).To(BeTrue())
+		})
+	})
+})
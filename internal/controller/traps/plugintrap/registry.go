@@ -0,0 +1,100 @@
+// Copyright (c) 2025 Dynatrace LLC
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package plugintrap
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/dynatrace-oss/koney/pkg/plugin"
+)
+
+// socketSuffix is the suffix of the Unix sockets that plugins serve on.
+const socketSuffix = ".sock"
+
+// ErrPluginNotFound is returned if no plugin with the given name serves in the plugin directory.
+var ErrPluginNotFound = errors.New("plugin not found")
+
+// Registry discovers trap plugins by their Unix sockets in a directory and caches the clients to them.
+// Plugins can come and go at any time, so the directory is checked again on every lookup.
+type Registry struct {
+	// Dir is the directory where plugins create their sockets. Plugins are disabled if it is empty.
+	Dir string
+
+	mu      sync.Mutex
+	clients map[string]*plugin.Client
+}
+
+// NewRegistry creates a registry that discovers plugins in a directory.
+func NewRegistry(dir string) *Registry {
+	return &Registry{Dir: dir, clients: map[string]*plugin.Client{}}
+}
+
+// Get returns the client of a plugin, or ErrPluginNotFound if its socket does not exist.
+func (r *Registry) Get(name string) (plugin.TrapPlugin, error) {
+	if r == nil || r.Dir == "" {
+		return nil, fmt.Errorf("%w: '%s' (plugins are disabled)", ErrPluginNotFound, name)
+	}
+
+	socketPath := filepath.Join(r.Dir, name+socketSuffix)
+	if _, err := os.Stat(socketPath); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("%w: '%s' (no socket at %s)", ErrPluginNotFound, name, socketPath)
+		}
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if client, ok := r.clients[name]; ok {
+		return client, nil
+	}
+
+	client, err := plugin.Dial(socketPath)
+	if err != nil {
+		return nil, err
+	}
+	r.clients[name] = client
+	return client, nil
+}
+
+// Names returns the names of all plugins that currently have a socket in the plugin directory.
+func (r *Registry) Names() ([]string, error) {
+	if r == nil || r.Dir == "" {
+		return nil, nil
+	}
+
+	entries, err := os.ReadDir(r.Dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if entry.Type()&os.ModeSocket != 0 && strings.HasSuffix(entry.Name(), socketSuffix) {
+			names = append(names, strings.TrimSuffix(entry.Name(), socketSuffix))
+		}
+	}
+	return names, nil
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2025 Dynatrace LLC
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package plugintrap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/dynatrace-oss/koney/api/v1alpha1"
+	"github.com/dynatrace-oss/koney/internal/controller/constants"
+	"github.com/dynatrace-oss/koney/pkg/plugin"
+)
+
+// RemoveTraps lets every discovered plugin remove the decoys and captors of a DeceptionPolicy,
+// except the ones of the given traps. Plugins that are not running anymore are skipped.
+func (r *PluginTrapReconciler) RemoveTraps(ctx context.Context, deceptionPolicy *v1alpha1.DeceptionPolicy, keepTraps []v1alpha1.Trap) error {
+	log := log.FromContext(ctx)
+
+	keepTrapIDs := map[string][]string{} // IDs of the traps to keep, per plugin
+	for _, trap := range keepTraps {
+		if trap.TrapType() != v1alpha1.PluginTrapType {
+			continue
+		}
+
+		trapID, err := GeneratePluginTrapID(trap)
+		if err != nil {
+			return err
+		}
+		keepTrapIDs[trap.Plugin.Name] = append(keepTrapIDs[trap.Plugin.Name], trapID)
+	}
+
+	names, err := r.Plugins.Names()
+	if err != nil {
+		return err
+	}
+
+	var joinedErrors error
+	for _, name := range names {
+		trapPlugin, err := r.Plugins.Get(name)
+		if err != nil {
+			joinedErrors = errors.Join(joinedErrors, err)
+			continue
+		}
+
+		request := &plugin.RemoveRequest{Policy: generatePolicyRef(deceptionPolicy), KeepTrapIDs: keepTrapIDs[name]}
+		if err := removeWithPlugin(ctx, trapPlugin.RemoveCaptors, request); err != nil {
+			log.Error(err, "trap plugin failed to remove captors", "plugin", name)
+			joinedErrors = errors.Join(joinedErrors, fmt.Errorf("plugin '%s' failed to remove captors: %w", name, err))
+		}
+		if err := removeWithPlugin(ctx, trapPlugin.RemoveDecoys, request); err != nil {
+			log.Error(err, "trap plugin failed to remove decoys", "plugin", name)
+			joinedErrors = errors.Join(joinedErrors, fmt.Errorf("plugin '%s' failed to remove decoys: %w", name, err))
+		}
+	}
+
+	return joinedErrors
+}
+
+// removeWithPlugin calls a remove function of a plugin, tolerating plugins that do not implement it.
+func removeWithPlugin(ctx context.Context, remove func(context.Context, *plugin.RemoveRequest) (*plugin.RemoveResponse, error), request *plugin.RemoveRequest) error {
+	callCtx, cancel := context.WithTimeout(ctx, constants.PluginCallTimeout)
+	defer cancel()
+
+	if _, err := remove(callCtx, request); err != nil && !plugin.IsUnimplemented(err) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2025 Dynatrace LLC
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package plugintrap
+
+import (
+	"encoding/json"
+	"errors"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/dynatrace-oss/koney/api/v1alpha1"
+	trapsapi "github.com/dynatrace-oss/koney/internal/controller/traps/api"
+	"github.com/dynatrace-oss/koney/internal/controller/utils"
+	"github.com/dynatrace-oss/koney/pkg/plugin"
+)
+
+// GeneratePluginTrapID generates an identifier for a plugin trap, which changes whenever the trap changes.
+func GeneratePluginTrapID(trap v1alpha1.Trap) (string, error) {
+	trapJSON, err := json.Marshal(struct {
+		Plugin          v1alpha1.PluginTrap       `json:"plugin"`
+		DecoyDeployment v1alpha1.DecoyDeployment  `json:"decoyDeployment"`
+		Captor          v1alpha1.CaptorDeployment `json:"captorDeployment"`
+		Match           v1alpha1.MatchResources   `json:"match"`
+	}{trap.Plugin, trap.DecoyDeployment, trap.CaptorDeployment, trap.MatchResources})
+	if err != nil {
+		return "", err
+	}
+
+	return utils.ShortHash(string(trapJSON)), nil
+}
+
+// generateTrapSpec converts a plugin trap to the specification that is sent to the plugin.
+func generateTrapSpec(trap v1alpha1.Trap) (plugin.TrapSpec, error) {
+	trapID, err := GeneratePluginTrapID(trap)
+	if err != nil {
+		return plugin.TrapSpec{}, err
+	}
+
+	return plugin.TrapSpec{
+		ID:             trapID,
+		Type:           trap.Plugin.Type,
+		Config:         trap.Plugin.Config,
+		DecoyStrategy:  trap.DecoyDeployment.Strategy,
+		CaptorStrategy: trap.CaptorDeployment.Strategy,
+		Match:          trap.MatchResources,
+	}, nil
+}
+
+// generatePolicyRef references a DeceptionPolicy in requests to plugins.
+func generatePolicyRef(deceptionPolicy *v1alpha1.DeceptionPolicy) plugin.PolicyRef {
+	return plugin.PolicyRef{Name: deceptionPolicy.Name, UID: string(deceptionPolicy.UID)}
+}
+
+// generateTarget converts a matched object (and its selected containers) to a target of a plugin.
+func generateTarget(object client.Object, containers []string) plugin.Target {
+	ref := trapsapi.NewObjectOutcome(object).Object
+	return plugin.Target{
+		Kind:       ref.Kind,
+		Namespace:  ref.Namespace,
+		Name:       ref.Name,
+		UID:        string(ref.UID),
+		Containers: containers,
+	}
+}
+
+// convertOutcomes converts the outcomes reported by a plugin to Koney's outcomes.
+// Targets without an outcome are assumed to be deployed to all selected containers.
+func convertOutcomes(targets []plugin.Target, pluginOutcomes []plugin.Outcome) []trapsapi.ObjectOutcome {
+	if len(pluginOutcomes) == 0 {
+		for _, target := range targets {
+			pluginOutcomes = append(pluginOutcomes, plugin.Outcome{Target: target, Containers: target.Containers})
+		}
+	}
+
+	outcomes := make([]trapsapi.ObjectOutcome, 0, len(pluginOutcomes))
+	for _, pluginOutcome := range pluginOutcomes {
+		outcome := trapsapi.ObjectOutcome{Containers: pluginOutcome.Containers, SkippedReason: pluginOutcome.SkippedReason}
+		outcome.Object.Kind = pluginOutcome.Target.Kind
+		outcome.Object.Namespace = pluginOutcome.Target.Namespace
+		outcome.Object.Name = pluginOutcome.Target.Name
+		if pluginOutcome.Error != "" {
+			outcome.Error = errors.New(pluginOutcome.Error)
+		}
+		outcomes = append(outcomes, outcome)
+	}
+	return outcomes
+}
@@ -0,0 +1,49 @@
+// Copyright (c) 2025 Dynatrace LLC
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package utils
+
+import "sync"
+
+// ForEachConcurrently calls fn for each item, with at most maxWorkers calls running at the same time.
+// The results are returned in the same order as the items. A maxWorkers of one or less calls fn sequentially.
+func ForEachConcurrently[T, R any](items []T, maxWorkers int, fn func(T) R) []R {
+	results := make([]R, len(items))
+
+	if maxWorkers <= 1 {
+		for i, item := range items {
+			results[i] = fn(item)
+		}
+		return results
+	}
+
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, maxWorkers)
+
+	for i, item := range items {
+		wg.Add(1)
+		semaphore <- struct{}{}
+
+		go func() {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			results[i] = fn(item)
+		}()
+	}
+
+	wg.Wait()
+	return results
+}
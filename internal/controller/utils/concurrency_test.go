@@ -0,0 +1,61 @@
+// Copyright (c) 2025 Dynatrace LLC
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package utils
+
+import (
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ForEachConcurrently", func() {
+	It("should return the results in the order of the items", func() {
+		items := []int{1, 2, 3, 4, 5, 6, 7, 8}
+		results := ForEachConcurrently(items, 3, func(item int) int {
+			time.Sleep(time.Duration(len(items)-item) * time.Millisecond)
+			return item * item
+		})
+		Expect(results).To(Equal([]int{1, 4, 9, 16, 25, 36, 49, 64}))
+	})
+
+	It("should not run more than maxWorkers calls at the same time", func() {
+		var running, maxRunning atomic.Int32
+		ForEachConcurrently(make([]struct{}, 20), 4, func(struct{}) bool {
+			current := running.Add(1)
+			for {
+				observed := maxRunning.Load()
+				if current <= observed || maxRunning.CompareAndSwap(observed, current) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			running.Add(-1)
+			return true
+		})
+		Expect(maxRunning.Load()).To(BeNumerically("<=", 4))
+		Expect(maxRunning.Load()).To(BeNumerically(">", 1))
+	})
+
+	It("should call the function sequentially without workers", func() {
+		var running atomic.Int32
+		results := ForEachConcurrently([]string{"a", "b"}, 0, func(item string) int32 {
+			return running.Add(1)
+		})
+		Expect(results).To(Equal([]int32{1, 2}))
+	})
+})
@@ -17,11 +17,34 @@ package utils
 
 import (
 	"crypto/md5"
+	"crypto/sha256"
 	"fmt"
 )
 
-// Hash returns the MD5 hash of the input string in hexadecimal format.
+// shortHashLength is the length of short hashes, which is the length of the MD5 hashes that older versions of Koney used.
+const shortHashLength = 32
+
+// Hash returns the SHA-256 hash of the input string in hexadecimal format.
 func Hash(input string) string {
+	hash := sha256.Sum256([]byte(input))
+	return fmt.Sprintf("%x", hash)
+}
+
+// ShortHash returns the SHA-256 hash of the input string in hexadecimal format, truncated to 32 characters.
+// Use it in names and label values, which must not be longer than 63 characters.
+func ShortHash(input string) string {
+	return Hash(input)[:shortHashLength]
+}
+
+// LegacyHash returns the MD5 hash of the input string in hexadecimal format.
+// Older versions of Koney used MD5 for all hashes, so it is only used to recognize hashes and names
+// in resources that were modified by these versions.
+func LegacyHash(input string) string {
 	hash := md5.Sum([]byte(input))
 	return fmt.Sprintf("%x", hash)
 }
+
+// HashMatches returns true if hash is the hash of the input string, created either with Hash or LegacyHash.
+func HashMatches(hash, input string) bool {
+	return hash == Hash(input) || hash == LegacyHash(input)
+}
@@ -0,0 +1,35 @@
+// Copyright (c) 2025 Dynatrace LLC
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package utils
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Hash", func() {
+	It("should hash with SHA-256", func() {
+		Expect(Hash("test")).To(Equal("9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"))
+		Expect(ShortHash("test")).To(Equal("9f86d081884c7d659a2feaa0c55ad015"))
+	})
+
+	It("should still recognize MD5 hashes of older versions", func() {
+		Expect(LegacyHash("test")).To(Equal("098f6bcd4621d373cade4e832627b4f6"))
+		Expect(HashMatches(Hash("test"), "test")).To(BeTrue())
+		Expect(HashMatches(LegacyHash("test"), "test")).To(BeTrue())
+		Expect(HashMatches(Hash("test"), "other")).To(BeFalse())
+	})
+})
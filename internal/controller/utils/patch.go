@@ -0,0 +1,67 @@
+// Copyright (c) 2025 Dynatrace LLC
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package utils
+
+import (
+	"context"
+
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/dynatrace-oss/koney/internal/controller/constants"
+)
+
+// PatchResource applies mutate to the object and patches only the changed fields, using Koney's field manager.
+// Unlike an update, a patch does not fail if someone else modified other fields of the object in the meantime.
+// Built-in types are patched with a strategic merge patch, which merges lists (e.g., containers, volumes,
+// or finalizers) by their keys. Other types (e.g., Koney's own resources) are patched with a JSON merge patch.
+// The object is updated with the response of the server. Nothing is sent if mutate did not change anything.
+func PatchResource(c client.Client, ctx context.Context, obj client.Object, mutate func() error) error {
+	return patchResource(c, ctx, obj, mutate, false)
+}
+
+// PatchResourceStatus works like PatchResource, but patches the status subresource of the object.
+func PatchResourceStatus(c client.Client, ctx context.Context, obj client.Object, mutate func() error) error {
+	return patchResource(c, ctx, obj, mutate, true)
+}
+
+func patchResource(c client.Client, ctx context.Context, obj client.Object, mutate func() error, status bool) error {
+	original := obj.DeepCopyObject().(client.Object)
+	if err := mutate(); err != nil {
+		return err
+	}
+
+	patch := patchFrom(original)
+	data, err := patch.Data(obj)
+	if err != nil {
+		return err
+	} else if string(data) == "{}" {
+		return nil
+	}
+
+	if status {
+		return c.Status().Patch(ctx, obj, patch, client.FieldOwner(constants.FieldManager))
+	}
+	return c.Patch(ctx, obj, patch, client.FieldOwner(constants.FieldManager))
+}
+
+// patchFrom returns the patch type that is suitable for the type of the object.
+func patchFrom(original client.Object) client.Patch {
+	if _, _, err := scheme.Scheme.ObjectKinds(original); err == nil {
+		return client.StrategicMergeFrom(original)
+	}
+	return client.MergeFrom(original)
+}
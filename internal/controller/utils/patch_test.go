@@ -0,0 +1,142 @@
+// Copyright (c) 2025 Dynatrace LLC
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package utils
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/dynatrace-oss/koney/api/v1alpha1"
+)
+
+var _ = Describe("PatchResource", func() {
+	ctx := context.Background()
+
+	var fakeClient client.Client
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(v1alpha1.AddToScheme(scheme)).To(Succeed())
+
+		deployment := &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-deployment", Namespace: "default"},
+			Spec: appsv1.DeploymentSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{{Name: "nginx"}, {Name: "sidecar"}},
+					},
+				},
+			},
+		}
+		deceptionPolicy := &v1alpha1.DeceptionPolicy{ObjectMeta: metav1.ObjectMeta{Name: "test-deception-policy"}}
+
+		fakeClient = fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(deployment, deceptionPolicy).
+			WithStatusSubresource(deceptionPolicy).
+			Build()
+	})
+
+	It("should not conflict with changes of others to other fields", func() {
+		staleDeployment := &appsv1.Deployment{}
+		Expect(fakeClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: "test-deployment"}, staleDeployment)).To(Succeed())
+
+		By("modifying the deployment concurrently")
+		otherDeployment := staleDeployment.DeepCopy()
+		otherDeployment.Labels = map[string]string{"owner": "someone-else"}
+		Expect(fakeClient.Update(ctx, otherDeployment)).To(Succeed())
+
+		By("patching the stale deployment")
+		Expect(PatchResource(fakeClient, ctx, staleDeployment, func() error {
+			staleDeployment.Annotations = map[string]string{"koney/test": "true"}
+			return nil
+		})).To(Succeed())
+
+		deployment := &appsv1.Deployment{}
+		Expect(fakeClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: "test-deployment"}, deployment)).To(Succeed())
+		Expect(deployment.Labels).To(HaveKeyWithValue("owner", "someone-else"))
+		Expect(deployment.Annotations).To(HaveKeyWithValue("koney/test", "true"))
+	})
+
+	It("should merge lists of built-in types by their keys", func() {
+		deployment := &appsv1.Deployment{}
+		Expect(fakeClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: "test-deployment"}, deployment)).To(Succeed())
+
+		Expect(PatchResource(fakeClient, ctx, deployment, func() error {
+			deployment.Spec.Template.Spec.Containers = deployment.Spec.Template.Spec.Containers[:1]
+			return nil
+		})).To(Succeed())
+
+		Expect(fakeClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: "test-deployment"}, deployment)).To(Succeed())
+		Expect(deployment.Spec.Template.Spec.Containers).To(HaveLen(1))
+		Expect(deployment.Spec.Template.Spec.Containers[0].Name).To(Equal("nginx"))
+	})
+
+	It("should not send anything if nothing changed", func() {
+		deployment := &appsv1.Deployment{}
+		Expect(fakeClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: "test-deployment"}, deployment)).To(Succeed())
+		resourceVersion := deployment.ResourceVersion
+
+		Expect(PatchResource(fakeClient, ctx, deployment, func() error { return nil })).To(Succeed())
+
+		Expect(fakeClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: "test-deployment"}, deployment)).To(Succeed())
+		Expect(deployment.ResourceVersion).To(Equal(resourceVersion))
+	})
+
+	It("should not send anything if mutate fails", func() {
+		deployment := &appsv1.Deployment{}
+		Expect(fakeClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: "test-deployment"}, deployment)).To(Succeed())
+
+		err := PatchResource(fakeClient, ctx, deployment, func() error {
+			deployment.Annotations = map[string]string{"koney/test": "true"}
+			return errors.New("some error")
+		})
+		Expect(err).To(MatchError("some error"))
+
+		Expect(fakeClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: "test-deployment"}, deployment)).To(Succeed())
+		Expect(deployment.Annotations).NotTo(HaveKey("koney/test"))
+	})
+
+	It("should patch Koney's own resources and their status", func() {
+		deceptionPolicy := &v1alpha1.DeceptionPolicy{}
+		Expect(fakeClient.Get(ctx, client.ObjectKey{Name: "test-deception-policy"}, deceptionPolicy)).To(Succeed())
+
+		Expect(PatchResource(fakeClient, ctx, deceptionPolicy, func() error {
+			deceptionPolicy.Finalizers = append(deceptionPolicy.Finalizers, "koney/finalizer")
+			return nil
+		})).To(Succeed())
+
+		Expect(PatchResourceStatus(fakeClient, ctx, deceptionPolicy, func() error {
+			deceptionPolicy.Status.PutCondition("ResourceFound", metav1.ConditionTrue, "ResourceFound", "found")
+			return nil
+		})).To(Succeed())
+
+		Expect(fakeClient.Get(ctx, client.ObjectKey{Name: "test-deception-policy"}, deceptionPolicy)).To(Succeed())
+		Expect(deceptionPolicy.Finalizers).To(ConsistOf("koney/finalizer"))
+		Expect(deceptionPolicy.Status.Conditions).To(HaveLen(1))
+	})
+})
@@ -25,6 +25,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/dynatrace-oss/koney/api/v1alpha1"
+	"github.com/dynatrace-oss/koney/internal/controller/constants"
+	"github.com/dynatrace-oss/koney/internal/controller/contentsources"
 )
 
 func HandleWatchEvent(r client.Reader, ctx context.Context, obj client.Object) []reconcile.Request {
@@ -59,6 +61,71 @@ func HandleWatchEvent(r client.Reader, ctx context.Context, obj client.Object) [
 	return reconcileRequests
 }
 
+// HandleTracingPolicyWatchEvent reconciles the deception policy that created a Tetragon tracing policy,
+// so that the tracing policy is restored if someone else changed or deleted it.
+func HandleTracingPolicyWatchEvent(ctx context.Context, obj client.Object) []reconcile.Request {
+	policyName, ok := obj.GetLabels()[constants.LabelKeyDeceptionPolicyRef]
+	if !ok || policyName == "" {
+		// Not a tracing policy created by Koney
+		return []reconcile.Request{}
+	}
+
+	log.FromContext(ctx).Info(fmt.Sprintf("Sending reconcile request to %v (triggered by watching tracing policy %s) ...", policyName, obj.GetName()))
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: policyName}}}
+}
+
+// HandleContentSourceWatchEvent reconciles the deception policies whose traps source their content from a Secret or ConfigMap,
+// so that decoys are redeployed with the new content when the Secret or ConfigMap changes.
+func HandleContentSourceWatchEvent(r client.Reader, ctx context.Context, obj client.Object) []reconcile.Request {
+	log := log.FromContext(ctx)
+
+	if obj.GetNamespace() != constants.KoneyNamespace {
+		// Content is only sourced from the Koney namespace
+		return []reconcile.Request{}
+	}
+
+	deceptionPolicies, err := listAllDeceptionPolicies(r, ctx)
+	if err != nil {
+		log.Error(err, "Unable to list DeceptionPolicies while watching content sources")
+		return []reconcile.Request{}
+	}
+
+	reconcileRequests := []reconcile.Request{}
+	for i := range deceptionPolicies {
+		if contentsources.ReferencesObject(&deceptionPolicies[i], obj) {
+			reconcileRequests = append(reconcileRequests, reconcile.Request{NamespacedName: types.NamespacedName{Name: deceptionPolicies[i].Name}})
+			log.Info(fmt.Sprintf("Sending reconcile request to %v (triggered by watching content source %s) ...", deceptionPolicies[i].Name, obj.GetName()))
+		}
+	}
+
+	return reconcileRequests
+}
+
+// HandleKoneyConfigWatchEvent reconciles all deception policies when the KoneyConfig changes,
+// so that changes of the operator-wide settings are applied without restarting the controller.
+func HandleKoneyConfigWatchEvent(r client.Reader, ctx context.Context, obj client.Object) []reconcile.Request {
+	log := log.FromContext(ctx)
+
+	if obj.GetName() != v1alpha1.KoneyConfigName {
+		// Only the KoneyConfig with the well-known name is read
+		return []reconcile.Request{}
+	}
+
+	deceptionPolicies, err := listAllDeceptionPolicies(r, ctx)
+	if err != nil {
+		log.Error(err, "Unable to list DeceptionPolicies while watching the KoneyConfig")
+		return []reconcile.Request{}
+	}
+
+	reconcileRequests := make([]reconcile.Request, 0, len(deceptionPolicies))
+	for _, deceptionPolicy := range deceptionPolicies {
+		reconcileRequests = append(reconcileRequests, reconcile.Request{NamespacedName: types.NamespacedName{Name: deceptionPolicy.Name}})
+		log.Info(fmt.Sprintf("Sending reconcile request to %v (triggered by watching KoneyConfig %s) ...", deceptionPolicy.Name, obj.GetName()))
+	}
+
+	return reconcileRequests
+}
+
 func listAllDeceptionPolicies(r client.Reader, ctx context.Context) ([]v1alpha1.DeceptionPolicy, error) {
 	deceptionPolicyList := v1alpha1.DeceptionPolicyList{}
 	if err := r.List(ctx, &deceptionPolicyList); err != nil {
@@ -0,0 +1,122 @@
+// Copyright (c) 2025 Dynatrace LLC
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package webhookauth
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/url"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/dynatrace-oss/koney/internal/controller/constants"
+)
+
+// keySize is the size (in bytes) of newly generated HMAC keys.
+const keySize = 32
+
+// GetOrCreateKey returns the HMAC key that is shared between Koney and the alert forwarder.
+// If the secret holding the key does not exist yet, a new random key is generated and stored.
+func GetOrCreateKey(c client.Client, ctx context.Context) ([]byte, error) {
+	secretKey := client.ObjectKey{Namespace: constants.KoneyNamespace, Name: constants.WebhookAuthSecretName}
+
+	secret := corev1.Secret{}
+	err := c.Get(ctx, secretKey, &secret)
+	if err == nil {
+		key := secret.Data[constants.WebhookAuthSecretKey]
+		if len(key) == 0 {
+			return nil, fmt.Errorf("secret %s does not contain the key %s", secretKey, constants.WebhookAuthSecretKey)
+		}
+		return key, nil
+	}
+	if client.IgnoreNotFound(err) != nil {
+		return nil, err
+	}
+
+	key := make([]byte, keySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+
+	secret = corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      secretKey.Name,
+			Namespace: secretKey.Namespace,
+		},
+		Data: map[string][]byte{constants.WebhookAuthSecretKey: key},
+	}
+	if err := c.Create(ctx, &secret); err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			// Someone else created the key in the meantime, retry later to read theirs
+			return nil, fmt.Errorf("secret %s was created concurrently: %w", secretKey, err)
+		}
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// Sign returns the hex-encoded HMAC-SHA256 signature of the message.
+func Sign(key []byte, message string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(message)) //nolint:errcheck
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// SignURL appends the name of the deception policy and its signature as query parameters to the webhook URL.
+// The alert forwarder rejects requests without a valid signature for the deception policy.
+func SignURL(key []byte, webhookURL, deceptionPolicyName string) (string, error) {
+	parsedURL, err := url.Parse(webhookURL)
+	if err != nil {
+		return "", err
+	}
+
+	query := parsedURL.Query()
+	query.Set(constants.WebhookPolicyParam, deceptionPolicyName)
+	query.Set(constants.WebhookSignatureParam, Sign(key, deceptionPolicyName))
+	parsedURL.RawQuery = query.Encode()
+
+	return parsedURL.String(), nil
+}
+
+// WebhookURL returns the URL of an alert forwarder handler on the given host, which may also be an IPv6 address.
+// If the host is empty, the default host of the alert forwarder is used.
+func WebhookURL(host, path string) string {
+	if host == "" {
+		host = constants.DefaultAlertWebhookHost
+	}
+
+	// JoinHostPort wraps IPv6 addresses in brackets
+	return "http://" + net.JoinHostPort(host, constants.AlertWebhookPort) + path
+}
+
+// GetSignedURL signs the webhook URL for the deception policy with the shared HMAC key (see SignURL).
+func GetSignedURL(c client.Client, ctx context.Context, webhookURL, deceptionPolicyName string) (string, error) {
+	key, err := GetOrCreateKey(c, ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return SignURL(key, webhookURL, deceptionPolicyName)
+}
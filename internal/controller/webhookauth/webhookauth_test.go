@@ -0,0 +1,121 @@
+// Copyright (c) 2025 Dynatrace LLC
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package webhookauth
+
+import (
+	"context"
+	"net/url"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/dynatrace-oss/koney/internal/controller/constants"
+)
+
+var _ = Describe("GetOrCreateKey", func() {
+	ctx := context.Background()
+
+	It("should generate and store a new key if none exists", func() {
+		fakeClient := fake.NewClientBuilder().Build()
+
+		key, err := GetOrCreateKey(fakeClient, ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(key).To(HaveLen(keySize))
+
+		By("returning the stored key on subsequent calls")
+		sameKey, err := GetOrCreateKey(fakeClient, ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(sameKey).To(Equal(key))
+	})
+
+	It("should return the key of an existing secret", func() {
+		secret := corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: constants.WebhookAuthSecretName, Namespace: constants.KoneyNamespace},
+			Data:       map[string][]byte{constants.WebhookAuthSecretKey: []byte("existing-key")},
+		}
+		fakeClient := fake.NewClientBuilder().WithObjects(&secret).Build()
+
+		key, err := GetOrCreateKey(fakeClient, ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(key).To(Equal([]byte("existing-key")))
+	})
+
+	It("should fail if the existing secret has no key", func() {
+		secret := corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: constants.WebhookAuthSecretName, Namespace: constants.KoneyNamespace},
+		}
+		fakeClient := fake.NewClientBuilder().WithObjects(&secret).Build()
+
+		_, err := GetOrCreateKey(fakeClient, ctx)
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("SignURL", func() {
+	key := []byte("some-key")
+
+	It("should append the policy name and its signature", func() {
+		signedURL, err := SignURL(key, constants.TetragonWebhookUrl, "deceptionpolicy-sample")
+		Expect(err).NotTo(HaveOccurred())
+
+		parsedURL, err := url.Parse(signedURL)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(parsedURL.Path).To(Equal("/handlers/tetragon"))
+		Expect(parsedURL.Query().Get(constants.WebhookPolicyParam)).To(Equal("deceptionpolicy-sample"))
+		Expect(parsedURL.Query().Get(constants.WebhookSignatureParam)).To(Equal(Sign(key, "deceptionpolicy-sample")))
+	})
+
+	It("should produce different signatures for different policies and keys", func() {
+		Expect(Sign(key, "policy-a")).NotTo(Equal(Sign(key, "policy-b")))
+		Expect(Sign(key, "policy-a")).NotTo(Equal(Sign([]byte("other-key"), "policy-a")))
+	})
+
+	It("should produce the well-known HMAC-SHA256 signature", func() {
+		// test vector from RFC 4231 (test case 2)
+		Expect(Sign([]byte("Jefe"), "what do ya want for nothing?")).To(
+			Equal("5bdcc146bf60754e6a042426089575c75a003f089d2739839dec58b964ec3843"))
+	})
+})
+
+var _ = Describe("WebhookURL", func() {
+	It("should use the default host if none is given", func() {
+		Expect(WebhookURL("", constants.TetragonWebhookPath)).To(Equal(constants.TetragonWebhookUrl))
+	})
+
+	It("should accept host names and IPv4 addresses", func() {
+		Expect(WebhookURL("alerts.example.com", constants.SidecarWebhookPath)).To(
+			Equal("http://alerts.example.com:8000/handlers/sidecar"))
+		Expect(WebhookURL("10.96.0.42", constants.TetragonWebhookPath)).To(
+			Equal("http://10.96.0.42:8000/handlers/tetragon"))
+	})
+
+	It("should wrap IPv6 addresses in brackets", func() {
+		webhookURL := WebhookURL("fd00:10:96::42", constants.TetragonWebhookPath)
+		Expect(webhookURL).To(Equal("http://[fd00:10:96::42]:8000/handlers/tetragon"))
+
+		By("keeping the URL signable")
+		signedURL, err := SignURL([]byte("some-key"), webhookURL, "deceptionpolicy-sample")
+		Expect(err).NotTo(HaveOccurred())
+
+		parsedURL, err := url.Parse(signedURL)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(parsedURL.Hostname()).To(Equal("fd00:10:96::42"))
+		Expect(parsedURL.Port()).To(Equal("8000"))
+	})
+})
@@ -0,0 +1,255 @@
+// Copyright (c) 2025 Dynatrace LLC
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package operator lets other operators embed Koney's controllers into their own manager binary.
+//
+// Register the API types with AddToScheme before creating the manager,
+// then call SetupWithManager to add the controllers to it:
+//
+//	scheme := runtime.NewScheme()
+//	utilruntime.Must(operator.AddToScheme(scheme))
+//	mgr, err := ctrl.NewManager(cfg, ctrl.Options{Scheme: scheme})
+//	...
+//	err = operator.SetupWithManager(mgr, operator.DefaultOptions())
+//
+// The manager must run with the permissions of Koney's manager-role (see config/rbac/role.yaml).
+package operator
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"maps"
+	"net"
+	"net/url"
+	"time"
+
+	ciliumiov1alpha1 "github.com/cilium/tetragon/pkg/k8s/apis/cilium.io/v1alpha1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+
+	"github.com/dynatrace-oss/koney/api/v1alpha1"
+	"github.com/dynatrace-oss/koney/internal/controller"
+	"github.com/dynatrace-oss/koney/internal/controller/audit"
+	"github.com/dynatrace-oss/koney/internal/controller/constants"
+	"github.com/dynatrace-oss/koney/internal/controller/traps/plugintrap"
+	"github.com/dynatrace-oss/koney/pkg/tokenprovider"
+)
+
+// Options configures the Koney controllers that are added to a manager.
+type Options struct {
+	// MaxAnnotationSize is the maximum size (in bytes) of the changes annotation that Koney places on resources.
+	// Older changes are spilled into a companion ConfigMap if the annotation would grow larger. Use 0 to disable.
+	MaxAnnotationSize int
+
+	// MaxConcurrentDeployments is the maximum number of resources (e.g., pods) that decoys are deployed to at the same time.
+	MaxConcurrentDeployments int
+
+	// RetryInterval is how soon the deployment of traps is retried if resources are not ready yet (e.g., containers are starting).
+	// DeceptionPolicies may override it with spec.retryInterval.
+	RetryInterval time.Duration
+
+	// SyncInterval is how often traps are checked again after they were deployed successfully. Use 0 to disable.
+	// DeceptionPolicies may override it with spec.syncInterval.
+	SyncInterval time.Duration
+
+	// VerifyDecoys checks once per sync interval if deployed decoys are still in place, and redeploys them otherwise.
+	// Decoys that were changed in the meantime raise a TrapTampered alert.
+	VerifyDecoys bool
+
+	// FalcoNamespace is the namespace where Falco is running.
+	// Captors with the falco strategy write their rules into a ConfigMap in this namespace.
+	FalcoNamespace string
+
+	// PluginDir is the directory where trap plugins serve on their Unix sockets ("<name>.sock").
+	// Plugin traps cannot be deployed if it is empty.
+	PluginDir string
+
+	// CanarytokensURL is the URL of the Canarytokens instance that mints canary tokens of the "canarytokens" provider,
+	// e.g., a self-hosted instance. The provider is disabled if it is empty.
+	CanarytokensURL string
+
+	// TokenProviders are additional providers that mint canary tokens, by the names that DeceptionPolicies reference.
+	// They take precedence over the built-in "canarytokens" provider.
+	TokenProviders tokenprovider.Providers
+
+	// AlertWebhookHost is the host that captors send their alerts to, i.e., the Service of the alert forwarder.
+	// It may be a DNS name, or an IPv4 or IPv6 address (e.g., for captors that cannot resolve cluster DNS names).
+	AlertWebhookHost string
+
+	// AuditLogEntries is the number of recent audit log entries (i.e., mutations that Koney performed in the cluster)
+	// that are kept in the koney-audit-log ConfigMap. All entries are written to the "audit" log stream regardless. Use 0 to disable.
+	AuditLogEntries int
+
+	// EnableHealthChecks adds health and readiness checks named "koney" to the manager.
+	// Embedding managers that already serve their own checks may leave this disabled.
+	EnableHealthChecks bool
+}
+
+// DefaultOptions returns the options that the standalone Koney deployment uses.
+func DefaultOptions() Options {
+	return Options{
+		MaxAnnotationSize:        constants.DefaultMaxAnnotationSize,
+		MaxConcurrentDeployments: constants.DefaultMaxConcurrentDeployments,
+		RetryInterval:            constants.ShortStatusCheckInterval,
+		SyncInterval:             constants.DefaultSyncInterval,
+		VerifyDecoys:             true,
+		FalcoNamespace:           constants.DefaultFalcoNamespace,
+		PluginDir:                constants.DefaultPluginDir,
+		CanarytokensURL:          tokenprovider.DefaultCanarytokensURL,
+		AlertWebhookHost:         constants.DefaultAlertWebhookHost,
+		EnableHealthChecks:       true,
+	}
+}
+
+// BindFlags binds the options to command line flags, using the current values as defaults.
+func (o *Options) BindFlags(fs *flag.FlagSet) {
+	fs.IntVar(&o.MaxAnnotationSize, "max-annotation-size", o.MaxAnnotationSize,
+		"The maximum size (in bytes) of the changes annotation that Koney places on resources. "+
+			"Older changes are spilled into a companion ConfigMap if the annotation would grow larger. Use 0 to disable.")
+	fs.IntVar(&o.MaxConcurrentDeployments, "max-concurrent-deployments", o.MaxConcurrentDeployments,
+		"The maximum number of resources (e.g., pods) that decoys are deployed to at the same time. Use 1 to deploy one after the other.")
+	fs.DurationVar(&o.RetryInterval, "retry-interval", o.RetryInterval,
+		"How soon the deployment of traps is retried if resources are not ready yet. DeceptionPolicies may override it with spec.retryInterval.")
+	fs.DurationVar(&o.SyncInterval, "sync-interval", o.SyncInterval,
+		"How often traps are checked again after they were deployed successfully. Use 0 to disable. DeceptionPolicies may override it with spec.syncInterval.")
+	fs.BoolVar(&o.VerifyDecoys, "verify-decoys", o.VerifyDecoys,
+		"Check once per sync interval if decoys are still in place, redeploy them otherwise, and alert if they were changed.")
+	fs.StringVar(&o.FalcoNamespace, "falco-namespace", o.FalcoNamespace,
+		"The namespace where Falco is running. Captors with the falco strategy write their rules into a ConfigMap in this namespace.")
+	fs.StringVar(&o.PluginDir, "plugin-dir", o.PluginDir,
+		"The directory where trap plugins serve on their Unix sockets (<name>.sock). Use an empty value to disable plugins.")
+	fs.StringVar(&o.CanarytokensURL, "canarytokens-url", o.CanarytokensURL,
+		"The URL of the Canarytokens instance that mints canary tokens of the canarytokens provider. Use an empty value to disable it.")
+	fs.StringVar(&o.AlertWebhookHost, "alert-webhook-host", o.AlertWebhookHost,
+		"The host that captors send their alerts to, i.e., the Service of the alert forwarder. "+
+			"May be a DNS name, or an IPv4 or IPv6 address (without brackets).")
+	fs.IntVar(&o.AuditLogEntries, "audit-log-entries", o.AuditLogEntries,
+		"The number of recent audit log entries that are kept in the koney-audit-log ConfigMap. "+
+			"All entries are written to the audit log stream regardless. Use 0 to disable the ConfigMap.")
+}
+
+// AddToScheme registers all types that Koney's controllers read or write:
+// the Kubernetes built-in types, Koney's own API types, and Tetragon's TracingPolicy types.
+func AddToScheme(scheme *runtime.Scheme) error {
+	return errors.Join(
+		clientgoscheme.AddToScheme(scheme),
+		ciliumiov1alpha1.AddToScheme(scheme),
+		v1alpha1.AddToScheme(scheme),
+	)
+}
+
+// SetupWithManager adds Koney's controllers to the manager.
+// The scheme of the manager must already contain all types registered by AddToScheme.
+func SetupWithManager(mgr ctrl.Manager, opts Options) error {
+	if err := validateOptions(mgr.GetScheme(), opts); err != nil {
+		return err
+	}
+
+	// All mutations that Koney performs are recorded in the audit log
+	ownedClient := client.WithFieldOwner(mgr.GetClient(), constants.FieldManager)
+	auditLog := audit.NewLog(ownedClient, opts.AuditLogEntries)
+	auditedClient := audit.WrapClient(ownedClient, auditLog)
+
+	if err := (&controller.DeceptionPolicyReconciler{
+		Client:                   auditedClient,
+		Scheme:                   mgr.GetScheme(),
+		MaxAnnotationSize:        opts.MaxAnnotationSize,
+		MaxConcurrentDeployments: opts.MaxConcurrentDeployments,
+		RetryInterval:            opts.RetryInterval,
+		SyncInterval:             opts.SyncInterval,
+		VerifyDecoys:             opts.VerifyDecoys,
+		FalcoNamespace:           opts.FalcoNamespace,
+		AlertWebhookHost:         opts.AlertWebhookHost,
+		Plugins:                  plugintrap.NewRegistry(opts.PluginDir),
+		TokenProviders:           buildTokenProviders(opts),
+		Audit:                    auditLog,
+	}).SetupWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create controller DeceptionPolicy: %w", err)
+	}
+
+	if err := (&controller.NamespacedDeceptionPolicyReconciler{
+		Client: auditedClient,
+		Scheme: mgr.GetScheme(),
+		Audit:  auditLog,
+	}).SetupWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create controller NamespacedDeceptionPolicy: %w", err)
+	}
+
+	if opts.EnableHealthChecks {
+		if err := mgr.AddHealthzCheck("koney", healthz.Ping); err != nil {
+			return fmt.Errorf("unable to set up health check: %w", err)
+		}
+		if err := mgr.AddReadyzCheck("koney", healthz.Ping); err != nil {
+			return fmt.Errorf("unable to set up ready check: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// buildTokenProviders returns the built-in token providers that are enabled, together with the additional ones.
+func buildTokenProviders(opts Options) tokenprovider.Providers {
+	providers := tokenprovider.Providers{}
+	if opts.CanarytokensURL != "" {
+		providers[tokenprovider.CanarytokensProviderName] = tokenprovider.NewCanarytokens(opts.CanarytokensURL, nil)
+	}
+	maps.Copy(providers, opts.TokenProviders)
+	return providers
+}
+
+// validateOptions checks that the options are usable and that the scheme knows all required types.
+func validateOptions(scheme *runtime.Scheme, opts Options) error {
+	if opts.MaxAnnotationSize < 0 {
+		return fmt.Errorf("max annotation size must not be negative, got %d", opts.MaxAnnotationSize)
+	}
+	if opts.MaxConcurrentDeployments < 1 {
+		return fmt.Errorf("max concurrent deployments must be at least 1, got %d", opts.MaxConcurrentDeployments)
+	}
+	if opts.RetryInterval < v1alpha1.MinRetryInterval {
+		return fmt.Errorf("retry interval must be at least %s, got %s", v1alpha1.MinRetryInterval, opts.RetryInterval)
+	}
+	if opts.SyncInterval < 0 {
+		return fmt.Errorf("sync interval must not be negative, got %s", opts.SyncInterval)
+	}
+	if opts.AuditLogEntries < 0 {
+		return fmt.Errorf("audit log entries must not be negative, got %d", opts.AuditLogEntries)
+	}
+	if opts.FalcoNamespace == "" {
+		return errors.New("falco namespace must not be empty")
+	}
+	if opts.CanarytokensURL != "" {
+		if u, err := url.Parse(opts.CanarytokensURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("canarytokens URL must be an absolute http(s) URL, got '%s'", opts.CanarytokensURL)
+		}
+	}
+	if opts.AlertWebhookHost != "" && net.ParseIP(opts.AlertWebhookHost) == nil {
+		if errs := validation.IsDNS1123Subdomain(opts.AlertWebhookHost); len(errs) > 0 {
+			return fmt.Errorf("alert webhook host must be a DNS name or an IP address, got '%s'", opts.AlertWebhookHost)
+		}
+	}
+
+	for _, obj := range []runtime.Object{&v1alpha1.DeceptionPolicy{}, &ciliumiov1alpha1.TracingPolicy{}} {
+		if _, _, err := scheme.ObjectKinds(obj); err != nil {
+			return fmt.Errorf("scheme is incomplete, call AddToScheme before creating the manager: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,181 @@
+// Copyright (c) 2025 Dynatrace LLC
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package operator
+
+import (
+	"flag"
+	"time"
+
+	ciliumiov1alpha1 "github.com/cilium/tetragon/pkg/k8s/apis/cilium.io/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/dynatrace-oss/koney/api/v1alpha1"
+	"github.com/dynatrace-oss/koney/internal/controller/constants"
+	"github.com/dynatrace-oss/koney/pkg/tokenprovider"
+)
+
+var _ = Describe("AddToScheme", func() {
+	It("should register all types that the controllers use", func() {
+		scheme := runtime.NewScheme()
+		Expect(AddToScheme(scheme)).To(Succeed())
+
+		for _, obj := range []runtime.Object{
+			&v1alpha1.DeceptionPolicy{},
+			&v1alpha1.DeceptionAlertSink{},
+			&ciliumiov1alpha1.TracingPolicy{},
+			&corev1.Pod{},
+		} {
+			_, _, err := scheme.ObjectKinds(obj)
+			Expect(err).NotTo(HaveOccurred())
+		}
+	})
+})
+
+var _ = Describe("Options", func() {
+	It("should default to the options of the standalone deployment", func() {
+		opts := DefaultOptions()
+		Expect(opts.MaxAnnotationSize).To(Equal(constants.DefaultMaxAnnotationSize))
+		Expect(opts.MaxConcurrentDeployments).To(Equal(constants.DefaultMaxConcurrentDeployments))
+		Expect(opts.RetryInterval).To(Equal(constants.ShortStatusCheckInterval))
+		Expect(opts.SyncInterval).To(Equal(constants.DefaultSyncInterval))
+		Expect(opts.VerifyDecoys).To(BeTrue())
+		Expect(opts.FalcoNamespace).To(Equal(constants.DefaultFalcoNamespace))
+		Expect(opts.CanarytokensURL).To(Equal(tokenprovider.DefaultCanarytokensURL))
+		Expect(opts.EnableHealthChecks).To(BeTrue())
+	})
+
+	It("should bind to command line flags", func() {
+		opts := DefaultOptions()
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		opts.BindFlags(fs)
+
+		Expect(fs.Parse([]string{"--max-annotation-size=1024", "--max-concurrent-deployments=32", "--retry-interval=30s", "--sync-interval=0", "--verify-decoys=false", "--falco-namespace=security", "--alert-webhook-host=fd00::42"})).To(Succeed())
+		Expect(opts.MaxAnnotationSize).To(Equal(1024))
+		Expect(opts.MaxConcurrentDeployments).To(Equal(32))
+		Expect(opts.RetryInterval).To(Equal(30 * time.Second))
+		Expect(opts.SyncInterval).To(BeZero())
+		Expect(opts.VerifyDecoys).To(BeFalse())
+		Expect(opts.FalcoNamespace).To(Equal("security"))
+		Expect(opts.AlertWebhookHost).To(Equal("fd00::42"))
+	})
+
+	It("should keep the current values as flag defaults", func() {
+		opts := Options{MaxAnnotationSize: 42, FalcoNamespace: "custom"}
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		opts.BindFlags(fs)
+
+		Expect(fs.Parse([]string{})).To(Succeed())
+		Expect(opts.MaxAnnotationSize).To(Equal(42))
+		Expect(opts.FalcoNamespace).To(Equal("custom"))
+	})
+})
+
+var _ = Describe("validateOptions", func() {
+	var scheme *runtime.Scheme
+
+	BeforeEach(func() {
+		scheme = runtime.NewScheme()
+		Expect(AddToScheme(scheme)).To(Succeed())
+	})
+
+	It("should accept the default options", func() {
+		Expect(validateOptions(scheme, DefaultOptions())).To(Succeed())
+	})
+
+	It("should reject a negative annotation size", func() {
+		opts := DefaultOptions()
+		opts.MaxAnnotationSize = -1
+		Expect(validateOptions(scheme, opts)).NotTo(Succeed())
+	})
+
+	It("should reject less than one concurrent deployment", func() {
+		opts := DefaultOptions()
+		opts.MaxConcurrentDeployments = 0
+		Expect(validateOptions(scheme, opts)).NotTo(Succeed())
+	})
+
+	It("should reject intervals that are too short", func() {
+		opts := DefaultOptions()
+		opts.RetryInterval = 0
+		Expect(validateOptions(scheme, opts)).NotTo(Succeed())
+
+		opts = DefaultOptions()
+		opts.SyncInterval = -time.Second
+		Expect(validateOptions(scheme, opts)).NotTo(Succeed())
+	})
+
+	It("should reject a negative number of audit log entries", func() {
+		opts := DefaultOptions()
+		opts.AuditLogEntries = -1
+		Expect(validateOptions(scheme, opts)).NotTo(Succeed())
+	})
+
+	It("should reject an empty Falco namespace", func() {
+		opts := DefaultOptions()
+		opts.FalcoNamespace = ""
+		Expect(validateOptions(scheme, opts)).NotTo(Succeed())
+	})
+
+	It("should accept IPv4 and IPv6 addresses as alert webhook host", func() {
+		opts := DefaultOptions()
+		for _, host := range []string{"10.96.0.42", "fd00:10:96::42", "alerts.example.com"} {
+			opts.AlertWebhookHost = host
+			Expect(validateOptions(scheme, opts)).To(Succeed())
+		}
+	})
+
+	It("should reject an invalid alert webhook host", func() {
+		opts := DefaultOptions()
+		for _, host := range []string{"[fd00:10:96::42]", "alerts.example.com:8000", "http://alerts"} {
+			opts.AlertWebhookHost = host
+			Expect(validateOptions(scheme, opts)).NotTo(Succeed())
+		}
+	})
+
+	It("should reject a Canarytokens URL that is not absolute", func() {
+		opts := DefaultOptions()
+		for _, canarytokensURL := range []string{"canarytokens.org", "ftp://canarytokens.org", "https://"} {
+			opts.CanarytokensURL = canarytokensURL
+			Expect(validateOptions(scheme, opts)).NotTo(Succeed())
+		}
+
+		opts.CanarytokensURL = ""
+		Expect(validateOptions(scheme, opts)).To(Succeed())
+	})
+
+	It("should reject a scheme without Koney's types", func() {
+		Expect(validateOptions(runtime.NewScheme(), DefaultOptions())).NotTo(Succeed())
+	})
+})
+
+var _ = Describe("buildTokenProviders", func() {
+	It("should let additional providers take precedence over the built-in ones", func() {
+		providers := buildTokenProviders(DefaultOptions())
+		Expect(providers).To(HaveKey(tokenprovider.CanarytokensProviderName))
+
+		custom := tokenprovider.NewCanarytokens("https://canarytokens.example.com", nil)
+		opts := DefaultOptions()
+		opts.TokenProviders = tokenprovider.Providers{tokenprovider.CanarytokensProviderName: custom}
+		Expect(buildTokenProviders(opts)[tokenprovider.CanarytokensProviderName]).To(BeIdenticalTo(custom))
+
+		opts = DefaultOptions()
+		opts.CanarytokensURL = ""
+		Expect(buildTokenProviders(opts)).To(BeEmpty())
+	})
+})
@@ -0,0 +1,156 @@
+// Copyright (c) 2025 Dynatrace LLC
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"os"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// ServiceName is the fully-qualified name of the TrapPlugin gRPC service.
+const ServiceName = "koney.plugin.v1.TrapPlugin"
+
+// jsonCodec encodes gRPC messages as JSON instead of protobuf.
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v any) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonCodec) Unmarshal(data []byte, v any) error {
+	return json.Unmarshal(data, v)
+}
+
+func (jsonCodec) Name() string {
+	return "json"
+}
+
+// unaryHandler adapts a call of the TrapPlugin interface to a gRPC method handler.
+func unaryHandler[Req, Resp any](method string, call func(TrapPlugin, context.Context, *Req) (*Resp, error)) grpc.MethodHandler {
+	return func(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+		req := new(Req)
+		if err := dec(req); err != nil {
+			return nil, err
+		}
+
+		handler := func(ctx context.Context, req any) (any, error) {
+			return call(srv.(TrapPlugin), ctx, req.(*Req))
+		}
+		if interceptor == nil {
+			return handler(ctx, req)
+		}
+
+		info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/" + ServiceName + "/" + method}
+		return interceptor(ctx, req, info, handler)
+	}
+}
+
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: ServiceName,
+	HandlerType: (*TrapPlugin)(nil),
+	Methods: []grpc.MethodDesc{
+		{MethodName: "Info", Handler: unaryHandler("Info", TrapPlugin.Info)},
+		{MethodName: "DeployDecoy", Handler: unaryHandler("DeployDecoy", TrapPlugin.DeployDecoy)},
+		{MethodName: "RemoveDecoys", Handler: unaryHandler("RemoveDecoys", TrapPlugin.RemoveDecoys)},
+		{MethodName: "DeployCaptor", Handler: unaryHandler("DeployCaptor", TrapPlugin.DeployCaptor)},
+		{MethodName: "RemoveCaptors", Handler: unaryHandler("RemoveCaptors", TrapPlugin.RemoveCaptors)},
+	},
+}
+
+// NewServer creates a gRPC server that serves the plugin.
+// Use Serve instead, unless you need to add your own server options or services.
+func NewServer(impl TrapPlugin, opts ...grpc.ServerOption) *grpc.Server {
+	server := grpc.NewServer(append(opts, grpc.ForceServerCodec(jsonCodec{}))...)
+	server.RegisterService(&serviceDesc, impl)
+	return server
+}
+
+// Serve serves the plugin on a Unix socket until the context is cancelled.
+// A stale socket from a previous run is removed before listening.
+func Serve(ctx context.Context, socketPath string, impl TrapPlugin) error {
+	if err := os.Remove(socketPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return err
+	}
+
+	server := NewServer(impl)
+	go func() {
+		<-ctx.Done()
+		server.GracefulStop()
+	}()
+
+	return server.Serve(listener)
+}
+
+// Client calls a plugin over its Unix socket. It implements TrapPlugin.
+type Client struct {
+	conn *grpc.ClientConn
+}
+
+var _ TrapPlugin = &Client{}
+
+// Dial creates a client for the plugin that serves on the socket.
+// The connection is established lazily, so Dial does not fail if the plugin is not running yet.
+func Dial(socketPath string) (*Client, error) {
+	conn, err := grpc.NewClient("unix://"+socketPath,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(grpc.ForceCodec(jsonCodec{})))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{conn: conn}, nil
+}
+
+// Close closes the connection to the plugin.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+func (c *Client) Info(ctx context.Context, req *InfoRequest) (*InfoResponse, error) {
+	resp := &InfoResponse{}
+	return resp, c.conn.Invoke(ctx, "/"+ServiceName+"/Info", req, resp)
+}
+
+func (c *Client) DeployDecoy(ctx context.Context, req *DeployDecoyRequest) (*DeployDecoyResponse, error) {
+	resp := &DeployDecoyResponse{}
+	return resp, c.conn.Invoke(ctx, "/"+ServiceName+"/DeployDecoy", req, resp)
+}
+
+func (c *Client) RemoveDecoys(ctx context.Context, req *RemoveRequest) (*RemoveResponse, error) {
+	resp := &RemoveResponse{}
+	return resp, c.conn.Invoke(ctx, "/"+ServiceName+"/RemoveDecoys", req, resp)
+}
+
+func (c *Client) DeployCaptor(ctx context.Context, req *DeployCaptorRequest) (*DeployCaptorResponse, error) {
+	resp := &DeployCaptorResponse{}
+	return resp, c.conn.Invoke(ctx, "/"+ServiceName+"/DeployCaptor", req, resp)
+}
+
+func (c *Client) RemoveCaptors(ctx context.Context, req *RemoveRequest) (*RemoveResponse, error) {
+	resp := &RemoveResponse{}
+	return resp, c.conn.Invoke(ctx, "/"+ServiceName+"/RemoveCaptors", req, resp)
+}
@@ -0,0 +1,189 @@
+// Copyright (c) 2025 Dynatrace LLC
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package plugin defines the protocol between Koney and out-of-tree trap plugins.
+//
+// A plugin is a separate binary that implements one or more proprietary trap types (e.g., decoy records in an
+// application database). It serves the TrapPlugin gRPC service on a Unix socket named "<name>.sock" in Koney's
+// plugin directory, usually from a sidecar container of the controller manager that shares the directory:
+//
+//	type recordsPlugin struct {
+//		plugin.UnimplementedTrapPlugin
+//	}
+//
+//	func (p *recordsPlugin) DeployDecoy(ctx context.Context, req *plugin.DeployDecoyRequest) (*plugin.DeployDecoyResponse, error) {
+//		...
+//	}
+//
+//	err := plugin.Serve(ctx, "/var/run/koney/plugins/acme-db-records.sock", &recordsPlugin{})
+//
+// Koney resolves the objects that a trap matches and passes them to the plugin as targets,
+// so plugins do not need to watch the cluster themselves.
+// Messages are encoded as JSON (content-subtype "json"), so plugins can also be written in other languages.
+package plugin
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/dynatrace-oss/koney/api/v1alpha1"
+)
+
+// TrapPlugin is implemented by plugins and called by Koney.
+// All calls must be idempotent, since Koney calls them again on every reconciliation.
+type TrapPlugin interface {
+	// Info describes the plugin and the trap types that it implements.
+	Info(ctx context.Context, req *InfoRequest) (*InfoResponse, error)
+	// DeployDecoy deploys the decoy of a trap to the targets (or updates it).
+	DeployDecoy(ctx context.Context, req *DeployDecoyRequest) (*DeployDecoyResponse, error)
+	// RemoveDecoys removes the decoys of a policy, except the decoys of the traps that should be kept.
+	RemoveDecoys(ctx context.Context, req *RemoveRequest) (*RemoveResponse, error)
+	// DeployCaptor deploys the captor of a trap, which reports access to the decoy to the webhook URL.
+	DeployCaptor(ctx context.Context, req *DeployCaptorRequest) (*DeployCaptorResponse, error)
+	// RemoveCaptors removes the captors of a policy, except the captors of the traps that should be kept.
+	RemoveCaptors(ctx context.Context, req *RemoveRequest) (*RemoveResponse, error)
+}
+
+// PolicyRef references the DeceptionPolicy that a trap belongs to.
+type PolicyRef struct {
+	// Name is the name of the DeceptionPolicy.
+	Name string `json:"name"`
+	// UID is the UID of the DeceptionPolicy.
+	UID string `json:"uid"`
+}
+
+// TrapSpec is the specification of a trap that is implemented by the plugin.
+type TrapSpec struct {
+	// ID identifies the trap within the policy. It changes whenever the specification of the trap changes.
+	ID string `json:"id"`
+	// Type is the trap type within the plugin, as specified in the DeceptionPolicy.
+	Type string `json:"type,omitempty"`
+	// Config is the configuration of the trap, as specified in the DeceptionPolicy.
+	Config string `json:"config,omitempty"`
+	// DecoyStrategy is the strategy of the decoy deployment, as specified in the DeceptionPolicy.
+	DecoyStrategy string `json:"decoyStrategy,omitempty"`
+	// CaptorStrategy is the strategy of the captor deployment, as specified in the DeceptionPolicy.
+	CaptorStrategy string `json:"captorStrategy,omitempty"`
+	// Match are the matching criteria of the trap, for plugins that want to match objects themselves.
+	Match v1alpha1.MatchResources `json:"match"`
+}
+
+// Target is an object that matched the trap and is ready for the decoy.
+// Pods are matched if the decoy strategy is containerExec, otherwise Deployments.
+type Target struct {
+	// Kind is the kind of the object, e.g., "Pod" or "Deployment".
+	Kind string `json:"kind"`
+	// Namespace is the namespace of the object.
+	Namespace string `json:"namespace"`
+	// Name is the name of the object.
+	Name string `json:"name"`
+	// UID is the UID of the object.
+	UID string `json:"uid,omitempty"`
+	// Containers are the containers of the object that matched the container selector.
+	Containers []string `json:"containers,omitempty"`
+}
+
+// Outcome reports what happened to a single target.
+type Outcome struct {
+	// Target is the target that the outcome is about.
+	Target Target `json:"target"`
+	// Containers are the containers that the decoy is deployed to.
+	Containers []string `json:"containers,omitempty"`
+	// SkippedReason is set if the decoy was not deployed to the target (yet).
+	SkippedReason string `json:"skippedReason,omitempty"`
+	// Error is set if the deployment to the target failed.
+	Error string `json:"error,omitempty"`
+}
+
+// InfoRequest is the request of TrapPlugin.Info.
+type InfoRequest struct{}
+
+// InfoResponse is the response of TrapPlugin.Info.
+type InfoResponse struct {
+	// Name is the name of the plugin. It must match the name of its socket.
+	Name string `json:"name"`
+	// Version is the version of the plugin.
+	Version string `json:"version,omitempty"`
+	// TrapTypes are the trap types that the plugin implements.
+	TrapTypes []string `json:"trapTypes,omitempty"`
+}
+
+// DeployDecoyRequest is the request of TrapPlugin.DeployDecoy.
+type DeployDecoyRequest struct {
+	Policy PolicyRef `json:"policy"`
+	Trap   TrapSpec  `json:"trap"`
+	// Namespaces are the namespaces that matched the trap.
+	Namespaces []string `json:"namespaces,omitempty"`
+	// Targets are the objects that matched the trap and are ready for the decoy.
+	Targets []Target `json:"targets,omitempty"`
+}
+
+// DeployDecoyResponse is the response of TrapPlugin.DeployDecoy.
+type DeployDecoyResponse struct {
+	// Outcomes report the outcome for every target.
+	// If Outcomes is empty, Koney assumes that the decoy was deployed to all targets.
+	Outcomes []Outcome `json:"outcomes,omitempty"`
+}
+
+// DeployCaptorRequest is the request of TrapPlugin.DeployCaptor.
+type DeployCaptorRequest struct {
+	Policy PolicyRef `json:"policy"`
+	Trap   TrapSpec  `json:"trap"`
+	// WebhookURL is the signed URL of Koney's alert forwarder where the captor can report access to the decoy.
+	WebhookURL string `json:"webhookURL"`
+}
+
+// DeployCaptorResponse is the response of TrapPlugin.DeployCaptor.
+type DeployCaptorResponse struct{}
+
+// RemoveRequest is the request of TrapPlugin.RemoveDecoys and TrapPlugin.RemoveCaptors.
+type RemoveRequest struct {
+	Policy PolicyRef `json:"policy"`
+	// KeepTrapIDs are the IDs of the traps that must not be removed. It is empty if the policy is deleted.
+	KeepTrapIDs []string `json:"keepTrapIDs,omitempty"`
+}
+
+// RemoveResponse is the response of TrapPlugin.RemoveDecoys and TrapPlugin.RemoveCaptors.
+type RemoveResponse struct{}
+
+// UnimplementedTrapPlugin can be embedded by plugins that do not implement all calls, e.g., plugins without captors.
+type UnimplementedTrapPlugin struct{}
+
+func (UnimplementedTrapPlugin) Info(context.Context, *InfoRequest) (*InfoResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "Info not implemented")
+}
+
+func (UnimplementedTrapPlugin) DeployDecoy(context.Context, *DeployDecoyRequest) (*DeployDecoyResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "DeployDecoy not implemented")
+}
+
+func (UnimplementedTrapPlugin) RemoveDecoys(context.Context, *RemoveRequest) (*RemoveResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "RemoveDecoys not implemented")
+}
+
+func (UnimplementedTrapPlugin) DeployCaptor(context.Context, *DeployCaptorRequest) (*DeployCaptorResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "DeployCaptor not implemented")
+}
+
+func (UnimplementedTrapPlugin) RemoveCaptors(context.Context, *RemoveRequest) (*RemoveResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "RemoveCaptors not implemented")
+}
+
+// IsUnimplemented returns true if the plugin does not implement the call that returned the error.
+func IsUnimplemented(err error) bool {
+	return status.Code(err) == codes.Unimplemented
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2025 Dynatrace LLC
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package plugin
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// recordsPlugin is a plugin that only implements decoys and records the requests it receives.
+type recordsPlugin struct {
+	UnimplementedTrapPlugin
+
+	decoyRequests []*DeployDecoyRequest
+}
+
+func (p *recordsPlugin) Info(context.Context, *InfoRequest) (*InfoResponse, error) {
+	return &InfoResponse{Name: "records", Version: "1.0.0", TrapTypes: []string{"customer-record"}}, nil
+}
+
+func (p *recordsPlugin) DeployDecoy(_ context.Context, req *DeployDecoyRequest) (*DeployDecoyResponse, error) {
+	p.decoyRequests = append(p.decoyRequests, req)
+	return &DeployDecoyResponse{Outcomes: []Outcome{{Target: req.Targets[0], Error: "table is read-only"}}}, nil
+}
+
+var _ = Describe("TrapPlugin over gRPC", func() {
+	var (
+		impl   *recordsPlugin
+		client *Client
+	)
+
+	BeforeEach(func() {
+		// Unix socket paths are limited in length, so we avoid the long temporary directories of Ginkgo
+		dir, err := os.MkdirTemp("", "koney-plugin")
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(os.RemoveAll, dir)
+
+		socketPath := filepath.Join(dir, "records.sock")
+		ctx, cancel := context.WithCancel(context.Background())
+		DeferCleanup(cancel)
+
+		impl = &recordsPlugin{}
+		go func() {
+			defer GinkgoRecover()
+			Expect(Serve(ctx, socketPath, impl)).To(Succeed())
+		}()
+		Eventually(func() error { _, err := os.Stat(socketPath); return err }).Should(Succeed())
+
+		client, err = Dial(socketPath)
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(client.Close)
+	})
+
+	It("should describe the plugin", func() {
+		info, err := client.Info(context.Background(), &InfoRequest{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info.Name).To(Equal("records"))
+		Expect(info.TrapTypes).To(ConsistOf("customer-record"))
+	})
+
+	It("should pass the targets and return the outcomes", func() {
+		target := Target{Kind: "Deployment", Namespace: "shop", Name: "backend", Containers: []string{"app"}}
+		resp, err := client.DeployDecoy(context.Background(), &DeployDecoyRequest{
+			Policy:  PolicyRef{Name: "deceptionpolicy-records"},
+			Trap:    TrapSpec{ID: "abc", Type: "customer-record", Config: `{"table":"customers"}`},
+			Targets: []Target{target},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.Outcomes).To(HaveLen(1))
+		Expect(resp.Outcomes[0].Target).To(Equal(target))
+		Expect(resp.Outcomes[0].Error).To(Equal("table is read-only"))
+
+		Expect(impl.decoyRequests).To(HaveLen(1))
+		Expect(impl.decoyRequests[0].Trap.Config).To(Equal(`{"table":"customers"}`))
+	})
+
+	It("should report calls that the plugin does not implement", func() {
+		_, err := client.DeployCaptor(context.Background(), &DeployCaptorRequest{Policy: PolicyRef{Name: "deceptionpolicy-records"}})
+		Expect(err).To(HaveOccurred())
+		Expect(IsUnimplemented(err)).To(BeTrue())
+	})
+})
@@ -0,0 +1,143 @@
+// Copyright (c) 2025 Dynatrace LLC
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package tokenprovider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// CanarytokensProviderName is the name of the Canarytokens provider, which DeceptionPolicies use by default.
+const CanarytokensProviderName = "canarytokens"
+
+// DefaultCanarytokensURL is the URL of the free, public Canarytokens service of Thinkst.
+const DefaultCanarytokensURL = "https://canarytokens.org"
+
+// canarytokensTimeout limits how long minting a token may take, if no HTTP client is given.
+const canarytokensTimeout = 30 * time.Second
+
+// canarytokensKinds maps the kinds of tokens to the token types of the Canarytokens API.
+var canarytokensKinds = map[string]string{
+	"web":      "web",
+	"dns":      "dns",
+	"aws-keys": "aws_keys",
+}
+
+var _ TokenProvider = &Canarytokens{}
+
+// Canarytokens mints tokens with the API of Canarytokens (https://github.com/thinkst/canarytokens),
+// either at canarytokens.org or at a self-hosted instance.
+type Canarytokens struct {
+	// URL is the base URL of the Canarytokens instance, e.g., "https://canarytokens.org".
+	URL string
+	// Client is the HTTP client that requests are sent with.
+	Client *http.Client
+}
+
+// NewCanarytokens creates a provider for the Canarytokens instance at the URL.
+// If client is nil, a client with a default timeout is used.
+func NewCanarytokens(url string, client *http.Client) *Canarytokens {
+	if client == nil {
+		client = &http.Client{Timeout: canarytokensTimeout}
+	}
+	return &Canarytokens{URL: strings.TrimSuffix(url, "/"), Client: client}
+}
+
+// canarytokensResponse is the response of the /generate endpoint.
+type canarytokensResponse struct {
+	Error              string `json:"error"`
+	Token              string `json:"token"`
+	TokenURL           string `json:"token_url"`
+	Hostname           string `json:"hostname"`
+	AWSAccessKeyID     string `json:"aws_access_key_id"`
+	AWSSecretAccessKey string `json:"aws_secret_access_key"`
+	Region             string `json:"region"`
+}
+
+// MintToken creates a new token with the /generate endpoint of Canarytokens.
+// The content is the URL of web tokens, the hostname of DNS tokens, and a credentials file of AWS keys tokens.
+func (p *Canarytokens) MintToken(ctx context.Context, req TokenRequest) (*Token, error) {
+	tokenType, ok := canarytokensKinds[req.Kind]
+	if !ok {
+		return nil, fmt.Errorf("canarytokens does not support tokens of kind '%s'", req.Kind)
+	}
+	if req.Email == "" && req.WebhookURL == "" {
+		return nil, errors.New("canarytokens requires an email address or a webhook URL for alerts")
+	}
+
+	form := url.Values{}
+	form.Set("token_type", tokenType)
+	form.Set("memo", req.Memo)
+	if req.Email != "" {
+		form.Set("email", req.Email)
+	}
+	if req.WebhookURL != "" {
+		form.Set("webhook_url", req.WebhookURL)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, p.URL+"/generate", strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := p.Client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("cannot reach canarytokens: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("canarytokens responded with status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var response canarytokensResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("cannot parse the response of canarytokens: %w", err)
+	}
+	if response.Error != "" {
+		return nil, fmt.Errorf("canarytokens rejected the token: %s", response.Error)
+	}
+
+	var content string
+	switch req.Kind {
+	case "web":
+		content = response.TokenURL
+	case "dns":
+		content = response.Hostname
+	case "aws-keys":
+		if response.AWSAccessKeyID != "" {
+			content = fmt.Sprintf("[default]\naws_access_key_id = %s\naws_secret_access_key = %s\nregion = %s\n",
+				response.AWSAccessKeyID, response.AWSSecretAccessKey, response.Region)
+		}
+	}
+	if content == "" {
+		return nil, fmt.Errorf("canarytokens returned no content for a token of kind '%s'", req.Kind)
+	}
+
+	return &Token{ID: response.Token, Content: content}, nil
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 2025 Dynatrace LLC
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package tokenprovider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Canarytokens", func() {
+	ctx := context.Background()
+
+	var (
+		server   *httptest.Server
+		form     url.Values
+		response map[string]string
+		provider *Canarytokens
+	)
+
+	BeforeEach(func() {
+		form = nil
+		response = map[string]string{
+			"token":                 "abc123",
+			"token_url":             "https://canarytokens.example.com/abc123/index.html",
+			"hostname":              "abc123.canarytokens.example.com",
+			"aws_access_key_id":     "AKIAEXAMPLE",
+			"aws_secret_access_key": "secret",
+			"region":                "us-east-2",
+		}
+
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer GinkgoRecover()
+			Expect(r.Method).To(Equal(http.MethodPost))
+			Expect(r.URL.Path).To(Equal("/generate"))
+			Expect(r.ParseForm()).To(Succeed())
+			form = r.PostForm
+			Expect(json.NewEncoder(w).Encode(response)).To(Succeed())
+		}))
+		DeferCleanup(server.Close)
+
+		provider = NewCanarytokens(server.URL+"/", nil)
+	})
+
+	It("should mint web tokens", func() {
+		token, err := provider.MintToken(ctx, TokenRequest{Kind: "web", Memo: "koney", WebhookURL: "https://alerts.example.com"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(token.ID).To(Equal("abc123"))
+		Expect(token.Content).To(Equal("https://canarytokens.example.com/abc123/index.html"))
+
+		Expect(form.Get("token_type")).To(Equal("web"))
+		Expect(form.Get("memo")).To(Equal("koney"))
+		Expect(form.Get("webhook_url")).To(Equal("https://alerts.example.com"))
+		Expect(form.Has("email")).To(BeFalse())
+	})
+
+	It("should mint DNS tokens", func() {
+		token, err := provider.MintToken(ctx, TokenRequest{Kind: "dns", Email: "security@example.com"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(token.Content).To(Equal("abc123.canarytokens.example.com"))
+		Expect(form.Get("email")).To(Equal("security@example.com"))
+	})
+
+	It("should mint AWS keys as a credentials file", func() {
+		token, err := provider.MintToken(ctx, TokenRequest{Kind: "aws-keys", Email: "security@example.com"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(token.Content).To(Equal("[default]\naws_access_key_id = AKIAEXAMPLE\naws_secret_access_key = secret\nregion = us-east-2\n"))
+		Expect(form.Get("token_type")).To(Equal("aws_keys"))
+	})
+
+	It("should reject unsupported kinds and missing alert targets without calling the API", func() {
+		_, err := provider.MintToken(ctx, TokenRequest{Kind: "pdf", Email: "security@example.com"})
+		Expect(err).To(MatchError(ContainSubstring("does not support tokens of kind 'pdf'")))
+
+		_, err = provider.MintToken(ctx, TokenRequest{Kind: "web"})
+		Expect(err).To(MatchError(ContainSubstring("email address or a webhook URL")))
+		Expect(form).To(BeNil())
+	})
+
+	It("should report errors of the API", func() {
+		response = map[string]string{"error": "invalid email"}
+		_, err := provider.MintToken(ctx, TokenRequest{Kind: "web", Email: "invalid"})
+		Expect(err).To(MatchError(ContainSubstring("invalid email")))
+	})
+})
+
+var _ = Describe("Providers", func() {
+	It("should only return configured providers", func() {
+		canarytokens := NewCanarytokens(DefaultCanarytokensURL, nil)
+		providers := Providers{CanarytokensProviderName: canarytokens}
+
+		provider, err := providers.Get(CanarytokensProviderName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(provider).To(BeIdenticalTo(canarytokens))
+
+		_, err = providers.Get("thinkst-canary")
+		Expect(err).To(MatchError(ErrProviderNotFound))
+	})
+})
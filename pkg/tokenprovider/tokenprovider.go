@@ -0,0 +1,80 @@
+// Copyright (c) 2025 Dynatrace LLC
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package tokenprovider defines how Koney mints honeytokens through external canary token providers.
+//
+// Tokens that are minted by a provider are also detected out of the cluster: if an attacker exfiltrates
+// the honeytoken and uses it from their own machine, the provider notices and raises the alert.
+// Koney ships an implementation for Canarytokens (https://canarytokens.org), and operators that embed
+// Koney can plug in their own providers with the TokenProviders option:
+//
+//	type vaultCanaries struct{ ... }
+//
+//	func (p *vaultCanaries) MintToken(ctx context.Context, req tokenprovider.TokenRequest) (*tokenprovider.Token, error) {
+//		...
+//	}
+//
+//	opts := operator.DefaultOptions()
+//	opts.TokenProviders = tokenprovider.Providers{"vault-canaries": &vaultCanaries{}}
+//
+// DeceptionPolicies reference providers by their name in fileContentFrom.canaryToken.provider.
+package tokenprovider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrProviderNotFound is returned if no token provider with the given name is configured.
+var ErrProviderNotFound = errors.New("token provider not found")
+
+// TokenProvider mints canary tokens, which raise alerts whenever they are used.
+type TokenProvider interface {
+	// MintToken creates a new canary token. Each call must return a new token,
+	// since Koney calls it again only when the token is rotated.
+	MintToken(ctx context.Context, req TokenRequest) (*Token, error)
+}
+
+// TokenRequest describes the canary token to mint.
+type TokenRequest struct {
+	// Kind is the kind of token, e.g., "web", "dns", or "aws-keys". Providers may not support all kinds.
+	Kind string
+	// Memo is a reminder where the token is deployed, which the provider includes in its alerts.
+	Memo string
+	// Email is the address that the provider sends alerts to (optional, if WebhookURL is set).
+	Email string
+	// WebhookURL is the URL that the provider sends alerts to (optional, if Email is set).
+	WebhookURL string
+}
+
+// Token is a canary token that was minted by a provider.
+type Token struct {
+	// ID identifies the token at the provider.
+	ID string
+	// Content is the honeytoken, as it is written into the decoy (e.g., a URL or a credentials file).
+	Content string
+}
+
+// Providers maps the names of token providers, as referenced by DeceptionPolicies, to their implementations.
+type Providers map[string]TokenProvider
+
+// Get returns the token provider with the given name, or ErrProviderNotFound if it is not configured.
+func (p Providers) Get(name string) (TokenProvider, error) {
+	if provider, ok := p[name]; ok && provider != nil {
+		return provider, nil
+	}
+	return nil, fmt.Errorf("%w: '%s'", ErrProviderNotFound, name)
+}
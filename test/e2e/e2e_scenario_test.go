@@ -0,0 +1,263 @@
+// Copyright (c) 2025 Dynatrace LLC
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package e2e
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+
+	"github.com/dynatrace-oss/koney/api/v1alpha1"
+	"github.com/dynatrace-oss/koney/internal/controller/constants"
+	testutils "github.com/dynatrace-oss/koney/test/utils"
+)
+
+// scenario is an isolated test fixture. Every scenario gets its own namespace and its own
+// DeceptionPolicy that only matches resources in that namespace, so that scenarios do not
+// share any state and can safely run in parallel.
+type scenario struct {
+	// Namespace is the namespace in which all test resources of the scenario are created
+	Namespace string
+
+	// PolicyName is the name of the (cluster-scoped) DeceptionPolicy of the scenario
+	PolicyName string
+
+	// TestPodName is the actual name of the test pod, which was created by the test deployment
+	TestPodName string
+
+	// LastModificationTime is the time when the DeceptionPolicy was last created, updated or deleted by us
+	LastModificationTime time.Time
+
+	// ObservedFilePaths is a list of all honeytoken paths placed during the scenario
+	ObservedFilePaths []string
+}
+
+// newScenario creates the namespace of a new scenario and registers its cleanup.
+// It must be called from a setup node (e.g., BeforeEach) or from within a spec.
+func newScenario(name string) *scenario {
+	s := &scenario{
+		Namespace:  fmt.Sprintf("koney-e2e-%s-%d", name, GinkgoParallelProcess()),
+		PolicyName: fmt.Sprintf("koney-e2e-%s-%d", name, GinkgoParallelProcess()),
+	}
+
+	By("creating scenario namespace " + s.Namespace)
+	cmd := exec.Command("kubectl", "create", "ns", s.Namespace)
+	_, err := testutils.Run(cmd)
+	Expect(err).NotTo(HaveOccurred())
+
+	DeferCleanup(s.teardown)
+
+	return s
+}
+
+// teardown removes the DeceptionPolicy of the scenario first (so that Koney can clean up its traps),
+// and then removes the scenario namespace with all remaining test resources.
+func (s *scenario) teardown() {
+	By("removing the scenario DeceptionPolicy " + s.PolicyName)
+	cmd := exec.Command("kubectl", "delete", testCrdName, s.PolicyName, "--ignore-not-found")
+	_, _ = testutils.Run(cmd)
+
+	By("removing scenario namespace " + s.Namespace)
+	cmd = exec.Command("kubectl", "delete", "ns", s.Namespace, "--ignore-not-found")
+	_, _ = testutils.Run(cmd)
+}
+
+// apply applies a manifest from the manifests directory in the context of the scenario.
+// Namespaced resources are created in the scenario namespace. DeceptionPolicies are renamed
+// to the scenario policy name and their traps are scoped to the scenario namespace.
+func (s *scenario) apply(manifest string) {
+	content, err := os.ReadFile(filepath.Join(projectDir, manifest))
+	Expect(err).NotTo(HaveOccurred())
+
+	var obj unstructured.Unstructured
+	Expect(yaml.Unmarshal(content, &obj.Object)).To(Succeed())
+
+	if obj.GetKind() == "DeceptionPolicy" {
+		obj.SetName(s.PolicyName)
+		Expect(scopeTrapsToNamespace(obj.Object, s.Namespace)).To(Succeed())
+	} else {
+		obj.SetNamespace(s.Namespace)
+	}
+
+	scopedContent, err := json.Marshal(obj.Object)
+	Expect(err).NotTo(HaveOccurred())
+
+	cmd := exec.Command("kubectl", "apply", "-f", "-")
+	cmd.Stdin = strings.NewReader(string(scopedContent))
+	_, err = testutils.Run(cmd)
+	Expect(err).NotTo(HaveOccurred())
+}
+
+// deployTestWorkload creates the test deployment in the scenario namespace and waits for its pod
+func (s *scenario) deployTestWorkload() {
+	By("creating a test pod")
+	s.apply(yamlOfTestDeployment)
+
+	By("validating that the test pod is running as expected")
+	s.awaitTestPod()
+}
+
+// awaitTestPod waits for the test deployment to be ready and remembers the name of its pod
+func (s *scenario) awaitTestPod() {
+	Expect(waitDeploymentReady(s.Namespace, nameOfTestDeployment)).To(Succeed())
+	Eventually(func() error {
+		return verifyTestPodRunningByLabel(s.Namespace, labelOfTestDeployment, &s.TestPodName)
+	}, time.Minute, time.Second).Should(Succeed())
+}
+
+// applyPolicy creates or updates the scenario DeceptionPolicy and returns it as stored in the cluster.
+// Every time that a DeceptionPolicy is applied, we need to wait for the test pod to be ready again,
+// because the test deployment may be updated.
+func (s *scenario) applyPolicy(manifest string) v1alpha1.DeceptionPolicy {
+	By("applying the Koney DeceptionPolicy CR")
+	s.LastModificationTime = time.Now()
+	s.apply(manifest)
+
+	By("validating that the test pod is running as expected")
+	s.awaitTestPod()
+
+	return s.getPolicy()
+}
+
+// getPolicy returns the scenario DeceptionPolicy as stored in the cluster
+func (s *scenario) getPolicy() v1alpha1.DeceptionPolicy {
+	var deceptionPolicy v1alpha1.DeceptionPolicy
+	cmd := exec.Command("kubectl", "get", testCrdName, s.PolicyName, "-o", "json")
+	deceptionPolicyJSON, err := testutils.Run(cmd)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(json.Unmarshal(deceptionPolicyJSON, &deceptionPolicy)).To(Succeed())
+	return deceptionPolicy
+}
+
+// deletePolicy deletes the scenario DeceptionPolicy and waits until it is gone
+func (s *scenario) deletePolicy() {
+	By("deleting the DeceptionPolicy CR")
+	s.LastModificationTime = time.Now()
+	cmd := exec.Command("kubectl", "delete", testCrdName, s.PolicyName)
+	_, err := testutils.Run(cmd)
+	Expect(err).NotTo(HaveOccurred())
+
+	By("validating that the DeceptionPolicy CR is deleted")
+	Eventually(func() error {
+		cmd := exec.Command("kubectl", "get", testCrdName, s.PolicyName, "-o", "json")
+		if _, err := testutils.Run(cmd); err == nil { // We expect an error here, as the CR should not exist anymore
+			return fmt.Errorf("DeceptionPolicy CR not deleted yet")
+		}
+		return nil
+	}, time.Minute, time.Second).Should(Succeed())
+}
+
+// expectTrapsPlaced verifies that the traps of the DeceptionPolicy are recorded in the changes annotation
+// of the given resource, that the honeytokens exist in the given pod and trigger alerts when accessed,
+// and that the status conditions of the DeceptionPolicy are accurate.
+func (s *scenario) expectTrapsPlaced(
+	deceptionPolicy v1alpha1.DeceptionPolicy, resourceKind, resourceName, podName string,
+) {
+	By("validating that the annotation " + constants.AnnotationKeyChanges + " is present in the " + resourceKind)
+	Eventually(func() error {
+		if resourceKind == "deployment" {
+			return verifyAnnotationPresentInDeployment(s.Namespace, resourceName)
+		}
+		return verifyAnnotationPresentInPod(s.Namespace, resourceName)
+	}, time.Minute, time.Second).Should(Succeed())
+
+	By("validating that the annotation " + constants.AnnotationKeyChanges + " is accurate in the " + resourceKind)
+	Eventually(func() error {
+		return verifyAnnotationIsAccurate(s.Namespace, resourceKind, resourceName,
+			s.PolicyName, deceptionPolicy.Spec.Traps)
+	}, time.Minute, time.Second).Should(Succeed())
+
+	updateObservedFilePaths(deceptionPolicy.Spec.Traps, &s.ObservedFilePaths)
+
+	By("validating that the honeytokens are placed in the test pod and have the expected content")
+	for _, trap := range deceptionPolicy.Spec.Traps {
+		err := verifyHoneytokenAndAwaitAlert(trap, s.LastModificationTime,
+			s.Namespace, podName, containersPolicyShouldMatch)
+		Expect(err).ShouldNot(HaveOccurred())
+	}
+
+	By("validating that the status conditions of the DeceptionPolicy are accurate")
+	Eventually(func() error {
+		return verifyStatusConditions(testCrdName, s.PolicyName, true, true)
+	}, time.Minute, time.Second).Should(Succeed())
+}
+
+// expectNoTrapsPlaced verifies that neither the test pod nor the test deployment carry the changes
+// annotation, and that none of the honeytokens observed during the scenario exist in the test pod.
+func (s *scenario) expectNoTrapsPlaced() {
+	By("validating that the annotation " + constants.AnnotationKeyChanges + " is not present in the test pod")
+	Eventually(func() error {
+		return verifyAnnotationPresentInPod(s.Namespace, s.TestPodName)
+	}).ShouldNot(Succeed())
+
+	By("validating that the annotation " + constants.AnnotationKeyChanges + " is not present in the test deployment")
+	Eventually(func() error {
+		return verifyAnnotationPresentInDeployment(s.Namespace, nameOfTestDeployment)
+	}).ShouldNot(Succeed())
+
+	By("validating that no honeytokens exist in the test pod")
+	for _, filePath := range s.ObservedFilePaths {
+		Eventually(func() error {
+			return verifyHoneytokenRemoved(filePath, s.Namespace, s.TestPodName, containersPolicyShouldMatch)
+		}, time.Minute, time.Second).Should(Succeed())
+	}
+}
+
+// scopeTrapsToNamespace restricts all resource filters of all traps of an unstructured
+// DeceptionPolicy to the given namespace
+func scopeTrapsToNamespace(deceptionPolicy map[string]any, namespace string) error {
+	traps, _, err := unstructured.NestedSlice(deceptionPolicy, "spec", "traps")
+	if err != nil {
+		return err
+	}
+
+	for _, trap := range traps {
+		trapMap, ok := trap.(map[string]any)
+		if !ok {
+			return fmt.Errorf("unexpected trap type %T", trap)
+		}
+
+		filters, _, err := unstructured.NestedSlice(trapMap, "match", "any")
+		if err != nil {
+			return err
+		}
+
+		for _, filter := range filters {
+			filterMap, ok := filter.(map[string]any)
+			if !ok {
+				return fmt.Errorf("unexpected resource filter type %T", filter)
+			}
+			if err := unstructured.SetNestedStringSlice(filterMap, []string{namespace}, "resources", "namespaces"); err != nil {
+				return err
+			}
+		}
+
+		if err := unstructured.SetNestedSlice(trapMap, filters, "match", "any"); err != nil {
+			return err
+		}
+	}
+
+	return unstructured.SetNestedSlice(deceptionPolicy, traps, "spec", "traps")
+}
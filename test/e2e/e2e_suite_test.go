@@ -16,12 +16,17 @@
 package e2e
 
 import (
+	"fmt"
+	"os/exec"
 	"testing"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	testutils "github.com/dynatrace-oss/koney/test/utils"
 )
 
 func TestKoneyEndToEnd(t *testing.T) {
@@ -29,6 +34,47 @@ func TestKoneyEndToEnd(t *testing.T) {
 	RunSpecs(t, "End-To-End Suite")
 }
 
-var _ = BeforeSuite(func() {
+// The controller-manager is shared by all scenarios, so it is deployed once on the first
+// Ginkgo process, before any of the (potentially parallel) scenarios start running.
+var _ = SynchronizedBeforeSuite(func() {
 	log.SetLogger(zap.New(zap.WriteTo(GinkgoWriter), zap.UseDevMode(true)))
+
+	By("creating manager namespace")
+	cmd := exec.Command("kubectl", "create", "ns", managerNamespace)
+	_, _ = testutils.Run(cmd)
+
+	// Add timestamp to the image name to avoid conflicts
+	var imageTag = imageTagPrefix + fmt.Sprintf("%d", time.Now().Unix())
+
+	By("building the manager(Operator) image")
+	cmd = exec.Command("make", "docker-build", fmt.Sprintf("VERSION=%s", imageTag))
+	_, err := testutils.Run(cmd)
+	Expect(err).NotTo(HaveOccurred())
+
+	By("loading the the manager(Operator) image on aws")
+	cmd = exec.Command("make", "docker-push", fmt.Sprintf("VERSION=%s", imageTag))
+	_, err = testutils.Run(cmd)
+	Expect(err).NotTo(HaveOccurred())
+
+	By("deploying the controller-manager")
+	cmd = exec.Command("make", "deploy", fmt.Sprintf("VERSION=%s", imageTag))
+	_, err = testutils.Run(cmd)
+	Expect(err).NotTo(HaveOccurred())
+
+	By("validating that the controller-manager pod is running as expected")
+	Eventually(verifyControllerUp, time.Minute, time.Second).Should(Succeed())
+}, func() {
+	log.SetLogger(zap.New(zap.WriteTo(GinkgoWriter), zap.UseDevMode(true)))
+})
+
+// The controller-manager is only removed once all Ginkgo processes have finished their scenarios.
+var _ = SynchronizedAfterSuite(func() {}, func() {
+	By("deleting the controller-manager")
+	cmd := exec.Command("make", "undeploy")
+	_, err := testutils.Run(cmd)
+	Expect(err).NotTo(HaveOccurred())
+
+	By("removing manager namespace")
+	cmd = exec.Command("kubectl", "delete", "ns", managerNamespace, "--ignore-not-found")
+	_, _ = testutils.Run(cmd)
 })
@@ -73,6 +73,38 @@ func updateObservedFilePaths(traps []v1alpha1.Trap, filePaths *[]string) {
 	}
 }
 
+// verifyControllerUp checks if exactly one controller-manager pod is running and ready
+func verifyControllerUp() error {
+	// Get pod name
+	podNames, err := testutils.GetPodNames(managerNamespace, "control-plane=controller-manager")
+	ExpectWithOffset(testutils.Offset, err).NotTo(HaveOccurred())
+	if len(podNames) != 1 {
+		return fmt.Errorf("expect 1 controller pods running, but got %d", len(podNames))
+	}
+	controllerPodName := podNames[0]
+	ExpectWithOffset(testutils.Offset, controllerPodName).Should(ContainSubstring("controller-manager"))
+
+	// Validate pod status
+	cmd := exec.Command("kubectl", "get",
+		"pods", controllerPodName, "-o", "jsonpath={.status.phase}",
+		"-n", managerNamespace,
+	)
+	status, err := testutils.Run(cmd)
+	ExpectWithOffset(testutils.Offset, err).NotTo(HaveOccurred())
+	if string(status) != "Running" {
+		return fmt.Errorf("controller pod in %s status", status)
+	}
+
+	// Wait for readiness
+	cmd = exec.Command("kubectl", "wait",
+		"--for=condition=Ready", "pod", "-l", "control-plane=controller-manager",
+		"-n", managerNamespace)
+	_, err = testutils.Run(cmd)
+	ExpectWithOffset(testutils.Offset, err).NotTo(HaveOccurred())
+
+	return nil
+}
+
 // verifyTestPodRunningByLabel checks if the test pod is running
 //
 //nolint:unparam
@@ -159,7 +191,7 @@ func verifyAnnotationPresentInDeployment(namespace, name string) error {
 //nolint:unparam
 func verifyAnnotationIsAccurate(
 	namespace, resourceKind, resourceName, deceptionPolicyName string,
-	traps []v1alpha1.Trap, allPaths *[]string,
+	traps []v1alpha1.Trap,
 ) error {
 	cmd := exec.Command("kubectl", "get", "-n", namespace, resourceKind, resourceName,
 		"-o", "jsonpath={.metadata.annotations."+constants.AnnotationKeyChanges+"}")
@@ -201,25 +233,16 @@ func verifyAnnotationIsAccurate(
 		}
 	}
 
-	// Add the file path to the list of file paths if not already present
-	for _, trap := range traps {
-		if trap.TrapType() == v1alpha1.FilesystemHoneytokenTrap {
-			if !utils.Contains(*allPaths, trap.FilesystemHoneytoken.FilePath) {
-				*allPaths = append(*allPaths, trap.FilesystemHoneytoken.FilePath)
-			}
-		}
-	}
-
 	return nil
 }
 
 // verifyStatusConditions checks if the status conditions of the DeceptionPolicy are as expected
 //
 //nolint:unparam
-func verifyStatusConditions(namespace, crdName, deceptionPolicyName string, expectDecoys, expectCaptors bool) error {
+func verifyStatusConditions(crdName, deceptionPolicyName string, expectDecoys, expectCaptors bool) error {
 	// Get the DeceptionPolicy CR to get the expected value of the annotation
 	var deceptionPolicy v1alpha1.DeceptionPolicy
-	cmd := exec.Command("kubectl", "get", crdName, deceptionPolicyName, "-o", "json", "-n", namespace)
+	cmd := exec.Command("kubectl", "get", crdName, deceptionPolicyName, "-o", "json")
 	deceptionPolicyJSON, err := testutils.Run(cmd)
 	Expect(err).NotTo(HaveOccurred())
 	err = json.Unmarshal(deceptionPolicyJSON, &deceptionPolicy)
@@ -318,15 +341,19 @@ func verifyHoneytokenAndAwaitAlert(
 		var attempt int
 
 		for attempt < maxAttempts {
-			alerts, err = findKoneyAlerts(trap.FilesystemHoneytoken.FilePath, "koney-system", &firstAccessTime)
+			alerts, err = findKoneyAlerts(trap.FilesystemHoneytoken.FilePath, managerNamespace, &firstAccessTime)
 			if err != nil {
 				return err
 			}
 
 			// Remove alerts that happened before the first access time
-			// (we don't want delayed alerts from previous tests)
+			// (we don't want delayed alerts from previous tests), and alerts
+			// from other namespaces (scenarios running in parallel may use the same paths)
 			filteredAlerts := []KoneyAlert{}
 			for i := 0; i < len(alerts); i++ {
+				if alerts[i].Pod.Namespace != podNamespace {
+					continue
+				}
 				timestamp, err := time.Parse(time.RFC3339, alerts[i].Timestamp)
 				if err != nil {
 					return fmt.Errorf("failed to parse alert timestamp: %v", err)
@@ -444,6 +471,25 @@ func verifyHoneytokenContent(trap v1alpha1.Trap, podNamespace, podName string, c
 
 // findKoneyAlerts returns log entries parsed as Koney alerts, if they contain the specified string
 func findKoneyAlerts(needle, managerNamespace string, sinceTime *time.Time) ([]KoneyAlert, error) {
+	lines, err := findAlertLines(needle, managerNamespace, sinceTime)
+	if err != nil {
+		return nil, err
+	}
+
+	alerts := []KoneyAlert{}
+	for _, line := range lines {
+		var alert KoneyAlert
+		if err := json.Unmarshal(line, &alert); err != nil {
+			return nil, err
+		}
+		alerts = append(alerts, alert)
+	}
+
+	return alerts, nil
+}
+
+// findAlertLines returns the log lines of the alert forwarder that contain the specified string
+func findAlertLines(needle, managerNamespace string, sinceTime *time.Time) ([][]byte, error) {
 	args := []string{"logs", "-n", managerNamespace, "--tail", "1000",
 		"-l", "control-plane=controller-manager", "-c", "alerts"}
 	if sinceTime != nil {
@@ -455,21 +501,102 @@ func findKoneyAlerts(needle, managerNamespace string, sinceTime *time.Time) ([]K
 		return nil, err
 	}
 
-	alerts := []KoneyAlert{}
+	matches := [][]byte{}
 	needleBytes := []byte(needle)
-	lines := bytes.Split(output, []byte("\n"))
-
-	for _, line := range lines {
+	for _, line := range bytes.Split(output, []byte("\n")) {
 		if bytes.Contains(line, needleBytes) {
-			var alert KoneyAlert
+			matches = append(matches, line)
+		}
+	}
+
+	return matches, nil
+}
+
+// isDualStackCluster checks if the pods of the cluster get addresses of both IP families
+func isDualStackCluster() bool {
+	cmd := exec.Command("kubectl", "get", "nodes", "-o", "jsonpath={.items[0].spec.podCIDRs[*]}")
+	output, err := testutils.Run(cmd)
+	if err != nil {
+		return false
+	}
+
+	podCIDRs := strings.Fields(string(output))
+	return len(podCIDRs) == 2 && strings.Contains(podCIDRs[0], ":") != strings.Contains(podCIDRs[1], ":")
+}
+
+// getServiceClusterIPs returns the cluster IPs of a service (one for each IP family)
+func getServiceClusterIPs(namespace, name string) ([]string, error) {
+	cmd := exec.Command("kubectl", "get", "service", name, "-n", namespace, "-o", "jsonpath={.spec.clusterIPs[*]}")
+	output, err := testutils.Run(cmd)
+	if err != nil {
+		return nil, err
+	}
+	return strings.Fields(string(output)), nil
+}
+
+// getPodIP returns the IP address of the pod that has the same IP family as the given address
+func getPodIP(namespace, name, sameFamilyAs string) (string, error) {
+	cmd := exec.Command("kubectl", "get", "pod", name, "-n", namespace, "-o", "jsonpath={.status.podIPs[*].ip}")
+	output, err := testutils.Run(cmd)
+	if err != nil {
+		return "", err
+	}
+
+	isIPv6 := strings.Contains(sameFamilyAs, ":")
+	for _, podIP := range strings.Fields(string(output)) {
+		if strings.Contains(podIP, ":") == isIPv6 {
+			return podIP, nil
+		}
+	}
+	return "", fmt.Errorf("pod %s/%s has no address of the same IP family as %s", namespace, name, sameFamilyAs)
+}
+
+// verifyHoneypotConnectionAlerted connects from the test pod to a network honeypot and awaits the alert,
+// which must name the address (of the same IP family) and the name of the test pod as the client
+func verifyHoneypotConnectionAlerted(namespace, podName, deceptionPolicyName, address, port string) error {
+	clientIP, err := getPodIP(namespace, podName, address)
+	if err != nil {
+		return err
+	}
+
+	startTime := time.Now()
+	cmd := exec.Command("kubectl", "exec", "-n", namespace, podName, "-c", "alpine", "--",
+		"sh", "-c", fmt.Sprintf("echo | nc -w 2 %s %s", address, port))
+	if _, err := testutils.Run(cmd); err != nil {
+		return err
+	}
+
+	type honeypotAlert struct {
+		TrapType string `json:"trap_type"`
+		Metadata struct {
+			ClientIP  string `json:"client_ip"`
+			ClientPod struct {
+				Name      string `json:"name"`
+				Namespace string `json:"namespace"`
+			} `json:"client_pod"`
+		} `json:"metadata"`
+	}
+
+	Eventually(func() error {
+		lines, err := findAlertLines(deceptionPolicyName, managerNamespace, &startTime)
+		if err != nil {
+			return err
+		}
+
+		for _, line := range lines {
+			var alert honeypotAlert
 			if err := json.Unmarshal(line, &alert); err != nil {
-				return nil, err
+				return err
+			}
+			if alert.TrapType == "network_honeypot" && alert.Metadata.ClientIP == clientIP &&
+				alert.Metadata.ClientPod.Name == podName && alert.Metadata.ClientPod.Namespace == namespace {
+				return nil
 			}
-			alerts = append(alerts, alert)
 		}
-	}
+		return fmt.Errorf("no network honeypot alert for client %s (%s) found", podName, clientIP)
+	}, time.Minute, time.Second).Should(Succeed())
 
-	return alerts, nil
+	return nil
 }
 
 // verifyHoneytokenRemoved checks if the honeytoken is removed from the test pod